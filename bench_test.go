@@ -0,0 +1,58 @@
+package win32metadata
+
+import (
+	"bytes"
+	"debug/pe"
+	"testing"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func BenchmarkWin32_TypeDefs(b *testing.B) {
+	backends := []struct {
+		name string
+		open func() (*types.Context, error)
+	}{
+		{"Reader", func() (*types.Context, error) {
+			f, err := pe.NewFile(bytes.NewReader(win32))
+			if err != nil {
+				return nil, err
+			}
+			return types.FromPE(f)
+		}},
+		{"Bytes", func() (*types.Context, error) {
+			return types.FromBytes(win32)
+		}},
+	}
+
+	for _, backend := range backends {
+		b.Run(backend.name, func(b *testing.B) {
+			c, err := backend.open()
+			if err != nil {
+				b.Fatal(err)
+			}
+			table := c.Table(md.TypeDef)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var (
+				def    types.TypeDef
+				fields []types.Field
+			)
+			for i := 0; i < b.N; i++ {
+				for row := uint32(0); row < table.RowCount(); row++ {
+					if err := def.FromRow(table.Row(row)); err != nil {
+						b.Fatal(err)
+					}
+					fields, err = def.ResolveFieldList(c)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			_ = fields
+		})
+	}
+}
@@ -2,7 +2,6 @@
 package main
 
 import (
-	"debug/pe"
 	"flag"
 	"fmt"
 	"os"
@@ -14,39 +13,44 @@ func run() error {
 	fileName := flag.String("file", "", "path to metadata file")
 	methodName := flag.String("method", "", "method to print")
 	typeNamespace := flag.String("namespace", "", "method namespace")
+	goarch := flag.String("arch", "", "target GOARCH (386, amd64 or arm64), any architecture if empty")
 	flag.Parse()
 
 	if *methodName == "" {
 		return fmt.Errorf("invalid method name: %q", *methodName)
 	}
 
-	file, err := pe.Open(*fileName)
-	if err != nil {
-		return fmt.Errorf("open PE file: %w", err)
+	arch := types.ArchitectureAny
+	if *goarch != "" {
+		a, ok := types.ArchitectureOf(*goarch)
+		if !ok {
+			return fmt.Errorf("unsupported architecture: %q", *goarch)
+		}
+		arch = a
 	}
-	defer func() {
-		_ = file.Close()
-	}()
 
-	c, err := types.FromPE(file)
+	c, err := types.Open(*fileName)
 	if err != nil {
-		return fmt.Errorf("parse metadata: %w", err)
+		return fmt.Errorf("open metadata file: %w", err)
 	}
+	defer func() {
+		_ = c.Close()
+	}()
 
-	methodIdx, method, err := findMethod(c, *typeNamespace, *methodName)
+	methodIdx, method, err := findMethod(c, *typeNamespace, *methodName, arch)
 	if err != nil {
 		return err
 	}
 	toPrint := map[types.TypeDefOrRef]types.TypeDef{}
 
-	s, err := printMethod(c, methodIdx, method, toPrint)
+	s, err := printMethod(c, arch, methodIdx, method, toPrint)
 	if err != nil {
 		return fmt.Errorf("print method %q: %w", method.Name, err)
 	}
 	fmt.Println(s)
 
 	for _, def := range toPrint {
-		s, err := printTypeDef(c, def, toPrint)
+		s, err := printTypeDef(c, arch, def, toPrint)
 		if err != nil {
 			return fmt.Errorf("print type %q: %w", def.TypeName, err)
 		}
@@ -12,6 +12,7 @@ import (
 
 func queueTypeDefs(
 	ctx *types.Context,
+	arch types.Architecture,
 	idx types.TypeDefOrRef,
 	toPrint map[types.TypeDefOrRef]types.TypeDef,
 ) (namespace, name string, err error) {
@@ -35,7 +36,7 @@ func queueTypeDefs(
 		return def.TypeNamespace, def.TypeName, nil
 	}
 
-	d, err := resolveTypeDef(ctx, idx)
+	d, err := resolveTypeDef(ctx, idx, arch)
 	if err != nil {
 		return "", "", err
 	}
@@ -60,7 +61,7 @@ func queueTypeDefs(
 			continue
 		}
 
-		if _, _, err := queueTypeDefs(ctx, typ.TypeDef.Index, toPrint); err != nil {
+		if _, _, err := queueTypeDefs(ctx, arch, typ.TypeDef.Index, toPrint); err != nil {
 			return "", "", err
 		}
 	}
@@ -70,6 +71,7 @@ func queueTypeDefs(
 
 func printTypeDef(
 	c *types.Context,
+	arch types.Architecture,
 	def types.TypeDef,
 	toPrint map[types.TypeDefOrRef]types.TypeDef,
 ) (string, error) {
@@ -96,7 +98,7 @@ func printTypeDef(
 		if err != nil {
 			return "", err
 		}
-		_, fieldType, err := printName(c, sig.Field, toPrint)
+		_, fieldType, err := printName(c, arch, sig.Field, toPrint)
 		if err != nil {
 			return "", err
 		}
@@ -117,6 +119,7 @@ func printTypeDef(
 
 func printName(
 	ctx *types.Context,
+	arch types.Architecture,
 	e types.Element,
 	toPrint map[types.TypeDefOrRef]types.TypeDef,
 ) (namespace, name string, err error) {
@@ -150,7 +153,7 @@ func printName(
 	case types.ELEMENT_TYPE_STRING:
 		name = "string"
 	case types.ELEMENT_TYPE_VALUETYPE, types.ELEMENT_TYPE_CLASS:
-		namespace, name, err = queueTypeDefs(ctx, e.Type.TypeDef.Index, toPrint)
+		namespace, name, err = queueTypeDefs(ctx, arch, e.Type.TypeDef.Index, toPrint)
 		if err != nil {
 			return "", "", err
 		}
@@ -160,6 +163,8 @@ func printName(
 		}
 	case types.ELEMENT_TYPE_OBJECT:
 		name = "Object"
+	case types.ELEMENT_TYPE_FNPTR:
+		name = "uintptr"
 	case types.ELEMENT_TYPE_GENERICINST:
 		_, genericName, err := ctx.ResolveTypeDefOrRefName(e.Type.TypeDef.Index)
 		if err != nil {
@@ -172,7 +177,7 @@ func printName(
 		name += "<"
 
 		for i, arg := range e.Type.TypeDef.Generics {
-			_, argName, err := printName(ctx, types.Element{
+			_, argName, err := printName(ctx, arch, types.Element{
 				Type: arg,
 			}, toPrint)
 			if err != nil {
@@ -186,14 +191,17 @@ func printName(
 		}
 		name += ">"
 	case types.ELEMENT_TYPE_ARRAY:
-		ns, elemName, err := printName(ctx, *e.Type.Array.Elem, toPrint)
+		ns, elemName, err := printName(ctx, arch, *e.Type.Array.Elem, toPrint)
 		if err != nil {
 			return "", "", err
 		}
-		name = fmt.Sprintf("%s[%d]", elemName, e.Type.Array.Size)
+		name = elemName
+		for _, size := range e.Type.Array.Shape.Sizes {
+			name += fmt.Sprintf("[%d]", size)
+		}
 		namespace = ns
 	case types.ELEMENT_TYPE_SZARRAY:
-		ns, elemName, err := printName(ctx, *e.Type.SZArray.Elem, toPrint)
+		ns, elemName, err := printName(ctx, arch, *e.Type.SZArray.Elem, toPrint)
 		if err != nil {
 			return "", "", err
 		}
@@ -229,6 +237,7 @@ func collectParamNames(c *types.Context, def types.MethodDef) (map[int]string, e
 
 func printMethod(
 	ctx *types.Context,
+	arch types.Architecture,
 	methodIdx uint32,
 	def types.MethodDef,
 	toPrint map[types.TypeDefOrRef]types.TypeDef,
@@ -275,7 +284,7 @@ func printMethod(
 
 			log.WriteByte(' ')
 
-			_, typeName, err := printName(ctx, method.Params[i], toPrint)
+			_, typeName, err := printName(ctx, arch, method.Params[i], toPrint)
 			if err != nil {
 				return "", err
 			}
@@ -285,7 +294,7 @@ func printMethod(
 	}
 	log.WriteString(") ")
 
-	_, typeName, err := printName(ctx, method.Return, toPrint)
+	_, typeName, err := printName(ctx, arch, method.Return, toPrint)
 	if err != nil {
 		return "", err
 	}
@@ -303,33 +312,21 @@ type dynamicImport struct {
 var errImportNotFound = errors.New("import not found")
 
 func findMethodDLLImport(ctx *types.Context, idx uint32) (dynamicImport, error) {
-	table := ctx.Table(md.ImplMap)
-	var implMap types.ImplMap
-
-	for i := uint32(0); i < table.RowCount(); i++ {
-		if err := implMap.FromRow(table.Row(i)); err != nil {
-			return dynamicImport{}, err
-		}
-		member := implMap.MemberForwarded
-
-		if tt, ok := member.Table(); !ok || tt != md.MethodDef {
-			continue
-		}
-
-		if member.TableIndex() != idx {
-			continue
-		}
-
-		module, err := implMap.ResolveImportScope(ctx)
-		if err != nil {
-			return dynamicImport{}, err
-		}
+	implMap, ok, err := ctx.ImplMap(types.CreateMemberForwarded(md.MethodDef, idx))
+	if err != nil {
+		return dynamicImport{}, err
+	}
+	if !ok {
+		return dynamicImport{}, errImportNotFound
+	}
 
-		return dynamicImport{
-			DLLName:     module.Name,
-			RoutineName: implMap.ImportName,
-		}, nil
+	module, err := implMap.ResolveImportScope(ctx)
+	if err != nil {
+		return dynamicImport{}, err
 	}
 
-	return dynamicImport{}, errImportNotFound
+	return dynamicImport{
+		DLLName:     module.Name,
+		RoutineName: implMap.ImportName,
+	}, nil
 }
@@ -0,0 +1,75 @@
+// Command winmdlint checks metadata file against ECMA-335 validation rules.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tdakkota/win32metadata/types"
+	"github.com/tdakkota/win32metadata/validate"
+)
+
+func run() (failed bool, _ error) {
+	fileName := flag.String("file", "", "path to metadata file")
+	jsonOutput := flag.Bool("json", false, "print diagnostics as JSON array")
+	win32 := flag.Bool("win32", false, "check Win32 metadata conventions")
+	flag.Parse()
+
+	if *fileName == "" && flag.NArg() > 0 {
+		*fileName = flag.Arg(0)
+	}
+	if *fileName == "" {
+		return false, fmt.Errorf("no metadata file given")
+	}
+
+	c, err := types.Open(*fileName)
+	if err != nil {
+		return false, fmt.Errorf("open metadata file: %w", err)
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	diags, err := validate.Validate(c, validate.Options{
+		Win32: *win32,
+	})
+	if err != nil {
+		return false, fmt.Errorf("validate: %w", err)
+	}
+
+	if *jsonOutput {
+		if diags == nil {
+			diags = []validate.Diagnostic{}
+		}
+		e := json.NewEncoder(os.Stdout)
+		e.SetIndent("", "\t")
+		if err := e.Encode(diags); err != nil {
+			return false, err
+		}
+	} else {
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+	}
+
+	for _, d := range diags {
+		if d.Severity == validate.Error {
+			failed = true
+		}
+	}
+	return failed, nil
+}
+
+func main() {
+	failed, err := run()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+		return
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,63 @@
+package win32metadata
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func TestCustomAttribute_Decode(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	var (
+		tt   = c.Table(md.CustomAttribute)
+		attr types.CustomAttribute
+		// IID_IUnknown, 00000000-0000-0000-C000-000000000046.
+		found bool
+		// BCL enums, e.g. CallingConvention, are defined in mscorlib, which is not loaded.
+		d = types.CustomAttributeDecoder{
+			ResolveEnum: func(namespace, name string) (types.ElementTypeKind, error) {
+				if !strings.HasPrefix(namespace, "System.") {
+					return 0, fmt.Errorf("unexpected enum %s.%s", namespace, name)
+				}
+				return types.ELEMENT_TYPE_I4, nil
+			},
+		}
+	)
+	for i := uint32(0); i < tt.RowCount(); i++ {
+		a.NoError(attr.FromRow(tt.Row(i)))
+
+		namespace, name, _, err := attr.ResolveConstructor(c)
+		a.NoError(err)
+
+		v, err := d.Decode(c, &attr)
+		a.NoError(err, "%s.%s", namespace, name)
+
+		if name != "GuidAttribute" {
+			continue
+		}
+		parent, ok := attr.Parent.Row(c)
+		a.True(ok)
+		if parent.Table.Type != md.TypeDef {
+			continue
+		}
+		typeName, err := parent.String(1)
+		a.NoError(err)
+		if typeName != "IUnknown" {
+			continue
+		}
+
+		a.Len(v.Fixed, 11)
+		a.Equal(uint32(0), v.Fixed[0].Value)
+		a.Equal(uint8(0xC0), v.Fixed[3].Value)
+		a.Equal(uint8(0x46), v.Fixed[10].Value)
+		found = true
+	}
+	a.True(found, "GuidAttribute of IUnknown not found")
+}
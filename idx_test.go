@@ -17,6 +17,15 @@ var (
 	win32 []byte
 )
 
+func openWin32(a *require.Assertions) *types.Context {
+	f, err := pe.NewFile(bytes.NewReader(win32))
+	a.NoError(err)
+
+	c, err := types.FromPE(f)
+	a.NoError(err)
+	return c
+}
+
 func TestIssue33(t *testing.T) {
 	a := require.New(t)
 
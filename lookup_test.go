@@ -0,0 +1,95 @@
+package win32metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func findTypeDef(a *require.Assertions, c *types.Context, namespace, name string) (types.Index, types.TypeDef) {
+	idx, ok, err := c.FindTypeDef(namespace, name)
+	a.NoError(err)
+	a.True(ok, "%s.%s", namespace, name)
+
+	var def types.TypeDef
+	a.NoError(def.FromRow(c.Table(md.TypeDef).Row(idx)))
+	return idx, def
+}
+
+func TestContext_Lookup(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	a.True(c.IsSorted(md.CustomAttribute))
+	a.True(c.IsSorted(md.InterfaceImpl))
+
+	idx, _ := findTypeDef(a, c, "Windows.Win32.UI.Shell", "IShellItemArray")
+
+	impls, err := c.InterfaceImpls(idx)
+	a.NoError(err)
+	a.Len(impls, 1)
+	impl, err := types.Get[types.InterfaceImpl](c, impls[0])
+	a.NoError(err)
+	ns, name, err := c.ResolveTypeDefOrRefName(impl.Interface)
+	a.NoError(err)
+	a.Equal("Windows.Win32.System.Com", ns)
+	a.Equal("IUnknown", name)
+
+	attrs, err := c.CustomAttributes(types.CreateHasCustomAttribute(md.TypeDef, idx))
+	a.NoError(err)
+	var names []string
+	for _, attr := range attrs {
+		_, name, _, err := attr.ResolveConstructor(c)
+		a.NoError(err)
+		names = append(names, name)
+	}
+	a.Contains(names, "GuidAttribute")
+
+	params, err := c.GenericParams(types.CreateTypeOrMethodDef(md.TypeDef, idx))
+	a.NoError(err)
+	a.Empty(params)
+
+	// Every ImplMap row must be found by its member.
+	tt := c.Table(md.ImplMap)
+	var implMap types.ImplMap
+	for i := uint32(0); i < tt.RowCount(); i++ {
+		a.NoError(implMap.FromRow(tt.Row(i)))
+
+		found, ok, err := c.ImplMap(implMap.MemberForwarded)
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(implMap, found)
+	}
+}
+
+func TestContext_FieldConstant(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	_, def := findTypeDef(a, c, "Windows.Win32.System.Console", "CONSOLE_MODE")
+
+	var (
+		fields = c.Table(md.Field)
+		field  types.Field
+		values = map[string]interface{}{}
+	)
+	for i := def.FieldList.Start(); i < def.FieldList.End(); i++ {
+		// FieldList may point to FieldPtr table.
+		idx, err := c.ListIndex(md.Field, i)
+		a.NoError(err)
+		a.NoError(field.FromRow(fields.Row(idx)))
+
+		v, ok, err := c.FieldConstant(idx)
+		a.NoError(err)
+		if field.Flags.Literal() {
+			a.True(ok, field.Name)
+			values[field.Name] = v
+		} else {
+			a.False(ok, field.Name)
+		}
+	}
+	a.Equal(uint32(0x200), values["ENABLE_VIRTUAL_TERMINAL_INPUT"])
+}
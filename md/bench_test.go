@@ -0,0 +1,122 @@
+package md
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+const benchRows = 10000
+
+func benchMetadata(b *testing.B) []byte {
+	var (
+		strs   StringHeapBuilder
+		blobs  BlobHeapBuilder
+		tables TablesBuilder
+	)
+	tables.Rows[Module] = []RowValues{{0, uint64(strs.Add("Bench.winmd"))}}
+	namespace := uint64(strs.Add("Windows.Win32.System.Benchmark"))
+	for i := 0; i < benchRows; i++ {
+		name := uint64(strs.Add(fmt.Sprintf("BENCHMARK_TYPE_%d", i)))
+		tables.Rows[TypeDef] = append(tables.Rows[TypeDef], RowValues{0x1, name, namespace, 0, uint64(i + 1), 1})
+
+		sig := uint64(blobs.Add([]byte{0x06, 0x11, byte(i>>8) | 0x80, byte(i)}))
+		tables.Rows[Field] = append(tables.Rows[Field], RowValues{0x6, name, sig})
+	}
+
+	var (
+		stringsHeap = strs.Bytes()
+		blobHeap    = blobs.Bytes()
+	)
+	tables.HeapSizes = HeapSizes(len(stringsHeap), 0, len(blobHeap))
+	data, err := tables.Bytes()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return EncodeMetadata("v4.0.30319",
+		Stream{Name: "#~", Data: data},
+		Stream{Name: "#Strings", Data: stringsHeap},
+		Stream{Name: "#Blob", Data: blobHeap},
+	)
+}
+
+// benchBackends runs given benchmark using io.ReaderAt and Bytes backends.
+func benchBackends(b *testing.B, f func(b *testing.B, m *Metadata, h TablesHeader, r io.ReaderAt)) {
+	data := benchMetadata(b)
+	backends := []struct {
+		name  string
+		parse func() (*Metadata, error)
+	}{
+		{"Reader", func() (*Metadata, error) {
+			return ParseMetadataAt(bytes.NewReader(data), int64(len(data)))
+		}},
+		{"Bytes", func() (*Metadata, error) {
+			return ParseMetadataBytes(data)
+		}},
+	}
+
+	for _, backend := range backends {
+		b.Run(backend.name, func(b *testing.B) {
+			m, err := backend.parse()
+			if err != nil {
+				b.Fatal(err)
+			}
+			h, section, err := m.Tables()
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var r io.ReaderAt = section
+			if data, ok := BytesOf(section); ok {
+				r = data
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			f(b, m, h, r)
+		})
+	}
+}
+
+func BenchmarkTable_Uint32(b *testing.B) {
+	benchBackends(b, func(b *testing.B, m *Metadata, h TablesHeader, r io.ReaderAt) {
+		table := h.Tables[TypeDef]
+		for i := 0; i < b.N; i++ {
+			if _, err := table.Uint32(r, uint32(i%benchRows), 1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMetadata_ReadString(b *testing.B) {
+	benchBackends(b, func(b *testing.B, m *Metadata, h TablesHeader, r io.ReaderAt) {
+		table := h.Tables[TypeDef]
+		for i := 0; i < b.N; i++ {
+			idx, err := table.Uint64(r, uint32(i%benchRows), 1)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := m.ReadString(idx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMetadata_ReadBlob(b *testing.B) {
+	benchBackends(b, func(b *testing.B, m *Metadata, h TablesHeader, r io.ReaderAt) {
+		table := h.Tables[Field]
+		for i := 0; i < b.N; i++ {
+			idx, err := table.Uint64(r, uint32(i%benchRows), 2)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := m.ReadBlob(idx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package md
+
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// Bytes is an in-memory data, which implements io.ReaderAt.
+//
+// Metadata and tables backed by Bytes are read without copying: column reads
+// are slice indexing, strings and blobs reference underlying data, so it must
+// not be modified while Metadata is in use.
+type Bytes []byte
+
+// ReadAt implements io.ReaderAt.
+func (b Bytes) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off >= int64(len(b)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, b[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// BytesOf returns underlying in-memory data of given reader.
+//
+// Reader is either Bytes or io.SectionReader over Bytes.
+// If reader is not backed by memory, ok is false.
+func BytesOf(r io.ReaderAt) (_ Bytes, ok bool) {
+	switch r := r.(type) {
+	case Bytes:
+		return r, true
+	case *io.SectionReader:
+		outer, off, n := r.Outer()
+		b, ok := BytesOf(outer)
+		if !ok || off < 0 || n < 0 || off+n > int64(len(b)) {
+			return nil, false
+		}
+		return b[off : off+n : off+n], true
+	default:
+		return nil, false
+	}
+}
+
+// unsafeString returns string which references given bytes.
+func unsafeString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,23 @@
+package md
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrTruncated is returned when data ends unexpectedly.
+	ErrTruncated = errors.New("truncated data")
+	// ErrOutOfBounds is returned when offset or index points outside of
+	// section, stream, heap or table.
+	ErrOutOfBounds = errors.New("out of bounds")
+)
+
+// truncated wraps io.EOF and io.ErrUnexpectedEOF errors with ErrTruncated.
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrTruncated, err)
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package md
+
+import (
+	"bytes"
+	"debug/pe"
+	"testing"
+)
+
+func fuzzTables(f *testing.F) []byte {
+	var b TablesBuilder
+	b.Sorted = 1 << NestedClass
+	b.Rows[Module] = []RowValues{{0, 1, 1}}
+	b.Rows[TypeDef] = []RowValues{
+		{0, 1, 0, 0, 1, 1},
+		{0x1, 12, 1, 0, 1, 1},
+	}
+	b.Rows[Field] = []RowValues{{0x16, 1, 1}}
+	b.Rows[NestedClass] = []RowValues{{2, 1}}
+
+	data, err := b.Bytes()
+	if err != nil {
+		f.Fatal(err)
+	}
+	return data
+}
+
+func fuzzMetadata(f *testing.F) []byte {
+	var (
+		strs  StringHeapBuilder
+		blobs BlobHeapBuilder
+		guids GUIDHeapBuilder
+		us    UserStringHeapBuilder
+	)
+	strs.Add("Test.winmd")
+	strs.Add("Foo")
+	blobs.Add([]byte{0x06, 0x08})
+	guids.Add(GUID{1, 2, 3})
+	us.Add("Hello")
+
+	return EncodeMetadata("WindowsRuntime 1.4",
+		Stream{Name: "#~", Data: fuzzTables(f)},
+		Stream{Name: "#Strings", Data: strs.Bytes()},
+		Stream{Name: "#US", Data: us.Bytes()},
+		Stream{Name: "#GUID", Data: guids.Bytes()},
+		Stream{Name: "#Blob", Data: blobs.Bytes()},
+	)
+}
+
+// readAll reads every column of every table and heap values they point to.
+func readAll(m *Metadata) {
+	h, section, err := m.Tables()
+	if err != nil {
+		return
+	}
+
+	for _, table := range &h.Tables {
+		for row := uint32(0); row < table.RowCount; row++ {
+			for column := range table.Columns {
+				if table.Columns[column].Zero() {
+					continue
+				}
+				v, err := table.Uint64(section, row, uint32(column))
+				if err != nil {
+					continue
+				}
+				_, _ = m.ReadString(v)
+				_, _ = m.ReadBlob(v)
+				_, _ = m.ReadGUID(v)
+				_, _ = m.ReadUserString(v)
+			}
+		}
+	}
+}
+
+func FuzzParseMetadata(f *testing.F) {
+	var buf bytes.Buffer
+	if err := WritePE(&buf, fuzzMetadata(f)); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		file, err := pe.NewFile(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		m, err := ParseMetadata(file)
+		if err != nil {
+			return
+		}
+		readAll(m)
+	})
+}
+
+func FuzzParseMetadataBytes(f *testing.F) {
+	f.Add(fuzzMetadata(f))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := ParseMetadataBytes(data)
+		if err != nil {
+			return
+		}
+		_, _, _ = m.Pdb()
+		readAll(m)
+	})
+}
+
+func FuzzTablesHeader_Decode(f *testing.F) {
+	f.Add(fuzzTables(f))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var h TablesHeader
+		if err := h.Decode(bytes.NewReader(data)); err != nil {
+			return
+		}
+
+		r := bytes.NewReader(data)
+		for _, table := range &h.Tables {
+			if table.RowCount == 0 {
+				continue
+			}
+			// Row count is not checked by Decode, read only first and last rows.
+			for _, row := range []uint32{0, table.RowCount - 1, table.RowCount} {
+				_, _ = table.Uint64(r, row, 0)
+			}
+		}
+	})
+}
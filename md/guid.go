@@ -0,0 +1,29 @@
+package md
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// GUID is a 16-byte value from #GUID heap.
+//
+// See II.24.2.5 #GUID heap.
+type GUID [16]byte
+
+// Zero denotes that GUID is zero (null) value.
+func (g GUID) Zero() bool {
+	return g == GUID{}
+}
+
+// String returns canonical GUID form, like "00000000-0000-0000-c000-000000000046".
+//
+// The first three groups are stored as little-endian integers.
+func (g GUID) String() string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(g[0:4]),
+		binary.LittleEndian.Uint16(g[4:6]),
+		binary.LittleEndian.Uint16(g[6:8]),
+		g[8:10],
+		g[10:16],
+	)
+}
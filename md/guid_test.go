@@ -0,0 +1,22 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGUID_String(t *testing.T) {
+	a := require.New(t)
+
+	// IID_IUnknown.
+	g := GUID{0, 0, 0, 0, 0, 0, 0, 0, 0xc0, 0, 0, 0, 0, 0, 0, 0x46}
+	a.Equal("00000000-0000-0000-c000-000000000046", g.String())
+	a.False(g.Zero())
+
+	// IID_IInspectable.
+	g = GUID{0xe0, 0xe2, 0x86, 0xaf, 0x2d, 0xb1, 0x6a, 0x4c, 0x9c, 0x5a, 0xd7, 0xaa, 0x65, 0x10, 0x1e, 0x90}
+	a.Equal("af86e2e0-b12d-4c6a-9c5a-d7aa65101e90", g.String())
+
+	a.True(GUID{}.Zero())
+}
@@ -0,0 +1,178 @@
+package md
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// appendCompressed appends II.23.2 compressed unsigned integer.
+func appendCompressed(buf []byte, v uint32) []byte {
+	switch {
+	case v < 0x80:
+		return append(buf, byte(v))
+	case v < 0x4000:
+		return binary.BigEndian.AppendUint16(buf, uint16(v)|0x8000)
+	default:
+		return binary.BigEndian.AppendUint32(buf, v|0xC000_0000)
+	}
+}
+
+// alignBytes pads given buffer with zeroes to 4-byte boundary.
+func alignBytes(buf []byte) []byte {
+	for len(buf)%4 != 0 {
+		buf = append(buf, 0)
+	}
+	return buf
+}
+
+// StringHeapBuilder builds II.24.2.3 #Strings heap.
+//
+// Zero value is ready to use.
+type StringHeapBuilder struct {
+	buf   []byte
+	index map[string]uint32
+}
+
+// Add adds string to heap and returns its index.
+// Equal strings share the same index, empty string is always 0.
+func (b *StringHeapBuilder) Add(s string) uint32 {
+	if s == "" {
+		return 0
+	}
+	if idx, ok := b.index[s]; ok {
+		return idx
+	}
+	if b.index == nil {
+		b.index = map[string]uint32{}
+	}
+	if len(b.buf) == 0 {
+		b.buf = append(b.buf, 0)
+	}
+
+	idx := uint32(len(b.buf))
+	b.buf = append(b.buf, s...)
+	b.buf = append(b.buf, 0)
+	b.index[s] = idx
+	return idx
+}
+
+// Bytes returns heap data, padded to 4-byte boundary.
+func (b *StringHeapBuilder) Bytes() []byte {
+	if len(b.buf) == 0 {
+		return alignBytes([]byte{0})
+	}
+	return alignBytes(append([]byte(nil), b.buf...))
+}
+
+// BlobHeapBuilder builds II.24.2.4 #Blob heap.
+//
+// Zero value is ready to use.
+type BlobHeapBuilder struct {
+	buf   []byte
+	index map[string]uint32
+}
+
+// Add adds blob to heap and returns its index.
+// Equal blobs share the same index, empty blob is always 0.
+func (b *BlobHeapBuilder) Add(data []byte) uint32 {
+	if len(data) == 0 {
+		return 0
+	}
+	return b.add(data)
+}
+
+func (b *BlobHeapBuilder) add(data []byte) uint32 {
+	if idx, ok := b.index[string(data)]; ok {
+		return idx
+	}
+	if b.index == nil {
+		b.index = map[string]uint32{}
+	}
+	if len(b.buf) == 0 {
+		b.buf = append(b.buf, 0)
+	}
+
+	idx := uint32(len(b.buf))
+	b.buf = appendCompressed(b.buf, uint32(len(data)))
+	b.buf = append(b.buf, data...)
+	b.index[string(data)] = idx
+	return idx
+}
+
+// Bytes returns heap data, padded to 4-byte boundary.
+func (b *BlobHeapBuilder) Bytes() []byte {
+	if len(b.buf) == 0 {
+		return alignBytes([]byte{0})
+	}
+	return alignBytes(append([]byte(nil), b.buf...))
+}
+
+// UserStringHeapBuilder builds II.24.2.4 #US heap.
+//
+// Zero value is ready to use.
+type UserStringHeapBuilder struct {
+	blobs BlobHeapBuilder
+}
+
+// Add adds string to heap and returns its index.
+// Equal strings share the same index.
+func (b *UserStringHeapBuilder) Add(s string) uint32 {
+	var (
+		data    []byte
+		special byte
+	)
+	for _, c := range utf16.Encode([]rune(s)) {
+		data = binary.LittleEndian.AppendUint16(data, c)
+
+		// II.24.2.4 #US and #Blob heaps
+		//
+		// This final byte holds the value 1 if and only if any UTF16 character within
+		// the string has any bit set in its top byte, or its low byte is any of the following:
+		// 0x01–0x08, 0x0E–0x1F, 0x27, 0x2D, 0x7F. Otherwise, it holds 0.
+		switch low := c & 0xFF; {
+		case c > 0xFF,
+			low >= 0x01 && low <= 0x08,
+			low >= 0x0E && low <= 0x1F,
+			low == 0x27, low == 0x2D, low == 0x7F:
+			special = 1
+		}
+	}
+	return b.blobs.add(append(data, special))
+}
+
+// Bytes returns heap data, padded to 4-byte boundary.
+func (b *UserStringHeapBuilder) Bytes() []byte {
+	return b.blobs.Bytes()
+}
+
+// GUIDHeapBuilder builds II.24.2.5 #GUID heap.
+//
+// Zero value is ready to use.
+type GUIDHeapBuilder struct {
+	buf   []byte
+	index map[GUID]uint32
+}
+
+// Add adds GUID to heap and returns its 1-based index.
+// Equal GUIDs share the same index, Zero GUID is always 0.
+func (b *GUIDHeapBuilder) Add(g GUID) uint32 {
+	if g.Zero() {
+		return 0
+	}
+	if idx, ok := b.index[g]; ok {
+		return idx
+	}
+	if b.index == nil {
+		b.index = map[GUID]uint32{}
+	}
+
+	b.buf = append(b.buf, g[:]...)
+	idx := uint32(len(b.buf) / len(g))
+	b.index[g] = idx
+	return idx
+}
+
+// Bytes returns heap data.
+func (b *GUIDHeapBuilder) Bytes() []byte {
+	return append([]byte(nil), b.buf...)
+}
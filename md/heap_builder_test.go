@@ -0,0 +1,142 @@
+package md
+
+import (
+	"bytes"
+	"debug/pe"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeapBuilders(t *testing.T) {
+	a := require.New(t)
+
+	var (
+		strs        StringHeapBuilder
+		blobs       BlobHeapBuilder
+		guids       GUIDHeapBuilder
+		userStrings UserStringHeapBuilder
+	)
+	a.Equal(uint32(0), strs.Add(""))
+	a.Equal(uint32(1), strs.Add("Foo"))
+	a.Equal(uint32(5), strs.Add("Bar"))
+	a.Equal(uint32(1), strs.Add("Foo"))
+	a.Equal([]byte("\x00Foo\x00Bar\x00\x00\x00\x00"), strs.Bytes())
+
+	long := bytes.Repeat([]byte{'a'}, 0x80)
+	a.Equal(uint32(0), blobs.Add(nil))
+	a.Equal(uint32(1), blobs.Add([]byte{1, 2}))
+	a.Equal(uint32(4), blobs.Add(long))
+	a.Equal(uint32(1), blobs.Add([]byte{1, 2}))
+
+	g := GUID{1, 2, 3}
+	a.Equal(uint32(0), guids.Add(GUID{}))
+	a.Equal(uint32(1), guids.Add(g))
+	a.Equal(uint32(2), guids.Add(GUID{4}))
+	a.Equal(uint32(1), guids.Add(g))
+
+	hello := userStrings.Add("Hello")
+	a.Equal(hello, userStrings.Add("Hello"))
+	special := userStrings.Add("It's")
+
+	tables := TablesBuilder{}
+	tablesData, err := tables.Bytes()
+	a.NoError(err)
+
+	m, err := ParseMetadataBytes(EncodeMetadata("v4.0.30319",
+		Stream{Name: "#~", Data: tablesData},
+		Stream{Name: "#Strings", Data: strs.Bytes()},
+		Stream{Name: "#US", Data: userStrings.Bytes()},
+		Stream{Name: "#GUID", Data: guids.Bytes()},
+		Stream{Name: "#Blob", Data: blobs.Bytes()},
+	))
+	a.NoError(err)
+	a.Equal("v4.0.30319", m.Version)
+	a.Len(m.StreamHeaders, 5)
+
+	s, err := m.ReadString(5)
+	a.NoError(err)
+	a.Equal("Bar", s)
+
+	b, err := m.ReadBlob(4)
+	a.NoError(err)
+	a.Equal(long, b)
+
+	rg, err := m.ReadGUID(1)
+	a.NoError(err)
+	a.Equal(g, rg)
+
+	us, err := m.ReadUserString(uint64(hello))
+	a.NoError(err)
+	a.Equal("Hello", us)
+
+	us, err = m.ReadUserString(uint64(special))
+	a.NoError(err)
+	a.Equal("It's", us)
+
+	heap, ok := m.findStreamHeader("#US")
+	a.True(ok)
+	raw, err := m.readBlob(heap, uint64(special))
+	a.NoError(err)
+	a.Equal(byte(1), raw[len(raw)-1])
+}
+
+func TestTablesBuilder(t *testing.T) {
+	a := require.New(t)
+
+	var b TablesBuilder
+	b.Rows[Module] = []RowValues{{0, 1, 1}}
+	b.Rows[TypeDef] = []RowValues{{0, 1, 0, 0, 1, 1}}
+
+	data, err := b.Bytes()
+	a.NoError(err)
+	a.Zero(len(data) % 4)
+
+	var h TablesHeader
+	a.NoError(h.Decode(bytes.NewReader(data)))
+	a.Equal(uint64(1<<Module|1<<TypeDef), h.Valid)
+	a.Equal(uint32(1), h.Tables[TypeDef].RowCount)
+
+	v, err := h.Tables[TypeDef].Uint32(bytes.NewReader(data), 0, 4)
+	a.NoError(err)
+	a.Equal(uint32(1), v)
+
+	// Value does not fit into column.
+	b.Rows[Module][0][1] = 1 << 16
+	_, err = b.Bytes()
+	a.Error(err)
+}
+
+func TestWritePE(t *testing.T) {
+	a := require.New(t)
+
+	var b TablesBuilder
+	b.Rows[Module] = []RowValues{{0, 1, 0}}
+	tables, err := b.Bytes()
+	a.NoError(err)
+
+	var strs StringHeapBuilder
+	strs.Add("Test.winmd")
+
+	var buf bytes.Buffer
+	a.NoError(WritePE(&buf, EncodeMetadata("WindowsRuntime 1.4",
+		Stream{Name: "#~", Data: tables},
+		Stream{Name: "#Strings", Data: strs.Bytes()},
+	)))
+
+	f, err := pe.NewFile(bytes.NewReader(buf.Bytes()))
+	a.NoError(err)
+
+	m, err := ParseMetadata(f)
+	a.NoError(err)
+	a.Equal("WindowsRuntime 1.4", m.Version)
+
+	h, section, err := m.Tables()
+	a.NoError(err)
+	idx, err := h.Tables[Module].Uint64(section, 0, 1)
+	a.NoError(err)
+
+	name, err := m.ReadString(idx)
+	a.NoError(err)
+	a.Equal("Test.winmd", name)
+}
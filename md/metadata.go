@@ -1,19 +1,26 @@
 package md
 
 import (
+	"bytes"
 	"debug/pe"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf16"
 )
 
 // Metadata is a simple wrapper around MetadataRoot to access
 // metadata streams.
+//
+// Metadata is safe for concurrent use by multiple goroutines.
 type Metadata struct {
 	r *io.SectionReader
+	// Metadata data, if it is backed by Bytes.
+	data Bytes
 	// Cache strings from file to prevent allocations.
-	strings map[uint64]string
+	strings *stringCache
 	MetadataRoot
 }
 
@@ -33,102 +40,263 @@ func (m *Metadata) StreamByName(name string) (*io.SectionReader, error) {
 		return nil, fmt.Errorf("section %q not found", name)
 	}
 
-	return io.NewSectionReader(m.r, int64(shr.Offset), int64(shr.Size)), nil
+	return m.section(shr), nil
+}
+
+// section returns reader of given stream.
+func (m *Metadata) section(h StreamHeader) *io.SectionReader {
+	return io.NewSectionReader(m.r, int64(h.Offset), int64(h.Size))
+}
+
+// streamData returns data of given stream, if Metadata is backed by Bytes.
+func (m *Metadata) streamData(h StreamHeader) (Bytes, bool) {
+	if m.data == nil {
+		return nil, false
+	}
+	// Stream bounds are checked by newMetadata.
+	start, end := int64(h.Offset), int64(h.Offset)+int64(h.Size)
+	return m.data[start:end:end], true
 }
 
 // Tables decodes metadata tables header and returns it and table data reader.
+//
+// Both optimized "#~" and uncompressed "#-" streams are supported.
+// If metadata contains "#Pdb" stream, referenced type system row counts are used
+// to compute index sizes.
 func (m *Metadata) Tables() (TablesHeader, *io.SectionReader, error) {
 	section, err := m.StreamByName("#~")
 	if err != nil {
-		return TablesHeader{}, nil, err
+		if _, ok := m.findStreamHeader("#-"); !ok {
+			return TablesHeader{}, nil, err
+		}
+
+		section, err = m.StreamByName("#-")
+		if err != nil {
+			return TablesHeader{}, nil, err
+		}
 	}
 
 	var header TablesHeader
+	// Portable PDB tables may reference type system tables of other file.
+	pdb, ok, err := m.Pdb()
+	if err != nil {
+		return TablesHeader{}, nil, err
+	}
+	if ok {
+		header.ExternalRowCounts = pdb.TypeSystemTableRows
+	}
+
 	if err := header.Decode(section); err != nil {
 		return TablesHeader{}, nil, err
 	}
 
+	// Every table must fit into the stream.
+	for _, table := range &header.Tables {
+		end := table.Offset + int64(table.RowCount)*int64(table.RowSize)
+		if table.RowCount > 0 && end > section.Size() {
+			return TablesHeader{}, nil, fmt.Errorf("%v table [%d:%d] does not fit into stream (%d): %w",
+				table.Type, table.Offset, end, section.Size(), ErrOutOfBounds)
+		}
+	}
+
 	return header, section, nil
 }
 
 // ReadString reads string from String heap.
+//
+// If Metadata is backed by Bytes, returned string references underlying data.
 func (m *Metadata) ReadString(idx uint64) (string, error) {
-	if v, ok := m.strings[idx]; ok {
-		return v, nil
-	}
-
 	heap, ok := m.findStreamHeader("#Strings")
 	if !ok {
 		return "", fmt.Errorf("string heap stream not found")
 	}
 
+	if idx >= uint64(heap.Size) {
+		return "", fmt.Errorf("string index %#x is out of heap bounds (%d): %w", idx, heap.Size, ErrOutOfBounds)
+	}
+
+	if data, ok := m.streamData(heap); ok {
+		s := data[idx:]
+		n := bytes.IndexByte(s, 0)
+		if n < 0 {
+			return "", fmt.Errorf("string %#x is not null-terminated: %w", idx, ErrTruncated)
+		}
+		return unsafeString(s[:n]), nil
+	}
+
+	if v, ok := m.strings.Load(idx); ok {
+		return v, nil
+	}
+
 	var (
-		offset = int64(heap.Offset) + int64(idx)
-		one    [1]byte
+		r      = m.section(heap)
+		offset = int64(idx)
+		chunk  [64]byte
 		buf    strings.Builder
 	)
 	for {
-		_, err := m.r.ReadAt(one[:], offset)
-		if err != nil {
-			return "", err
-		}
-		if one[0] == '\x00' {
+		n, err := r.ReadAt(chunk[:], offset)
+		if i := bytes.IndexByte(chunk[:n], 0); i >= 0 {
+			buf.Write(chunk[:i])
 			break
 		}
+		if err != nil {
+			return "", fmt.Errorf("string %#x is not null-terminated: %w", idx, truncated(err))
+		}
 
-		buf.WriteByte(one[0])
-		offset++
+		buf.Write(chunk[:n])
+		offset += int64(n)
 	}
 
 	v := buf.String()
-	m.strings[idx] = v
+	m.strings.Store(idx, v)
 	return v, nil
 }
 
 // ReadBlob reads blob from Blob heap.
+//
+// If Metadata is backed by Bytes, returned blob references underlying data
+// and must not be modified.
 func (m *Metadata) ReadBlob(idx uint64) ([]byte, error) {
-	// TODO(tdakkota): Decode blob lazily using io.Reader/some helper.
-
 	heap, ok := m.findStreamHeader("#Blob")
 	if !ok {
 		return nil, fmt.Errorf("blob heap stream not found")
 	}
+
+	return m.readBlob(heap, idx)
+}
+
+// readBlob reads length-prefixed blob from given heap.
+func (m *Metadata) readBlob(heap StreamHeader, idx uint64) ([]byte, error) {
+	// TODO(tdakkota): Decode blob lazily using io.Reader/some helper.
+	if idx >= uint64(heap.Size) {
+		return nil, fmt.Errorf("blob index %#x is out of %s bounds (%d): %w", idx, heap.Name, heap.Size, ErrOutOfBounds)
+	}
+
 	var (
-		offset = int64(heap.Offset) + int64(idx)
-		buf    = make([]byte, 4)
+		offset = int64(idx)
+		end    = int64(heap.Size)
+		// Compressed length of blob
+		head []byte
 		// Size of blob data
-		blobSize int
+		blobSize int64
 		// Size of length in bytes
 		lenSize int64
 	)
-
-	_, err := m.r.ReadAt(buf, offset)
-	if err != nil {
-		return nil, err
+	data, inMemory := m.streamData(heap)
+	if inMemory {
+		head = data[offset:min(offset+4, end)]
+	} else {
+		// Blob could be shorter than 4 bytes at the end of heap.
+		buf := make([]byte, 4)
+		n, err := m.section(heap).ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		head = buf[:n]
+	}
+	if len(head) < 1 {
+		return nil, fmt.Errorf("blob %#x length: %w", idx, ErrTruncated)
 	}
 
-	switch v := buf[0] >> 5; {
+	// Length is encoded as II.23.2 compressed unsigned integer.
+	switch v := head[0] >> 5; {
 	case v <= 3:
 		lenSize = 1
-		blobSize = int(buf[0] & 0x7f)
 	case v >= 4 && v <= 5:
 		lenSize = 2
-		blobSize = int(binary.LittleEndian.Uint16([]byte{buf[0] & 0x3f, buf[1]}))
 	case v == 6:
 		lenSize = 4
-		blobSize = int(binary.LittleEndian.Uint32([]byte{buf[0] & 0x1f, buf[1], buf[2], buf[3]}))
 	default:
-		return nil, fmt.Errorf("invalid blob length: %d", buf[0])
+		return nil, fmt.Errorf("invalid blob length: %d", head[0])
+	}
+	if int64(len(head)) < lenSize {
+		return nil, fmt.Errorf("blob %#x length: %w", idx, ErrTruncated)
+	}
+	switch lenSize {
+	case 1:
+		blobSize = int64(head[0] & 0x7f)
+	case 2:
+		blobSize = int64(binary.BigEndian.Uint16([]byte{head[0] & 0x3f, head[1]}))
+	default:
+		blobSize = int64(binary.BigEndian.Uint32([]byte{head[0] & 0x1f, head[1], head[2], head[3]}))
 	}
 
-	buf = append(buf[:0], make([]byte, blobSize)...)
-	if _, err := m.r.ReadAt(buf, offset+lenSize); err != nil {
-		return nil, err
+	start := offset + lenSize
+	if start+blobSize > end {
+		return nil, fmt.Errorf("blob [%d:%d] is out of %s bounds (%d): %w", offset, start+blobSize, heap.Name, end, ErrOutOfBounds)
+	}
+
+	if inMemory {
+		return data[start : start+blobSize : start+blobSize], nil
+	}
+
+	buf := make([]byte, blobSize)
+	if _, err := m.section(heap).ReadAt(buf, start); err != nil {
+		return nil, truncated(err)
 	}
 
 	return buf, nil
 }
 
+// ReadGUID reads GUID from GUID heap.
+//
+// GUID heap index is 1-based, zero index denotes null GUID.
+func (m *Metadata) ReadGUID(idx uint64) (GUID, error) {
+	if idx == 0 {
+		return GUID{}, nil
+	}
+
+	heap, ok := m.findStreamHeader("#GUID")
+	if !ok {
+		return GUID{}, fmt.Errorf("GUID heap stream not found")
+	}
+
+	var g GUID
+	if count := uint64(heap.Size) / uint64(len(g)); idx > count {
+		return GUID{}, fmt.Errorf("GUID index %d is out of bounds (%d): %w", idx, count, ErrOutOfBounds)
+	}
+
+	offset := (idx - 1) * uint64(len(g))
+	if data, ok := m.streamData(heap); ok {
+		copy(g[:], data[offset:])
+		return g, nil
+	}
+
+	if _, err := m.section(heap).ReadAt(g[:], int64(offset)); err != nil {
+		return GUID{}, truncated(err)
+	}
+	return g, nil
+}
+
+// ReadUserString reads string from US (user string) heap.
+func (m *Metadata) ReadUserString(idx uint64) (string, error) {
+	heap, ok := m.findStreamHeader("#US")
+	if !ok {
+		return "", fmt.Errorf("user string heap stream not found")
+	}
+
+	buf, err := m.readBlob(heap, idx)
+	if err != nil {
+		return "", err
+	}
+
+	// II.24.2.4 #US and #Blob heaps
+	//
+	// Strings in the #US (user string) heap are encoded using 16-bit Unicode encodings.
+	// The count on each string is the number of bytes (not characters) in the string.
+	// Furthermore, there is an additional terminal byte (so all byte counts are odd, not even).
+	if len(buf)%2 == 1 {
+		buf = buf[:len(buf)-1]
+	}
+
+	s := make([]uint16, len(buf)/2)
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint16(buf[2*i:])
+	}
+	return string(utf16.Decode(s)), nil
+}
+
 // ParseMetadata parses and creates Metadata from given PE file.
 func ParseMetadata(f *pe.File) (*Metadata, error) {
 	cliHeader, err := getCLIHeader(f)
@@ -141,14 +309,49 @@ func ParseMetadata(f *pe.File) (*Metadata, error) {
 		return nil, err
 	}
 
+	return newMetadata(r)
+}
+
+// ParseMetadataAt parses and creates Metadata from given reader.
+//
+// Reader must be positioned at the metadata root, starting with BSJB signature,
+// size is a size of metadata in bytes.
+func ParseMetadataAt(r io.ReaderAt, size int64) (*Metadata, error) {
+	sr := io.NewSectionReader(r, 0, size)
+	if err := checkMagic(sr); err != nil {
+		return nil, err
+	}
+
+	return newMetadata(sr)
+}
+
+// ParseMetadataBytes parses and creates Metadata from given bare metadata blob,
+// starting with BSJB signature, like portable PDB file.
+//
+// Metadata references given data, see Bytes.
+func ParseMetadataBytes(data []byte) (*Metadata, error) {
+	return ParseMetadataAt(Bytes(data), int64(len(data)))
+}
+
+// newMetadata decodes MetadataRoot from given reader, positioned after magic.
+func newMetadata(r *io.SectionReader) (*Metadata, error) {
 	var root MetadataRoot
 	if err := root.Decode(r); err != nil {
 		return nil, err
 	}
 
+	for _, h := range root.StreamHeaders {
+		if end := uint64(h.Offset) + uint64(h.Size); end > uint64(r.Size()) {
+			return nil, fmt.Errorf("stream %q [%d:%d] is out of metadata bounds (%d): %w",
+				h.Name, h.Offset, end, r.Size(), ErrOutOfBounds)
+		}
+	}
+
+	data, _ := BytesOf(r)
 	return &Metadata{
 		r:            r,
-		strings:      map[uint64]string{},
+		data:         data,
+		strings:      &stringCache{},
 		MetadataRoot: root,
 	}, nil
 }
@@ -0,0 +1,156 @@
+package md
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"io"
+)
+
+// Stream is a named metadata stream data.
+type Stream struct {
+	Name string
+	Data []byte
+}
+
+// EncodeMetadata encodes II.24.2.1 metadata root with given version string and streams.
+func EncodeMetadata(version string, streams ...Stream) []byte {
+	versionData := alignBytes(append([]byte(version), 0))
+
+	names := make([][]byte, len(streams))
+	headerSize := 4 + 2 + 2 + 4 + 4 + len(versionData) + 2 + 2
+	for i, s := range streams {
+		names[i] = alignBytes(append([]byte(s.Name), 0))
+		headerSize += 4 + 4 + len(names[i])
+	}
+
+	var buf bytes.Buffer
+	write := func(v interface{}) {
+		// bytes.Buffer never fails.
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	write(uint32(STORAGE_MAGIC_SIG))
+	write(uint16(1)) // MajorVersion
+	write(uint16(1)) // MinorVersion
+	write([4]byte{}) // Reserved
+	write(uint32(len(versionData)))
+	write(versionData)
+	write(uint16(0)) // Flags
+	write(uint16(len(streams)))
+
+	offset := headerSize
+	for i, s := range streams {
+		size := len(alignBytes(append([]byte(nil), s.Data...)))
+		write(uint32(offset))
+		write(uint32(size))
+		write(names[i])
+		offset += size
+	}
+	for _, s := range streams {
+		write(alignBytes(append([]byte(nil), s.Data...)))
+	}
+	return buf.Bytes()
+}
+
+// WritePE writes minimal PE file with CLI header and given metadata.
+//
+// Output is compatible with ParseMetadata.
+func WritePE(w io.Writer, metadata []byte) error {
+	const (
+		fileAlignment    = 0x200
+		sectionAlignment = 0x2000
+		sectionRVA       = sectionAlignment
+		cliHeaderSize    = 72
+		peHeaderOffset   = 0x80
+	)
+	align := func(v, to uint32) uint32 {
+		return (v + to - 1) &^ (to - 1)
+	}
+
+	var (
+		virtualSize = uint32(cliHeaderSize + len(metadata))
+		rawSize     = align(virtualSize, fileAlignment)
+	)
+
+	var buf bytes.Buffer
+	write := func(v interface{}) {
+		// bytes.Buffer never fails.
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+
+	// DOS header.
+	var dos [peHeaderOffset]byte
+	dos[0], dos[1] = 'M', 'Z'
+	binary.LittleEndian.PutUint32(dos[0x3c:], peHeaderOffset)
+	write(dos)
+
+	write([4]byte{'P', 'E', 0, 0})
+	write(pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_I386,
+		NumberOfSections:     1,
+		SizeOfOptionalHeader: uint16(binary.Size(pe.OptionalHeader32{})),
+		Characteristics: pe.IMAGE_FILE_DLL |
+			pe.IMAGE_FILE_32BIT_MACHINE |
+			pe.IMAGE_FILE_EXECUTABLE_IMAGE,
+	})
+
+	// See II.25.2.3.3 PE header data directories.
+	const IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR = (208 - 96) / 8 // 14
+	opt := pe.OptionalHeader32{
+		Magic:                       0x10b,
+		MajorLinkerVersion:          8,
+		SizeOfCode:                  rawSize,
+		BaseOfCode:                  sectionRVA,
+		ImageBase:                   0x400000,
+		SectionAlignment:            sectionAlignment,
+		FileAlignment:               fileAlignment,
+		MajorOperatingSystemVersion: 4,
+		MajorSubsystemVersion:       4,
+		SizeOfImage:                 sectionRVA + align(virtualSize, sectionAlignment),
+		SizeOfHeaders:               fileAlignment,
+		Subsystem:                   pe.IMAGE_SUBSYSTEM_WINDOWS_CUI,
+		DllCharacteristics: pe.IMAGE_DLLCHARACTERISTICS_DYNAMIC_BASE |
+			pe.IMAGE_DLLCHARACTERISTICS_NX_COMPAT |
+			pe.IMAGE_DLLCHARACTERISTICS_NO_SEH,
+		SizeOfStackReserve:  0x100000,
+		SizeOfStackCommit:   0x1000,
+		SizeOfHeapReserve:   0x100000,
+		SizeOfHeapCommit:    0x1000,
+		NumberOfRvaAndSizes: 16,
+	}
+	opt.DataDirectory[IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR] = pe.DataDirectory{
+		VirtualAddress: sectionRVA,
+		Size:           cliHeaderSize,
+	}
+	write(opt)
+
+	write(pe.SectionHeader32{
+		Name:             [8]uint8{'.', 't', 'e', 'x', 't'},
+		VirtualSize:      virtualSize,
+		VirtualAddress:   sectionRVA,
+		SizeOfRawData:    rawSize,
+		PointerToRawData: fileAlignment,
+		Characteristics: pe.IMAGE_SCN_CNT_CODE |
+			pe.IMAGE_SCN_MEM_EXECUTE |
+			pe.IMAGE_SCN_MEM_READ,
+	})
+	buf.Write(make([]byte, fileAlignment-buf.Len()))
+
+	// II.25.3.3 CLI header.
+	const COMIMAGE_FLAGS_ILONLY = 0x1
+	write(CLIHeader{
+		CB:                  cliHeaderSize,
+		MajorRuntimeVersion: 2,
+		MinorRuntimeVersion: 5,
+		MetaData: pe.DataDirectory{
+			VirtualAddress: sectionRVA + cliHeaderSize,
+			Size:           uint32(len(metadata)),
+		},
+		Flags: COMIMAGE_FLAGS_ILONLY,
+	})
+	buf.Write(metadata)
+	buf.Write(make([]byte, fileAlignment+int(rawSize)-buf.Len()))
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -2,6 +2,7 @@ package md
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"strings"
 )
@@ -34,13 +35,17 @@ func (m *MetadataRoot) Decode(r io.Reader) error {
 		!rr.Read(&versionLength) {
 		return rr.Err()
 	}
+	// Version string is limited to 255 bytes, rounded up to a multiple of four.
+	if versionLength > 256 {
+		return fmt.Errorf("version length %d is too big: %w", versionLength, ErrOutOfBounds)
+	}
 
 	{
 		b := &strings.Builder{}
 		b.Grow(int(versionLength))
 
 		if _, err := io.CopyN(b, r, int64(versionLength)); err != nil {
-			return err
+			return truncated(err)
 		}
 		m.Version = strings.TrimRight(b.String(), "\x00")
 	}
@@ -89,7 +94,7 @@ func (h *StreamHeader) Decode(r io.Reader) error {
 	)
 	for i := 0; i < 32; i++ {
 		if _, err := io.ReadFull(r, buf); err != nil {
-			return err
+			return truncated(err)
 		}
 
 		idx := bytes.IndexByte(buf, 0)
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"debug/pe"
 	"embed"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -61,3 +63,179 @@ func TestMetadata_StreamByName(t *testing.T) {
 	_, err = m.StreamByName("lolnogenerics")
 	a.Error(err)
 }
+
+func TestMetadata_ReadGUID(t *testing.T) {
+	a := require.New(t)
+	f := openTestData(a, `_testdata/Windows.Win32.winmd`)
+	defer f.Close()
+
+	m, err := ParseMetadata(f)
+	a.NoError(err)
+
+	g, err := m.ReadGUID(0)
+	a.NoError(err)
+	a.True(g.Zero())
+
+	// Module.Mvid is always present.
+	g, err = m.ReadGUID(1)
+	a.NoError(err)
+	a.False(g.Zero())
+
+	_, err = m.ReadGUID(1 << 20)
+	a.Error(err)
+}
+
+func TestMetadata_OutOfBounds(t *testing.T) {
+	a := require.New(t)
+
+	var b TablesBuilder
+	b.Rows[Module] = []RowValues{{0, 1, 1}}
+	tables, err := b.Bytes()
+	a.NoError(err)
+
+	data := EncodeMetadata("v4.0.30319",
+		Stream{Name: "#~", Data: tables},
+		Stream{Name: "#Strings", Data: []byte("\x00Foo")},
+		Stream{Name: "#GUID", Data: make([]byte, 16)},
+		Stream{Name: "#Blob", Data: []byte{0x00, 0x05, 0x01}},
+	)
+	m, err := ParseMetadataBytes(data)
+	a.NoError(err)
+
+	// Not null-terminated.
+	_, err = m.ReadString(1)
+	a.ErrorIs(err, ErrTruncated)
+	_, err = m.ReadString(100)
+	a.ErrorIs(err, ErrOutOfBounds)
+
+	_, err = m.ReadBlob(1)
+	a.ErrorIs(err, ErrOutOfBounds)
+	_, err = m.ReadBlob(100)
+	a.ErrorIs(err, ErrOutOfBounds)
+
+	_, err = m.ReadGUID(2)
+	a.ErrorIs(err, ErrOutOfBounds)
+
+	h, section, err := m.Tables()
+	a.NoError(err)
+	_, err = h.Tables[Module].Uint32(section, 1, 0)
+	a.ErrorIs(err, ErrOutOfBounds)
+
+	// Stream is out of metadata bounds.
+	_, err = ParseMetadataBytes(data[:len(data)-4])
+	a.ErrorIs(err, ErrOutOfBounds)
+
+	// Truncated metadata root.
+	_, err = ParseMetadataBytes(data[:16])
+	a.ErrorIs(err, ErrTruncated)
+}
+
+func TestMetadata_ReadBlob(t *testing.T) {
+	var (
+		medium = bytes.Repeat([]byte{'m'}, 0x102)
+		large  = bytes.Repeat([]byte{'l'}, 0x4000)
+		heap   []byte
+	)
+	heap = append(heap, 0x00)
+	heap = append(heap, 0x02, 'a', 'b')
+	// 2-byte compressed length: 10xxxxxx xxxxxxxx.
+	heap = append(heap, 0x81, 0x02)
+	heap = append(heap, medium...)
+	// 4-byte compressed length: 110xxxxx xxxxxxxx xxxxxxxx xxxxxxxx.
+	heap = append(heap, 0xc0, 0x00, 0x40, 0x00)
+	heap = append(heap, large...)
+	// Invalid length prefix 111xxxxx.
+	invalid := uint64(len(heap))
+	heap = append(heap, 0xe0)
+	// Blob shorter than 4 bytes at the end of heap.
+	last := uint64(len(heap))
+	heap = append(heap, 0x01, 'z')
+
+	data := EncodeMetadata("v4.0.30319",
+		Stream{Name: "#Blob", Data: heap},
+	)
+	for _, tt := range []struct {
+		name  string
+		parse func() (*Metadata, error)
+	}{
+		{"Bytes", func() (*Metadata, error) {
+			return ParseMetadataBytes(data)
+		}},
+		{"ReaderAt", func() (*Metadata, error) {
+			return ParseMetadataAt(bytes.NewReader(data), int64(len(data)))
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := require.New(t)
+
+			m, err := tt.parse()
+			a.NoError(err)
+
+			for _, b := range []struct {
+				idx    uint64
+				expect []byte
+			}{
+				{0, []byte{}},
+				{1, []byte("ab")},
+				{4, medium},
+				{4 + 2 + uint64(len(medium)), large},
+				{last, []byte("z")},
+			} {
+				v, err := m.ReadBlob(b.idx)
+				a.NoError(err)
+				a.Equal(b.expect, v, "blob %#x", b.idx)
+			}
+
+			_, err = m.ReadBlob(invalid)
+			a.Error(err)
+		})
+	}
+}
+
+func TestMetadata_ReadString_Concurrent(t *testing.T) {
+	a := require.New(t)
+
+	var (
+		strs    StringHeapBuilder
+		indexes []uint64
+	)
+	for i := 0; i < 100; i++ {
+		indexes = append(indexes, uint64(strs.Add(fmt.Sprintf("String%d", i))))
+	}
+	data := EncodeMetadata("v4.0.30319",
+		Stream{Name: "#Strings", Data: strs.Bytes()},
+	)
+
+	// Reader backend uses string cache.
+	m, err := ParseMetadataAt(bytes.NewReader(data), int64(len(data)))
+	a.NoError(err)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 16)
+	)
+	for g := 0; g < cap(errs); g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i, idx := range indexes {
+				v, err := m.ReadString(idx)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if expect := fmt.Sprintf("String%d", i); v != expect {
+					errs <- fmt.Errorf("expected %q, got %q", expect, v)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		a.NoError(err)
+	}
+}
@@ -0,0 +1,65 @@
+package md
+
+import (
+	"fmt"
+	"io"
+)
+
+// PdbStream is a representation of portable PDB #Pdb stream.
+//
+// See Portable PDB v1.0: Format Specification.
+type PdbStream struct {
+	// PdbId is a PDB identifier.
+	PdbId [20]byte
+	// EntryPoint is a MethodDef token of entry point or 0.
+	EntryPoint uint32
+	// ReferencedTypeSystemTables is a bitmask of type system tables, which
+	// are referenced by PDB tables.
+	ReferencedTypeSystemTables uint64
+	// TypeSystemTableRows contains row counts of referenced type system tables.
+	TypeSystemTableRows [CustomDebugInformation + 1]uint32
+}
+
+// Decode decodes PdbStream from stream.
+func (p *PdbStream) Decode(r io.Reader) error {
+	rr := reader{r: r}
+
+	if !rr.Read(&p.PdbId) ||
+		!rr.Read(&p.EntryPoint) ||
+		!rr.Read(&p.ReferencedTypeSystemTables) {
+		return rr.Err()
+	}
+
+	for i := 0; i < 64; i++ {
+		if p.ReferencedTypeSystemTables>>i&1 == 0 {
+			continue
+		}
+		if i >= len(p.TypeSystemTableRows) {
+			return fmt.Errorf("unknown table %#x", i)
+		}
+
+		if !rr.Read(&p.TypeSystemTableRows[i]) {
+			return rr.Err()
+		}
+	}
+
+	return nil
+}
+
+// Pdb decodes portable PDB stream.
+// If there is no "#Pdb" stream, ok is false.
+func (m *Metadata) Pdb() (p PdbStream, ok bool, _ error) {
+	if _, ok := m.findStreamHeader("#Pdb"); !ok {
+		return p, false, nil
+	}
+
+	section, err := m.StreamByName("#Pdb")
+	if err != nil {
+		return p, false, err
+	}
+
+	if err := p.Decode(section); err != nil {
+		return p, false, fmt.Errorf("decode #Pdb: %w", err)
+	}
+	return p, true, nil
+}
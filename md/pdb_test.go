@@ -0,0 +1,69 @@
+package md
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPdbStream_Decode(t *testing.T) {
+	a := require.New(t)
+
+	var buf bytes.Buffer
+	for _, v := range []interface{}{
+		[20]byte{1, 2, 3},                 // PdbId
+		uint32(0x06000001),                // EntryPoint
+		uint64(1<<MethodDef | 1<<TypeDef), // ReferencedTypeSystemTables
+		uint32(10),                        // TypeDef rows
+		uint32(0x10000),                   // MethodDef rows
+	} {
+		a.NoError(binary.Write(&buf, binary.LittleEndian, v))
+	}
+
+	var p PdbStream
+	a.NoError(p.Decode(&buf))
+	a.Equal([20]byte{1, 2, 3}, p.PdbId)
+	a.Equal(uint32(0x06000001), p.EntryPoint)
+	a.Equal(uint32(10), p.TypeSystemTableRows[TypeDef])
+	a.Equal(uint32(0x10000), p.TypeSystemTableRows[MethodDef])
+
+	// Truncated row counts.
+	a.Error(p.Decode(bytes.NewReader(buf.Bytes()[:0])))
+}
+
+func TestTablesHeader_DecodePdb(t *testing.T) {
+	a := require.New(t)
+
+	var buf bytes.Buffer
+	for _, v := range []interface{}{
+		[4]byte{}, // Reserved
+		uint8(2),  // MajorVersion
+		uint8(0),  // MinorVersion
+		uint8(0),  // HeapSizes
+		uint8(1),  // Reserved
+		uint64(1<<Document | 1<<MethodDebugInformation | 1<<LocalScope), // Valid
+		uint64(0), // Sorted
+		uint32(1), // Document rows
+		uint32(1), // MethodDebugInformation rows
+		uint32(1), // LocalScope rows
+	} {
+		a.NoError(binary.Write(&buf, binary.LittleEndian, v))
+	}
+
+	var h TablesHeader
+	h.ExternalRowCounts[MethodDef] = 0x10000
+	a.NoError(h.Decode(&buf))
+
+	a.Equal(uint32(8), h.Tables[Document].RowSize)
+	a.Equal(uint32(4), h.Tables[MethodDebugInformation].RowSize)
+	a.Equal(uint32(18), h.Tables[LocalScope].RowSize)
+	a.Equal(uint32(4), h.Tables[LocalScope].Columns[0].Size)
+	// External tables are not stored in the stream.
+	a.Equal(uint32(0), h.Tables[MethodDef].RowCount)
+
+	a.Equal(int64(36), h.Tables[Document].Offset)
+	a.Equal(int64(44), h.Tables[MethodDebugInformation].Offset)
+	a.Equal(int64(48), h.Tables[LocalScope].Offset)
+}
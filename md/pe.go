@@ -28,7 +28,7 @@ type CLIHeader struct {
 func findSection(sections []*pe.Section, va uint32) (*pe.Section, error) {
 	var section *pe.Section
 	for _, s := range sections {
-		if va >= s.VirtualAddress && va < s.VirtualAddress+s.VirtualSize {
+		if va >= s.VirtualAddress && uint64(va) < uint64(s.VirtualAddress)+uint64(s.VirtualSize) {
 			section = s
 			break
 		}
@@ -71,7 +71,7 @@ func getCLIHeader(f *pe.File) (CLIHeader, error) {
 
 	var h CLIHeader
 	if err := binary.Read(headerReader, binary.LittleEndian, &h); err != nil {
-		return CLIHeader{}, err
+		return CLIHeader{}, truncated(err)
 	}
 	if int64(h.CB) != HeaderSize {
 		return CLIHeader{}, fmt.Errorf("invalid size of CLI header: %d", h.CB)
@@ -85,23 +85,35 @@ func getMetadataReader(f *pe.File, header CLIHeader) (*io.SectionReader, error)
 	if err != nil {
 		return nil, err
 	}
-	r := io.NewSectionReader(
-		section,
-		int64(header.MetaData.VirtualAddress-section.VirtualAddress),
-		int64(header.MetaData.Size),
-	)
+	offset := int64(header.MetaData.VirtualAddress - section.VirtualAddress)
+	if end := offset + int64(header.MetaData.Size); end > int64(section.Size) {
+		return nil, fmt.Errorf("metadata [%d:%d] is out of section %q bounds (%d): %w",
+			offset, end, section.Name, section.Size, ErrOutOfBounds)
+	}
+	// Use section data reader directly to keep in-memory data accessible, see BytesOf.
+	r := io.NewSectionReader(section.ReaderAt, offset, int64(header.MetaData.Size))
+
+	if err := checkMagic(r); err != nil {
+		return nil, err
+	}
 
+	return r, nil
+}
+
+// STORAGE_MAGIC_SIG is a II.24.2.1 metadata root signature ("BSJB").
+const STORAGE_MAGIC_SIG = 0x424A_5342
+
+// checkMagic reads and checks metadata root signature.
+func checkMagic(r io.Reader) error {
 	var (
 		magic uint32
 	)
 	if err := binary.Read(r, binary.LittleEndian, &magic); err != nil {
-		return nil, err
+		return truncated(err)
 	}
 
-	const STORAGE_MAGIC_SIG = 0x424A_5342
 	if magic != STORAGE_MAGIC_SIG {
-		return nil, fmt.Errorf("invalid magic: %#x, expected %#x", magic, STORAGE_MAGIC_SIG)
+		return fmt.Errorf("invalid magic: %#x, expected %#x", magic, STORAGE_MAGIC_SIG)
 	}
-
-	return r, nil
+	return nil
 }
@@ -21,6 +21,6 @@ func (r reader) Err() error {
 // Read reads value from stream to dst. It returns true on success, or false
 // if error encountered during decoding.
 func (r *reader) Read(dst interface{}) bool {
-	r.err = binary.Read(r.r, binary.LittleEndian, dst)
+	r.err = truncated(binary.Read(r.r, binary.LittleEndian, dst))
 	return r.err == nil
 }
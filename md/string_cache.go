@@ -0,0 +1,37 @@
+package md
+
+import "sync"
+
+const stringCacheShards = 32
+
+// stringCache is a concurrency-safe cache of #Strings heap values.
+//
+// Cache is split into shards to reduce lock contention between readers.
+type stringCache struct {
+	shards [stringCacheShards]struct {
+		mux sync.RWMutex
+		m   map[uint64]string
+	}
+}
+
+// Load returns cached string of given index.
+func (c *stringCache) Load(idx uint64) (string, bool) {
+	shard := &c.shards[idx%stringCacheShards]
+
+	shard.mux.RLock()
+	v, ok := shard.m[idx]
+	shard.mux.RUnlock()
+	return v, ok
+}
+
+// Store caches string of given index.
+func (c *stringCache) Store(idx uint64, v string) {
+	shard := &c.shards[idx%stringCacheShards]
+
+	shard.mux.Lock()
+	if shard.m == nil {
+		shard.m = map[uint64]string{}
+	}
+	shard.m[idx] = v
+	shard.mux.Unlock()
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 )
 
 // TableType is a metadata table type.
@@ -20,10 +21,16 @@ const (
 	TypeRef TableType = 0x01
 	// TypeDef table type.
 	TypeDef TableType = 0x02
+	// FieldPtr table type.
+	FieldPtr TableType = 0x03
 	// Field table type.
 	Field TableType = 0x04
+	// MethodPtr table type.
+	MethodPtr TableType = 0x05
 	// MethodDef table type.
 	MethodDef TableType = 0x06
+	// ParamPtr table type.
+	ParamPtr TableType = 0x07
 	// Param table type.
 	Param TableType = 0x08
 	// InterfaceImpl table type.
@@ -46,10 +53,14 @@ const (
 	StandAloneSig TableType = 0x11
 	// EventMap table type.
 	EventMap TableType = 0x12
+	// EventPtr table type.
+	EventPtr TableType = 0x13
 	// Event table type.
 	Event TableType = 0x14
 	// PropertyMap table type.
 	PropertyMap TableType = 0x15
+	// PropertyPtr table type.
+	PropertyPtr TableType = 0x16
 	// Property table type.
 	Property TableType = 0x17
 	// MethodSemantics table type.
@@ -64,6 +75,10 @@ const (
 	ImplMap TableType = 0x1c
 	// FieldRva table type.
 	FieldRva TableType = 0x1d
+	// EncLog table type.
+	EncLog TableType = 0x1e
+	// EncMap table type.
+	EncMap TableType = 0x1f
 	// Assembly table type.
 	Assembly TableType = 0x20
 	// AssemblyProcessor table type.
@@ -90,8 +105,32 @@ const (
 	MethodSpec TableType = 0x2b
 	// GenericParamConstraint table type.
 	GenericParamConstraint TableType = 0x2c
+
+	// Document table type.
+	//
+	// See Portable PDB v1.0: Format Specification.
+	Document TableType = 0x30
+	// MethodDebugInformation table type.
+	MethodDebugInformation TableType = 0x31
+	// LocalScope table type.
+	LocalScope TableType = 0x32
+	// LocalVariable table type.
+	LocalVariable TableType = 0x33
+	// LocalConstant table type.
+	LocalConstant TableType = 0x34
+	// ImportScope table type.
+	ImportScope TableType = 0x35
+	// StateMachineMethod table type.
+	StateMachineMethod TableType = 0x36
+	// CustomDebugInformation table type.
+	CustomDebugInformation TableType = 0x37
 )
 
+// MarshalText implements encoding.TextMarshaler.
+func (i TableType) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
 // Column represents metadata table column sizes.
 type Column struct {
 	// Offset from row start.
@@ -119,26 +158,52 @@ type Table struct {
 
 // Find returns offset of given column in row.
 func (t Table) Find(row, column uint32) (uint32, error) {
-	c := t.Columns[column]
-	if c.Zero() {
+	if column >= uint32(len(t.Columns)) || t.Columns[column].Zero() {
 		return 0, fmt.Errorf("type %#x does not have column %d", t.Type, column)
 	}
-	if row > t.RowCount {
-		return 0, fmt.Errorf("row index %d is out of bounds (%d)", row, t.RowCount)
+	c := t.Columns[column]
+	if row >= t.RowCount {
+		return 0, fmt.Errorf("%v row index %d is out of bounds (%d): %w", t.Type, row, t.RowCount, ErrOutOfBounds)
 	}
 
-	return uint32(t.Offset) + row*t.RowSize + c.Offset, nil
+	offset := uint64(t.Offset) + uint64(row)*uint64(t.RowSize) + uint64(c.Offset)
+	if offset > math.MaxUint32 {
+		return 0, fmt.Errorf("%v row %d offset %d overflows: %w", t.Type, row, offset, ErrOutOfBounds)
+	}
+	return uint32(offset), nil
 }
 
-// Uint32 returns numeric value truncated to uint32.
-func (t Table) Uint32(r io.ReaderAt, row, column uint32) (uint32, error) {
+// readColumn reads raw value of given column.
+//
+// If r is Bytes, value is not copied.
+func (t Table) readColumn(r io.ReaderAt, row, column uint32) ([]byte, error) {
 	offset, err := t.Find(row, column)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	size := t.Columns[column].Size
+	if b, ok := r.(Bytes); ok {
+		end := uint64(offset) + uint64(size)
+		if end > uint64(len(b)) {
+			return nil, fmt.Errorf("read %v row %d: %w", t.Type, row, ErrTruncated)
+		}
+		return b[offset:end], nil
 	}
 
-	buf := make([]byte, t.Columns[column].Size)
+	buf := make([]byte, size)
 	if _, err := r.ReadAt(buf, int64(offset)); err != nil {
+		return nil, fmt.Errorf("read %v row %d: %w", t.Type, row, truncated(err))
+	}
+	return buf, nil
+}
+
+// Uint32 returns numeric value truncated to uint32.
+//
+// If r is Bytes, value is read without allocations.
+func (t Table) Uint32(r io.ReaderAt, row, column uint32) (uint32, error) {
+	buf, err := t.readColumn(r, row, column)
+	if err != nil {
 		return 0, err
 	}
 
@@ -155,15 +220,13 @@ func (t Table) Uint32(r io.ReaderAt, row, column uint32) (uint32, error) {
 }
 
 // Uint64 returns numeric value truncated to uint64.
+//
+// If r is Bytes, value is read without allocations.
 func (t Table) Uint64(r io.ReaderAt, row, column uint32) (uint64, error) {
-	offset, err := t.Find(row, column)
+	buf, err := t.readColumn(r, row, column)
 	if err != nil {
 		return 0, err
 	}
-	buf := make([]byte, t.Columns[column].Size)
-	if _, err := r.ReadAt(buf, int64(offset)); err != nil {
-		return 0, err
-	}
 
 	switch len(buf) {
 	case 1:
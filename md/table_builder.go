@@ -0,0 +1,119 @@
+package md
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// RowValues is a raw values of table row columns.
+type RowValues = [6]uint64
+
+// HeapSizes computes TablesHeader.HeapSizes from sizes of heaps in bytes.
+func HeapSizes(strings, guid, blob int) (flags uint8) {
+	const limit = 1 << 16
+	if strings >= limit {
+		flags |= 0x01
+	}
+	if guid >= limit {
+		flags |= 0x02
+	}
+	if blob >= limit {
+		flags |= 0x04
+	}
+	return flags
+}
+
+// TablesBuilder builds II.24.2.6 #~ stream.
+type TablesBuilder struct {
+	HeapSizes uint8
+	Sorted    uint64
+	Rows      [CustomDebugInformation + 1][]RowValues
+	// ExternalRowCounts contains row counts of tables stored in other metadata.
+	//
+	// See TablesHeader.ExternalRowCounts.
+	ExternalRowCounts [CustomDebugInformation + 1]uint32
+}
+
+// Header computes TablesHeader of stream.
+func (b *TablesBuilder) Header() TablesHeader {
+	h := TablesHeader{
+		MajorVersion:      2,
+		MinorVersion:      0,
+		HeapSizes:         b.HeapSizes,
+		Reserved2:         [1]byte{1},
+		Sorted:            b.Sorted,
+		ExternalRowCounts: b.ExternalRowCounts,
+	}
+	for i, rows := range &b.Rows {
+		if len(rows) == 0 {
+			continue
+		}
+		h.Valid |= 1 << uint(i)
+		h.Tables[i] = Table{
+			Type:     TableType(i),
+			RowCount: uint32(len(rows)),
+		}
+	}
+	h.computeIndexes()
+	return h
+}
+
+// Bytes encodes stream, padded to 4-byte boundary.
+func (b *TablesBuilder) Bytes() ([]byte, error) {
+	h := b.Header()
+
+	var buf bytes.Buffer
+	for _, v := range []interface{}{
+		h.Reserved,
+		h.MajorVersion,
+		h.MinorVersion,
+		h.HeapSizes,
+		h.Reserved2,
+		h.Valid,
+		h.Sorted,
+	} {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	for i := range h.Tables {
+		if h.Valid>>uint(i)&1 == 0 {
+			continue
+		}
+		if err := binary.Write(&buf, binary.LittleEndian, h.Tables[i].RowCount); err != nil {
+			return nil, err
+		}
+	}
+
+	data := buf.Bytes()
+	for i, rows := range &b.Rows {
+		table := h.Tables[i]
+		for row, values := range rows {
+			for column, c := range table.Columns {
+				if c.Zero() {
+					break
+				}
+				v := values[column]
+				if c.Size < 8 && v>>(8*c.Size) != 0 {
+					return nil, fmt.Errorf(
+						"%v(%d): value %#x of column %d does not fit into %d bytes",
+						table.Type, row, v, column, c.Size,
+					)
+				}
+
+				switch c.Size {
+				case 1:
+					data = append(data, byte(v))
+				case 2:
+					data = binary.LittleEndian.AppendUint16(data, uint16(v))
+				case 4:
+					data = binary.LittleEndian.AppendUint32(data, uint32(v))
+				default:
+					data = binary.LittleEndian.AppendUint64(data, v)
+				}
+			}
+		}
+	}
+	return alignBytes(data), nil
+}
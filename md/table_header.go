@@ -1,6 +1,7 @@
 package md
 
 import (
+	"fmt"
 	"io"
 )
 
@@ -13,7 +14,12 @@ type TablesHeader struct {
 	Reserved2    [1]byte
 	Valid        uint64
 	Sorted       uint64
-	Tables       [GenericParamConstraint + 1]Table
+	Tables       [CustomDebugInformation + 1]Table
+	// ExternalRowCounts contains row counts of tables stored in other metadata.
+	//
+	// Portable PDB tables reference type system tables of PE file, so their
+	// row counts are used to compute index sizes.
+	ExternalRowCounts [CustomDebugInformation + 1]uint32
 }
 
 // StringIndexSize returns size of index of "#String" heap.
@@ -32,6 +38,13 @@ func (h TablesHeader) BlobIndexSize() uint32 {
 	return 2
 }
 
+// ExtraData denotes that header contains additional 4 bytes after row counts.
+//
+// This flag is used only by uncompressed "#-" streams.
+func (h TablesHeader) ExtraData() bool {
+	return (h.HeapSizes>>6)&1 == 1
+}
+
 // GUIDIndexSize returns size of index of "#GUID" heap.
 func (h TablesHeader) GUIDIndexSize() uint32 {
 	if (h.HeapSizes>>1)&1 == 1 {
@@ -66,6 +79,9 @@ func (h *TablesHeader) Decode(r io.Reader) error {
 		if h.Valid>>i&1 == 0 {
 			continue
 		}
+		if i >= len(h.Tables) {
+			return fmt.Errorf("unknown table %#x", i)
+		}
 
 		if !rr.Read(&row) {
 			return rr.Err()
@@ -76,12 +92,20 @@ func (h *TablesHeader) Decode(r io.Reader) error {
 			RowCount: row,
 		}
 	}
+	if h.ExtraData() {
+		var extra uint32
+		if !rr.Read(&extra) {
+			return rr.Err()
+		}
+		offset += 4
+	}
+
 	h.computeIndexes()
 
 	// Compute data offsets of every table.
 	for i, table := range &h.Tables {
 		h.Tables[i].Offset = offset
-		offset += int64(table.RowCount * table.RowSize)
+		offset += int64(table.RowCount) * int64(table.RowSize)
 	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package md
 
 import (
+	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -109,3 +111,36 @@ func TestMetadata_Tables(t *testing.T) {
 		}
 	}
 }
+
+func TestTablesHeader_DecodeUncompressed(t *testing.T) {
+	a := require.New(t)
+
+	var buf bytes.Buffer
+	for _, v := range []interface{}{
+		[4]byte{},   // Reserved
+		uint8(2),    // MajorVersion
+		uint8(0),    // MinorVersion
+		uint8(0x40), // HeapSizes, extra data flag
+		uint8(1),    // Reserved
+		uint64(1<<TypeDef | 1<<FieldPtr | 1<<Field), // Valid
+		uint64(0), // Sorted
+		uint32(1), // TypeDef rows
+		uint32(2), // FieldPtr rows
+		uint32(2), // Field rows
+		uint32(0), // Extra data
+	} {
+		a.NoError(binary.Write(&buf, binary.LittleEndian, v))
+	}
+
+	var h TablesHeader
+	a.NoError(h.Decode(&buf))
+	a.True(h.ExtraData())
+
+	a.Equal(uint32(14), h.Tables[TypeDef].RowSize)
+	a.Equal(uint32(2), h.Tables[FieldPtr].RowSize)
+	a.Equal(uint32(6), h.Tables[Field].RowSize)
+
+	a.Equal(int64(40), h.Tables[TypeDef].Offset)
+	a.Equal(int64(54), h.Tables[FieldPtr].Offset)
+	a.Equal(int64(58), h.Tables[Field].Offset)
+}
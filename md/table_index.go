@@ -7,135 +7,195 @@ func (h *TablesHeader) computeIndexes() {
 		blobIndexSize   = h.BlobIndexSize()
 		stringIndexSize = h.StringIndexSize()
 		guidIndexSize   = h.GUIDIndexSize()
+		// Tables used to compute index sizes.
+		tables = h.Tables
 	)
+	for i, rows := range h.ExternalRowCounts {
+		if tables[i].RowCount == 0 {
+			tables[i].RowCount = rows
+		}
+	}
 
 	typeDefOrRef := compositeIndexSize(
-		h.Tables[TypeDef],
-		h.Tables[TypeRef],
-		h.Tables[TypeSpec],
+		tables[TypeDef],
+		tables[TypeRef],
+		tables[TypeSpec],
 	)
 	hasConstant := compositeIndexSize(
-		h.Tables[TypeDef],
-		h.Tables[TypeRef],
-		h.Tables[Property],
+		tables[Field],
+		tables[Param],
+		tables[Property],
 	)
 	hasCustomAttribute := compositeIndexSize(
-		h.Tables[MethodDef],
-		h.Tables[Field],
-		h.Tables[TypeRef],
-		h.Tables[TypeDef],
-		h.Tables[Param],
-		h.Tables[InterfaceImpl],
-		h.Tables[MemberRef],
-		h.Tables[Module],
-		h.Tables[Property],
-		h.Tables[Event],
-		h.Tables[StandAloneSig],
-		h.Tables[ModuleRef],
-		h.Tables[TypeSpec],
-		h.Tables[Assembly],
-		h.Tables[AssemblyRef],
-		h.Tables[File],
-		h.Tables[ExportedType],
-		h.Tables[ManifestResource],
-		h.Tables[GenericParam],
-		h.Tables[GenericParamConstraint],
-		h.Tables[MethodSpec],
+		tables[MethodDef],
+		tables[Field],
+		tables[TypeRef],
+		tables[TypeDef],
+		tables[Param],
+		tables[InterfaceImpl],
+		tables[MemberRef],
+		tables[Module],
+		tables[Property],
+		tables[Event],
+		tables[StandAloneSig],
+		tables[ModuleRef],
+		tables[TypeSpec],
+		tables[Assembly],
+		tables[AssemblyRef],
+		tables[File],
+		tables[ExportedType],
+		tables[ManifestResource],
+		tables[GenericParam],
+		tables[GenericParamConstraint],
+		tables[MethodSpec],
 	)
 	hasFieldMarshal := compositeIndexSize(
-		h.Tables[Field],
-		h.Tables[Param],
+		tables[Field],
+		tables[Param],
 	)
 	hasDeclSecurity := compositeIndexSize(
-		h.Tables[TypeDef],
-		h.Tables[MethodDef],
-		h.Tables[Assembly],
+		tables[TypeDef],
+		tables[MethodDef],
+		tables[Assembly],
 	)
 	memberRefParent := compositeIndexSize(
-		h.Tables[TypeDef],
-		h.Tables[TypeRef],
-		h.Tables[ModuleRef],
-		h.Tables[MethodDef],
-		h.Tables[TypeSpec],
+		tables[TypeDef],
+		tables[TypeRef],
+		tables[ModuleRef],
+		tables[MethodDef],
+		tables[TypeSpec],
 	)
 	hasSemantics := compositeIndexSize(
-		h.Tables[Event],
-		h.Tables[Property],
+		tables[Event],
+		tables[Property],
 	)
 	methodDefOrRef := compositeIndexSize(
-		h.Tables[MethodDef],
-		h.Tables[MemberRef],
+		tables[MethodDef],
+		tables[MemberRef],
 	)
 	memberForwarded := compositeIndexSize(
-		h.Tables[Field],
-		h.Tables[MethodDef],
+		tables[Field],
+		tables[MethodDef],
 	)
 	implementation := compositeIndexSize(
-		h.Tables[File],
-		h.Tables[AssemblyRef],
-		h.Tables[ExportedType],
+		tables[File],
+		tables[AssemblyRef],
+		tables[ExportedType],
 	)
 	customAttributeType := compositeIndexSize(
-		h.Tables[MethodDef],
-		h.Tables[MemberRef],
+		tables[MethodDef],
+		tables[MemberRef],
 		empty,
 		empty,
 		empty,
 	)
+	hasCustomDebugInformation := compositeIndexSize(
+		tables[MethodDef],
+		tables[Field],
+		tables[TypeRef],
+		tables[TypeDef],
+		tables[Param],
+		tables[InterfaceImpl],
+		tables[MemberRef],
+		tables[Module],
+		tables[DeclSecurity],
+		tables[Property],
+		tables[Event],
+		tables[StandAloneSig],
+		tables[ModuleRef],
+		tables[TypeSpec],
+		tables[Assembly],
+		tables[AssemblyRef],
+		tables[File],
+		tables[ExportedType],
+		tables[ManifestResource],
+		tables[GenericParam],
+		tables[GenericParamConstraint],
+		tables[MethodSpec],
+		tables[Document],
+		tables[LocalScope],
+		tables[LocalVariable],
+		tables[LocalConstant],
+		tables[ImportScope],
+	)
 	resolutionScope := compositeIndexSize(
-		h.Tables[Module],
-		h.Tables[ModuleRef],
-		h.Tables[AssemblyRef],
-		h.Tables[TypeRef],
+		tables[Module],
+		tables[ModuleRef],
+		tables[AssemblyRef],
+		tables[TypeRef],
 	)
 	typeOrMethodDef := compositeIndexSize(
-		h.Tables[TypeDef],
-		h.Tables[MethodDef],
+		tables[TypeDef],
+		tables[MethodDef],
 	)
 
 	h.Tables[Assembly].SetRowType([6]uint32{4, 8, 4, blobIndexSize, stringIndexSize, stringIndexSize})
 	h.Tables[AssemblyOs].SetRowType([6]uint32{4, 4, 4})
 	h.Tables[AssemblyProcessor].SetRowType([6]uint32{4})
 	h.Tables[AssemblyRef].SetRowType([6]uint32{8, 4, blobIndexSize, stringIndexSize, stringIndexSize, blobIndexSize})
-	h.Tables[AssemblyRefOs].SetRowType([6]uint32{4, 4, 4, h.Tables[AssemblyRef].IndexSize()})
-	h.Tables[AssemblyRefProcessor].SetRowType([6]uint32{4, h.Tables[AssemblyRef].IndexSize()})
-	h.Tables[ClassLayout].SetRowType([6]uint32{2, 4, h.Tables[TypeDef].IndexSize()})
+	h.Tables[AssemblyRefOs].SetRowType([6]uint32{4, 4, 4, tables[AssemblyRef].IndexSize()})
+	h.Tables[AssemblyRefProcessor].SetRowType([6]uint32{4, tables[AssemblyRef].IndexSize()})
+	h.Tables[ClassLayout].SetRowType([6]uint32{2, 4, tables[TypeDef].IndexSize()})
 	h.Tables[Constant].SetRowType([6]uint32{2, hasConstant, blobIndexSize})
 	h.Tables[CustomAttribute].SetRowType([6]uint32{hasCustomAttribute, customAttributeType, blobIndexSize})
 	h.Tables[DeclSecurity].SetRowType([6]uint32{2, hasDeclSecurity, blobIndexSize})
-	h.Tables[EventMap].SetRowType([6]uint32{h.Tables[TypeDef].IndexSize(), h.Tables[Event].IndexSize()})
+	h.Tables[EncLog].SetRowType([6]uint32{4, 4})
+	h.Tables[EncMap].SetRowType([6]uint32{4})
+	h.Tables[EventMap].SetRowType([6]uint32{tables[TypeDef].IndexSize(), tables[Event].IndexSize()})
 	h.Tables[Event].SetRowType([6]uint32{2, stringIndexSize, typeDefOrRef})
+	h.Tables[EventPtr].SetRowType([6]uint32{tables[Event].IndexSize()})
 	h.Tables[ExportedType].SetRowType([6]uint32{4, 4, stringIndexSize, stringIndexSize, implementation})
 	h.Tables[Field].SetRowType([6]uint32{2, stringIndexSize, blobIndexSize})
-	h.Tables[FieldLayout].SetRowType([6]uint32{4, h.Tables[Field].IndexSize()})
+	h.Tables[FieldPtr].SetRowType([6]uint32{tables[Field].IndexSize()})
+	h.Tables[FieldLayout].SetRowType([6]uint32{4, tables[Field].IndexSize()})
 	h.Tables[FieldMarshal].SetRowType([6]uint32{hasFieldMarshal, blobIndexSize})
-	h.Tables[FieldRva].SetRowType([6]uint32{4, h.Tables[Field].IndexSize()})
+	h.Tables[FieldRva].SetRowType([6]uint32{4, tables[Field].IndexSize()})
 	h.Tables[File].SetRowType([6]uint32{4, stringIndexSize, blobIndexSize})
 	h.Tables[GenericParam].SetRowType([6]uint32{2, 2, typeOrMethodDef, stringIndexSize})
-	h.Tables[GenericParamConstraint].SetRowType([6]uint32{h.Tables[GenericParam].IndexSize(), typeDefOrRef})
-	h.Tables[ImplMap].SetRowType([6]uint32{2, memberForwarded, stringIndexSize, h.Tables[ModuleRef].IndexSize()})
-	h.Tables[InterfaceImpl].SetRowType([6]uint32{h.Tables[TypeDef].IndexSize(), typeDefOrRef})
+	h.Tables[GenericParamConstraint].SetRowType([6]uint32{tables[GenericParam].IndexSize(), typeDefOrRef})
+	h.Tables[ImplMap].SetRowType([6]uint32{2, memberForwarded, stringIndexSize, tables[ModuleRef].IndexSize()})
+	h.Tables[InterfaceImpl].SetRowType([6]uint32{tables[TypeDef].IndexSize(), typeDefOrRef})
 	h.Tables[ManifestResource].SetRowType([6]uint32{4, 4, stringIndexSize, implementation})
 	h.Tables[MemberRef].SetRowType([6]uint32{memberRefParent, stringIndexSize, blobIndexSize})
-	h.Tables[MethodDef].SetRowType([6]uint32{4, 2, 2, stringIndexSize, blobIndexSize, h.Tables[Param].IndexSize()})
-	h.Tables[MethodImpl].SetRowType([6]uint32{h.Tables[TypeDef].IndexSize(), methodDefOrRef, methodDefOrRef})
-	h.Tables[MethodSemantics].SetRowType([6]uint32{2, h.Tables[MethodDef].IndexSize(), hasSemantics})
+	h.Tables[MethodDef].SetRowType([6]uint32{4, 2, 2, stringIndexSize, blobIndexSize, tables[Param].IndexSize()})
+	h.Tables[MethodPtr].SetRowType([6]uint32{tables[MethodDef].IndexSize()})
+	h.Tables[MethodImpl].SetRowType([6]uint32{tables[TypeDef].IndexSize(), methodDefOrRef, methodDefOrRef})
+	h.Tables[MethodSemantics].SetRowType([6]uint32{2, tables[MethodDef].IndexSize(), hasSemantics})
 	h.Tables[MethodSpec].SetRowType([6]uint32{methodDefOrRef, blobIndexSize})
 	h.Tables[Module].SetRowType([6]uint32{2, stringIndexSize, guidIndexSize, guidIndexSize, guidIndexSize})
 	h.Tables[ModuleRef].SetRowType([6]uint32{stringIndexSize})
-	h.Tables[NestedClass].SetRowType([6]uint32{h.Tables[TypeDef].IndexSize(), h.Tables[TypeDef].IndexSize()})
+	h.Tables[NestedClass].SetRowType([6]uint32{tables[TypeDef].IndexSize(), tables[TypeDef].IndexSize()})
 	h.Tables[Param].SetRowType([6]uint32{2, 2, stringIndexSize})
+	h.Tables[ParamPtr].SetRowType([6]uint32{tables[Param].IndexSize()})
 	h.Tables[Property].SetRowType([6]uint32{2, stringIndexSize, blobIndexSize})
-	h.Tables[PropertyMap].SetRowType([6]uint32{h.Tables[TypeDef].IndexSize(), h.Tables[Property].IndexSize()})
+	h.Tables[PropertyPtr].SetRowType([6]uint32{tables[Property].IndexSize()})
+	h.Tables[PropertyMap].SetRowType([6]uint32{tables[TypeDef].IndexSize(), tables[Property].IndexSize()})
 	h.Tables[StandAloneSig].SetRowType([6]uint32{blobIndexSize})
 	h.Tables[TypeDef].SetRowType([6]uint32{
 		4,
 		stringIndexSize,
 		stringIndexSize,
 		typeDefOrRef,
-		h.Tables[Field].IndexSize(),
-		h.Tables[MethodDef].IndexSize(),
+		tables[Field].IndexSize(),
+		tables[MethodDef].IndexSize(),
 	})
+	// Portable PDB tables.
+	h.Tables[Document].SetRowType([6]uint32{blobIndexSize, guidIndexSize, blobIndexSize, guidIndexSize})
+	h.Tables[MethodDebugInformation].SetRowType([6]uint32{tables[Document].IndexSize(), blobIndexSize})
+	h.Tables[LocalScope].SetRowType([6]uint32{
+		tables[MethodDef].IndexSize(),
+		tables[ImportScope].IndexSize(),
+		tables[LocalVariable].IndexSize(),
+		tables[LocalConstant].IndexSize(),
+		4,
+		4,
+	})
+	h.Tables[LocalVariable].SetRowType([6]uint32{2, 2, stringIndexSize})
+	h.Tables[LocalConstant].SetRowType([6]uint32{stringIndexSize, blobIndexSize})
+	h.Tables[ImportScope].SetRowType([6]uint32{tables[ImportScope].IndexSize(), blobIndexSize})
+	h.Tables[StateMachineMethod].SetRowType([6]uint32{tables[MethodDef].IndexSize(), tables[MethodDef].IndexSize()})
+	h.Tables[CustomDebugInformation].SetRowType([6]uint32{hasCustomDebugInformation, guidIndexSize, blobIndexSize})
+
 	h.Tables[TypeRef].SetRowType([6]uint32{resolutionScope, stringIndexSize, stringIndexSize})
 	h.Tables[TypeSpec].SetRowType([6]uint32{blobIndexSize})
 }
@@ -11,8 +11,11 @@ func _() {
 	_ = x[Module-0]
 	_ = x[TypeRef-1]
 	_ = x[TypeDef-2]
+	_ = x[FieldPtr-3]
 	_ = x[Field-4]
+	_ = x[MethodPtr-5]
 	_ = x[MethodDef-6]
+	_ = x[ParamPtr-7]
 	_ = x[Param-8]
 	_ = x[InterfaceImpl-9]
 	_ = x[MemberRef-10]
@@ -24,8 +27,10 @@ func _() {
 	_ = x[FieldLayout-16]
 	_ = x[StandAloneSig-17]
 	_ = x[EventMap-18]
+	_ = x[EventPtr-19]
 	_ = x[Event-20]
 	_ = x[PropertyMap-21]
+	_ = x[PropertyPtr-22]
 	_ = x[Property-23]
 	_ = x[MethodSemantics-24]
 	_ = x[MethodImpl-25]
@@ -33,6 +38,8 @@ func _() {
 	_ = x[TypeSpec-27]
 	_ = x[ImplMap-28]
 	_ = x[FieldRva-29]
+	_ = x[EncLog-30]
+	_ = x[EncMap-31]
 	_ = x[Assembly-32]
 	_ = x[AssemblyProcessor-33]
 	_ = x[AssemblyOs-34]
@@ -46,46 +53,33 @@ func _() {
 	_ = x[GenericParam-42]
 	_ = x[MethodSpec-43]
 	_ = x[GenericParamConstraint-44]
+	_ = x[Document-48]
+	_ = x[MethodDebugInformation-49]
+	_ = x[LocalScope-50]
+	_ = x[LocalVariable-51]
+	_ = x[LocalConstant-52]
+	_ = x[ImportScope-53]
+	_ = x[StateMachineMethod-54]
+	_ = x[CustomDebugInformation-55]
 }
 
 const (
-	_TableType_name_0 = "ModuleTypeRefTypeDef"
-	_TableType_name_1 = "Field"
-	_TableType_name_2 = "MethodDef"
-	_TableType_name_3 = "ParamInterfaceImplMemberRefConstantCustomAttributeFieldMarshalDeclSecurityClassLayoutFieldLayoutStandAloneSigEventMap"
-	_TableType_name_4 = "EventPropertyMap"
-	_TableType_name_5 = "PropertyMethodSemanticsMethodImplModuleRefTypeSpecImplMapFieldRva"
-	_TableType_name_6 = "AssemblyAssemblyProcessorAssemblyOsAssemblyRefAssemblyRefProcessorAssemblyRefOsFileExportedTypeManifestResourceNestedClassGenericParamMethodSpecGenericParamConstraint"
+	_TableType_name_0 = "ModuleTypeRefTypeDefFieldPtrFieldMethodPtrMethodDefParamPtrParamInterfaceImplMemberRefConstantCustomAttributeFieldMarshalDeclSecurityClassLayoutFieldLayoutStandAloneSigEventMapEventPtrEventPropertyMapPropertyPtrPropertyMethodSemanticsMethodImplModuleRefTypeSpecImplMapFieldRvaEncLogEncMapAssemblyAssemblyProcessorAssemblyOsAssemblyRefAssemblyRefProcessorAssemblyRefOsFileExportedTypeManifestResourceNestedClassGenericParamMethodSpecGenericParamConstraint"
+	_TableType_name_1 = "DocumentMethodDebugInformationLocalScopeLocalVariableLocalConstantImportScopeStateMachineMethodCustomDebugInformation"
 )
 
 var (
-	_TableType_index_0 = [...]uint8{0, 6, 13, 20}
-	_TableType_index_3 = [...]uint8{0, 5, 18, 27, 35, 50, 62, 74, 85, 96, 109, 117}
-	_TableType_index_4 = [...]uint8{0, 5, 16}
-	_TableType_index_5 = [...]uint8{0, 8, 23, 33, 42, 50, 57, 65}
-	_TableType_index_6 = [...]uint8{0, 8, 25, 35, 46, 66, 79, 83, 95, 111, 122, 134, 144, 166}
+	_TableType_index_0 = [...]uint16{0, 6, 13, 20, 28, 33, 42, 51, 59, 64, 77, 86, 94, 109, 121, 133, 144, 155, 168, 176, 184, 189, 200, 211, 219, 234, 244, 253, 261, 268, 276, 282, 288, 296, 313, 323, 334, 354, 367, 371, 383, 399, 410, 422, 432, 454}
+	_TableType_index_1 = [...]uint8{0, 8, 30, 40, 53, 66, 77, 95, 117}
 )
 
 func (i TableType) String() string {
 	switch {
-	case 0 <= i && i <= 2:
+	case 0 <= i && i <= 44:
 		return _TableType_name_0[_TableType_index_0[i]:_TableType_index_0[i+1]]
-	case i == 4:
-		return _TableType_name_1
-	case i == 6:
-		return _TableType_name_2
-	case 8 <= i && i <= 18:
-		i -= 8
-		return _TableType_name_3[_TableType_index_3[i]:_TableType_index_3[i+1]]
-	case 20 <= i && i <= 21:
-		i -= 20
-		return _TableType_name_4[_TableType_index_4[i]:_TableType_index_4[i+1]]
-	case 23 <= i && i <= 29:
-		i -= 23
-		return _TableType_name_5[_TableType_index_5[i]:_TableType_index_5[i+1]]
-	case 32 <= i && i <= 44:
-		i -= 32
-		return _TableType_name_6[_TableType_index_6[i]:_TableType_index_6[i+1]]
+	case 48 <= i && i <= 55:
+		i -= 48
+		return _TableType_name_1[_TableType_index_1[i]:_TableType_index_1[i+1]]
 	default:
 		return "TableType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
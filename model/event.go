@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Event is a II.22.13 Event with resolved event type.
+type Event struct {
+	Index types.Index
+	Row   types.Event
+	// Type is a delegate type of Event.
+	Type Ref
+
+	ctx *types.Context
+}
+
+func eventOf(c *types.Context, idx types.Index) (Event, error) {
+	e, err := types.Get[types.Event](c, idx)
+	if err != nil {
+		return Event{}, err
+	}
+
+	typ, err := resolveRef(c, e.EventType)
+	if err != nil {
+		return Event{}, fmt.Errorf("resolve type of event %s: %w", e.Name, err)
+	}
+
+	return Event{
+		Index: idx,
+		Row:   e,
+		Type:  typ,
+		ctx:   c,
+	}, nil
+}
+
+// Events returns events of Type.
+func (t Type) Events() ([]Event, error) {
+	m, ok, err := t.ctx.EventMap(t.Index)
+	if err != nil || !ok {
+		return nil, err
+	}
+	list := m.EventList
+
+	result := make([]Event, 0, list.Size())
+	for i := list.Start(); i < list.End(); i++ {
+		idx, err := t.ctx.ListIndex(md.Event, i)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := eventOf(t.ctx, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// Name returns name of Event.
+func (e Event) Name() string {
+	return e.Row.Name
+}
+
+func (e Event) semantics() types.HasSemantics {
+	return types.CreateHasSemantics(md.Event, e.Index)
+}
+
+// AddMethod returns method which adds event handler.
+// If Event has no such method, ok is false.
+func (e Event) AddMethod() (_ Method, ok bool, _ error) {
+	return semanticMethod(e.ctx, e.semantics(), types.MethodSemanticsAttributes.AddOn)
+}
+
+// RemoveMethod returns method which removes event handler.
+// If Event has no such method, ok is false.
+func (e Event) RemoveMethod() (_ Method, ok bool, _ error) {
+	return semanticMethod(e.ctx, e.semantics(), types.MethodSemanticsAttributes.RemoveOn)
+}
+
+// FireMethod returns method which raises Event.
+// If Event has no such method, ok is false.
+func (e Event) FireMethod() (_ Method, ok bool, _ error) {
+	return semanticMethod(e.ctx, e.semantics(), types.MethodSemanticsAttributes.Fire)
+}
+
+// Attributes returns custom attributes of Event.
+func (e Event) Attributes() ([]Attribute, error) {
+	return attributes(e.ctx, types.CreateHasCustomAttribute(md.Event, e.Index))
+}
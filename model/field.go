@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Field is a II.22.15 Field with decoded signature.
+type Field struct {
+	Index types.Index
+	Row   types.Field
+	Type  types.Element
+
+	ctx *types.Context
+}
+
+func fieldOf(c *types.Context, idx types.Index) (Field, error) {
+	f, err := types.Get[types.Field](c, idx)
+	if err != nil {
+		return Field{}, err
+	}
+
+	sig, err := f.Signature.Reader().Field(c)
+	if err != nil {
+		return Field{}, fmt.Errorf("field %s: %w", f.Name, err)
+	}
+
+	return Field{
+		Index: idx,
+		Row:   f,
+		Type:  sig.Field,
+		ctx:   c,
+	}, nil
+}
+
+// Name returns name of Field.
+func (f Field) Name() string {
+	return f.Row.Name
+}
+
+// Constant returns constant value of Field.
+// If Field has no constant, ok is false.
+func (f Field) Constant() (v interface{}, ok bool, _ error) {
+	return f.ctx.FieldConstant(f.Index)
+}
+
+// Offset returns explicit offset of Field.
+// If Field has no FieldLayout, ok is false.
+func (f Field) Offset() (offset uint32, ok bool, _ error) {
+	l, ok, err := f.ctx.FieldLayout(f.Index)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	return l.Offset, true, nil
+}
+
+// Attributes returns custom attributes of Field.
+func (f Field) Attributes() ([]Attribute, error) {
+	return attributes(f.ctx, types.CreateHasCustomAttribute(md.Field, f.Index))
+}
+
+// Marshal returns marshalling descriptor of Field.
+// If Field has no FieldMarshal, ok is false.
+func (f Field) Marshal() (_ types.MarshalSpec, ok bool, _ error) {
+	return marshalSpec(f.ctx, types.CreateHasFieldMarshall(md.Field, f.Index))
+}
+
+func marshalSpec(c *types.Context, parent types.HasFieldMarshall) (_ types.MarshalSpec, ok bool, _ error) {
+	m, ok, err := c.FieldMarshal(parent)
+	if err != nil || !ok {
+		return types.MarshalSpec{}, false, err
+	}
+
+	spec, err := m.MarshalSpec()
+	if err != nil {
+		return types.MarshalSpec{}, false, err
+	}
+	return spec, true, nil
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// primitiveNames contains names of primitive types.
+var primitiveNames = map[types.ElementTypeKind]string{
+	types.ELEMENT_TYPE_VOID:       "Void",
+	types.ELEMENT_TYPE_BOOLEAN:    "Boolean",
+	types.ELEMENT_TYPE_CHAR:       "Char",
+	types.ELEMENT_TYPE_I1:         "SByte",
+	types.ELEMENT_TYPE_U1:         "Byte",
+	types.ELEMENT_TYPE_I2:         "Int16",
+	types.ELEMENT_TYPE_U2:         "UInt16",
+	types.ELEMENT_TYPE_I4:         "Int32",
+	types.ELEMENT_TYPE_U4:         "UInt32",
+	types.ELEMENT_TYPE_I8:         "Int64",
+	types.ELEMENT_TYPE_U8:         "UInt64",
+	types.ELEMENT_TYPE_R4:         "Single",
+	types.ELEMENT_TYPE_R8:         "Double",
+	types.ELEMENT_TYPE_STRING:     "String",
+	types.ELEMENT_TYPE_OBJECT:     "Object",
+	types.ELEMENT_TYPE_I:          "IntPtr",
+	types.ELEMENT_TYPE_U:          "UIntPtr",
+	types.ELEMENT_TYPE_TYPEDBYREF: "TypedReference",
+}
+
+// TypeName returns display name of ElementType, e.g. "Windows.Foundation.Collections.IVector`1<String>".
+//
+// Generic variables are named using given generic parameter names. If name is unknown,
+// variable is formatted as "!0" or "!!0".
+func TypeName(c *types.Context, t types.ElementType, typeParams, methodParams []string) (string, error) {
+	f := formatter{
+		ctx:          c,
+		typeParams:   typeParams,
+		methodParams: methodParams,
+	}
+	if err := f.elementType(t); err != nil {
+		return "", err
+	}
+	return f.buf.String(), nil
+}
+
+type formatter struct {
+	ctx          *types.Context
+	typeParams   []string
+	methodParams []string
+	buf          strings.Builder
+}
+
+func (f *formatter) variable(prefix string, names []string, idx uint32) {
+	if idx < uint32(len(names)) {
+		f.buf.WriteString(names[idx])
+		return
+	}
+	f.buf.WriteString(prefix)
+	f.buf.WriteString(strconv.FormatUint(uint64(idx), 10))
+}
+
+func (f *formatter) element(e types.Element) error {
+	if err := f.elementType(e.Type); err != nil {
+		return err
+	}
+	f.buf.WriteString(strings.Repeat("*", e.Pointers))
+	if e.ByRef {
+		f.buf.WriteByte('&')
+	}
+	return nil
+}
+
+func (f *formatter) elementType(t types.ElementType) error {
+	if name, ok := primitiveNames[t.Kind]; ok {
+		f.buf.WriteString(name)
+		return nil
+	}
+
+	switch t.Kind {
+	case types.ELEMENT_TYPE_VAR:
+		f.variable("!", f.typeParams, t.GenericTypeVar.Index)
+	case types.ELEMENT_TYPE_MVAR:
+		f.variable("!!", f.methodParams, t.GenericMethodVar.Index)
+	case types.ELEMENT_TYPE_PTR:
+		if err := f.element(*t.Ptr.Elem); err != nil {
+			return err
+		}
+		f.buf.WriteByte('*')
+	case types.ELEMENT_TYPE_SZARRAY:
+		if err := f.element(*t.SZArray.Elem); err != nil {
+			return err
+		}
+		f.buf.WriteString("[]")
+	case types.ELEMENT_TYPE_ARRAY:
+		if err := f.element(*t.Array.Elem); err != nil {
+			return err
+		}
+		f.buf.WriteByte('[')
+		if rank := t.Array.Shape.Rank; rank > 1 {
+			f.buf.WriteString(strings.Repeat(",", int(rank-1)))
+		}
+		f.buf.WriteByte(']')
+	case types.ELEMENT_TYPE_FNPTR:
+		sig := t.FnPtr.Signature
+		if err := f.element(sig.Return); err != nil {
+			return err
+		}
+		f.buf.WriteString(" *(")
+		for i, p := range sig.Params {
+			if i > 0 {
+				f.buf.WriteString(", ")
+			}
+			if err := f.element(p); err != nil {
+				return err
+			}
+		}
+		f.buf.WriteByte(')')
+	case types.ELEMENT_TYPE_CLASS, types.ELEMENT_TYPE_VALUETYPE, types.ELEMENT_TYPE_GENERICINST:
+		namespace, name, err := f.ctx.ResolveTypeDefOrRefName(t.TypeDef.Index)
+		if err != nil {
+			return err
+		}
+		if namespace != "" {
+			f.buf.WriteString(namespace)
+			f.buf.WriteByte('.')
+		}
+		f.buf.WriteString(name)
+
+		if t.Kind != types.ELEMENT_TYPE_GENERICINST {
+			return nil
+		}
+		f.buf.WriteByte('<')
+		for i, arg := range t.TypeDef.Generics {
+			if i > 0 {
+				f.buf.WriteString(", ")
+			}
+			if err := f.elementType(arg); err != nil {
+				return err
+			}
+		}
+		f.buf.WriteByte('>')
+	default:
+		f.buf.WriteString(t.Kind.String())
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// genericParamNames returns names of generic parameters, ordered by number.
+func genericParamNames(params []types.GenericParam) []string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// GenericParamNames returns names of generic parameters of Type, e.g. ["K", "V"].
+func (t Type) GenericParamNames() ([]string, error) {
+	params, err := t.GenericParams()
+	if err != nil {
+		return nil, err
+	}
+	return genericParamNames(params), nil
+}
+
+// GenericParams returns generic parameters of Method, ordered by number.
+func (m Method) GenericParams() ([]types.GenericParam, error) {
+	return m.ctx.GenericParams(types.CreateTypeOrMethodDef(md.MethodDef, m.Index))
+}
+
+// Instantiate returns copy of Method with every MVAR replaced by given arguments.
+func (m Method) Instantiate(args []types.ElementType) (Method, error) {
+	if count := m.Signature.GenericArgCount; uint32(len(args)) != count {
+		return Method{}, fmt.Errorf("method %s: expected %d generic arguments, got %d", m.Name(), count, len(args))
+	}
+
+	sig, err := m.Signature.Substitute(nil, args)
+	if err != nil {
+		return Method{}, fmt.Errorf("method %s: %w", m.Name(), err)
+	}
+	m.Signature = sig
+	return m, nil
+}
+
+// Instance is a generic Type instantiated with concrete arguments, e.g. IVector<String>.
+type Instance struct {
+	// Type is a generic type definition.
+	Type Type
+	Args []types.ElementType
+}
+
+// Instantiate instantiates generic Type with given arguments.
+func (t Type) Instantiate(args []types.ElementType) (Instance, error) {
+	params, err := t.GenericParams()
+	if err != nil {
+		return Instance{}, err
+	}
+	if len(params) != len(args) {
+		return Instance{}, fmt.Errorf("%s: expected %d generic arguments, got %d", t, len(params), len(args))
+	}
+
+	return Instance{
+		Type: t,
+		Args: args,
+	}, nil
+}
+
+// Instantiate finds generic type of reference and instantiates it with reference arguments.
+// If generic type is not defined in given Context, ok is false.
+func (r Ref) Instantiate(c *types.Context) (_ Instance, ok bool, _ error) {
+	t, ok, err := r.Resolve(c)
+	if err != nil || !ok {
+		return Instance{}, false, err
+	}
+
+	i, err := t.Instantiate(r.Generics)
+	if err != nil {
+		return Instance{}, false, err
+	}
+	return i, true, nil
+}
+
+// InstanceOf finds generic type of GENERICINST ElementType and instantiates it.
+// If generic type is not defined in given Context, ok is false.
+func InstanceOf(c *types.Context, t types.ElementType) (_ Instance, ok bool, _ error) {
+	if t.Kind != types.ELEMENT_TYPE_GENERICINST {
+		return Instance{}, false, fmt.Errorf("unexpected element type %v", t.Kind)
+	}
+
+	return Ref{
+		Type:     t.TypeDef.Index,
+		Generics: t.TypeDef.Generics,
+	}.Instantiate(c)
+}
+
+// ElementType returns GENERICINST ElementType of Instance.
+func (i Instance) ElementType() types.ElementType {
+	var valueType bool
+	switch i.Type.Kind {
+	case KindEnum, KindStruct, KindUnion:
+		valueType = true
+	}
+
+	return types.ElementType{
+		Kind: types.ELEMENT_TYPE_GENERICINST,
+		TypeDef: types.ElementTypeTypeDef{
+			Index:       types.CreateTypeDefOrRef(md.TypeDef, i.Type.Index),
+			IsValueType: valueType,
+			Generics:    i.Args,
+		},
+	}
+}
+
+// Name returns display name of Instance, e.g. "Windows.Foundation.Collections.IVector`1<String>".
+func (i Instance) Name() (string, error) {
+	return TypeName(i.Type.ctx, i.ElementType(), nil, nil)
+}
+
+// Fields returns fields of generic type with substituted types.
+func (i Instance) Fields() ([]Field, error) {
+	fields, err := i.Type.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, f := range fields {
+		typ, err := f.Type.Substitute(i.Args, nil)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+		fields[idx].Type = typ
+	}
+	return fields, nil
+}
+
+// Methods returns methods of generic type with substituted signatures.
+//
+// Generic method variables are kept, use Method.Instantiate to substitute them.
+func (i Instance) Methods() ([]Method, error) {
+	methods, err := i.Type.Methods()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, m := range methods {
+		sig, err := m.Signature.Substitute(i.Args, nil)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.Name(), err)
+		}
+		methods[idx].Signature = sig
+	}
+	return methods, nil
+}
+
+// Properties returns properties of generic type with substituted signatures.
+func (i Instance) Properties() ([]Property, error) {
+	props, err := i.Type.Properties()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, p := range props {
+		sig, err := p.Signature.Substitute(i.Args)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", p.Name(), err)
+		}
+		props[idx].Signature = sig
+	}
+	return props, nil
+}
+
+func (i Instance) substituteRef(r Ref) (Ref, error) {
+	if r.Generics == nil {
+		return r, nil
+	}
+
+	generics := make([]types.ElementType, len(r.Generics))
+	for idx, arg := range r.Generics {
+		s, err := arg.Substitute(i.Args, nil)
+		if err != nil {
+			return Ref{}, fmt.Errorf("%s: %w", r, err)
+		}
+		generics[idx] = s
+	}
+	r.Generics = generics
+	return r, nil
+}
+
+// Events returns events of generic type with substituted event types.
+func (i Instance) Events() ([]Event, error) {
+	events, err := i.Type.Events()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, e := range events {
+		typ, err := i.substituteRef(e.Type)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", e.Name(), err)
+		}
+		events[idx].Type = typ
+	}
+	return events, nil
+}
+
+// Interfaces returns interfaces implemented by generic type with substituted arguments.
+//
+// Returned references can be instantiated using Ref.Instantiate.
+func (i Instance) Interfaces() ([]Ref, error) {
+	ifaces, err := i.Type.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, r := range ifaces {
+		s, err := i.substituteRef(r)
+		if err != nil {
+			return nil, err
+		}
+		ifaces[idx] = s
+	}
+	return ifaces, nil
+}
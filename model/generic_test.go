@@ -0,0 +1,238 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+var (
+	stringType = types.ElementType{Kind: types.ELEMENT_TYPE_STRING}
+	int32Type  = types.ElementType{Kind: types.ELEMENT_TYPE_I4}
+)
+
+func typeVar(idx uint32) types.ElementType {
+	return types.ElementType{
+		Kind:           types.ELEMENT_TYPE_VAR,
+		GenericTypeVar: types.ElementTypeGenericTypeVar{Index: idx},
+	}
+}
+
+func genericInst(def types.Index, args ...types.ElementType) types.ElementType {
+	return types.ElementType{
+		Kind: types.ELEMENT_TYPE_GENERICINST,
+		TypeDef: types.ElementTypeTypeDef{
+			Index:    types.CreateTypeDefOrRef(md.TypeDef, def),
+			Generics: args,
+		},
+	}
+}
+
+type encoder interface {
+	Encode() (types.Signature, error)
+}
+
+func encode(sig encoder) types.Signature {
+	s, err := sig.Encode()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// instanceMethod encodes signature of instance method.
+func instanceMethod(ret types.ElementType, params ...types.ElementType) types.Signature {
+	m := types.MethodSignature{
+		Flags:  0x20, // HASTHIS
+		Return: types.Element{Type: ret},
+	}
+	for _, p := range params {
+		m.Params = append(m.Params, types.Element{Type: p})
+	}
+	return encode(m)
+}
+
+// genericParams appends generic parameters of given TypeDef.
+func genericParams(w *types.Writer, def types.Index, names ...string) {
+	for i, name := range names {
+		(&types.GenericParam{
+			Number: uint16(i),
+			Owner:  types.CreateTypeOrMethodDef(md.TypeDef, def),
+			Name:   name,
+		}).AppendTo(w)
+	}
+}
+
+// genericWriter creates metadata with WinRT-like generic collection interfaces.
+func genericWriter() *types.Writer {
+	w := types.NewWriter()
+	(&types.Module{Name: "Windows.Foundation.winmd"}).AppendTo(w)
+	typeDef(w, types.TypeDef{TypeName: "<Module>"})
+
+	const (
+		namespace = "Windows.Foundation.Collections"
+		flags     = 0x4a1 // Public | Interface | Abstract | WindowsRuntime
+	)
+
+	iterable := typeDef(w, types.TypeDef{Flags: flags, TypeName: "IIterable`1", TypeNamespace: namespace})
+	iterator := iterable + 1
+	(&types.MethodDef{Flags: 0x5c6, Name: "First", Signature: instanceMethod(genericInst(iterator-1, typeVar(0)))}).AppendTo(w)
+	genericParams(w, iterable-1, "T")
+
+	typeDef(w, types.TypeDef{Flags: flags, TypeName: "IIterator`1", TypeNamespace: namespace})
+	getCurrent := (&types.MethodDef{Flags: 0xdc6, Name: "get_Current", Signature: instanceMethod(typeVar(0))}).AppendTo(w)
+	genericParams(w, iterator-1, "T")
+	current := (&types.Property{Name: "Current", Type: encode(types.PropertySignature{
+		HasThis: true,
+		Type:    types.Element{Type: typeVar(0)},
+	})}).AppendTo(w)
+	(&types.PropertyMap{Parent: iterator, PropertyList: types.List{current - 1, current - 1}}).AppendTo(w)
+	(&types.MethodSemantics{
+		Semantics:   0x2, // Getter
+		Method:      getCurrent,
+		Association: types.CreateHasSemantics(md.Property, current-1),
+	}).AppendTo(w)
+
+	pair := typeDef(w, types.TypeDef{Flags: flags, TypeName: "IKeyValuePair`2", TypeNamespace: namespace})
+	(&types.MethodDef{Flags: 0xdc6, Name: "get_Key", Signature: instanceMethod(typeVar(0))}).AppendTo(w)
+	(&types.MethodDef{Flags: 0xdc6, Name: "get_Value", Signature: instanceMethod(typeVar(1))}).AppendTo(w)
+	genericParams(w, pair-1, "K", "V")
+
+	m := typeDef(w, types.TypeDef{Flags: flags, TypeName: "IMap`2", TypeNamespace: namespace})
+	(&types.MethodDef{Flags: 0x5c6, Name: "Lookup", Signature: instanceMethod(typeVar(1), typeVar(0))}).AppendTo(w)
+	(&types.MethodDef{Flags: 0x5c6, Name: "Convert", Signature: encode(types.MethodSignature{
+		Flags:           0x30, // HASTHIS | GENERIC
+		GenericArgCount: 1,
+		Return: types.Element{Type: types.ElementType{
+			Kind:             types.ELEMENT_TYPE_MVAR,
+			GenericMethodVar: types.ElementTypeGenericMethodVar{Index: 0},
+		}},
+		Params: []types.Element{{Type: typeVar(1)}},
+	})}).AppendTo(w)
+	genericParams(w, m-1, "K", "V")
+	// IMap<K, V> requires IIterable<IKeyValuePair<K, V>>.
+	spec := (&types.TypeSpec{Signature: encode(genericInst(iterable-1, genericInst(pair-1, typeVar(0), typeVar(1))))}).AppendTo(w)
+	(&types.InterfaceImpl{Class: m, Interface: types.CreateTypeDefOrRef(md.TypeSpec, spec-1)}).AppendTo(w)
+
+	return w
+}
+
+func TestInstance(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, genericWriter())
+
+	imap := findType(a, c, "Windows.Foundation.Collections", "IMap`2")
+	names, err := imap.GenericParamNames()
+	a.NoError(err)
+	a.Equal([]string{"K", "V"}, names)
+
+	_, err = imap.Instantiate([]types.ElementType{stringType})
+	a.Error(err)
+
+	inst, err := imap.Instantiate([]types.ElementType{stringType, int32Type})
+	a.NoError(err)
+	name, err := inst.Name()
+	a.NoError(err)
+	a.Equal("Windows.Foundation.Collections.IMap`2<String, Int32>", name)
+
+	t.Run("Methods", func(t *testing.T) {
+		a := require.New(t)
+
+		methods, err := inst.Methods()
+		a.NoError(err)
+		a.Len(methods, 2)
+
+		lookup := methods[0]
+		a.Equal(types.ELEMENT_TYPE_I4, lookup.Signature.Return.Type.Kind)
+		a.Equal(types.ELEMENT_TYPE_STRING, lookup.Signature.Params[0].Type.Kind)
+
+		convert := methods[1]
+		a.Equal(types.ELEMENT_TYPE_MVAR, convert.Signature.Return.Type.Kind)
+		a.Equal(types.ELEMENT_TYPE_I4, convert.Signature.Params[0].Type.Kind)
+
+		_, err = convert.Instantiate(nil)
+		a.Error(err)
+		convert, err = convert.Instantiate([]types.ElementType{stringType})
+		a.NoError(err)
+		a.Equal(types.ELEMENT_TYPE_STRING, convert.Signature.Return.Type.Kind)
+
+		// Generic definition is not modified.
+		methods, err = imap.Methods()
+		a.NoError(err)
+		a.Equal(types.ELEMENT_TYPE_VAR, methods[0].Signature.Return.Type.Kind)
+	})
+	t.Run("Interfaces", func(t *testing.T) {
+		a := require.New(t)
+
+		ifaces, err := inst.Interfaces()
+		a.NoError(err)
+		a.Len(ifaces, 1)
+		a.Equal("Windows.Foundation.Collections.IIterable`1", ifaces[0].String())
+
+		// IIterable<IKeyValuePair<String, Int32>>
+		iterable, ok, err := ifaces[0].Instantiate(c)
+		a.NoError(err)
+		a.True(ok)
+		name, err := iterable.Name()
+		a.NoError(err)
+		a.Equal("Windows.Foundation.Collections.IIterable`1<Windows.Foundation.Collections.IKeyValuePair`2<String, Int32>>", name)
+
+		// IIterator<IKeyValuePair<String, Int32>> First()
+		methods, err := iterable.Methods()
+		a.NoError(err)
+		a.Len(methods, 1)
+		iterator, ok, err := InstanceOf(c, methods[0].Signature.Return.Type)
+		a.NoError(err)
+		a.True(ok)
+
+		// IKeyValuePair<String, Int32> Current { get; }
+		props, err := iterator.Properties()
+		a.NoError(err)
+		a.Len(props, 1)
+		pair, ok, err := InstanceOf(c, props[0].Type().Type)
+		a.NoError(err)
+		a.True(ok)
+
+		methods, err = pair.Methods()
+		a.NoError(err)
+		a.Len(methods, 2)
+		a.Equal(types.ELEMENT_TYPE_STRING, methods[0].Signature.Return.Type.Kind)
+		a.Equal(types.ELEMENT_TYPE_I4, methods[1].Signature.Return.Type.Kind)
+	})
+}
+
+func TestTypeName(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, genericWriter())
+
+	iterable := findType(a, c, "Windows.Foundation.Collections", "IIterable`1")
+	methods, err := iterable.Methods()
+	a.NoError(err)
+	ret := methods[0].Signature.Return.Type
+
+	names, err := iterable.GenericParamNames()
+	a.NoError(err)
+	name, err := TypeName(c, ret, names, nil)
+	a.NoError(err)
+	a.Equal("Windows.Foundation.Collections.IIterator`1<T>", name)
+
+	name, err = TypeName(c, ret, nil, nil)
+	a.NoError(err)
+	a.Equal("Windows.Foundation.Collections.IIterator`1<!0>", name)
+
+	name, err = TypeName(c, types.ElementType{
+		Kind: types.ELEMENT_TYPE_SZARRAY,
+		SZArray: types.ElementTypeSZArray{Elem: &types.Element{
+			Type: types.ElementType{
+				Kind:             types.ELEMENT_TYPE_MVAR,
+				GenericMethodVar: types.ElementTypeGenericMethodVar{Index: 0},
+			},
+			Pointers: 1,
+		}},
+	}, nil, []string{"U"})
+	a.NoError(err)
+	a.Equal("U*[]", name)
+}
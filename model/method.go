@@ -0,0 +1,198 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Method is a II.22.26 MethodDef with decoded signature.
+type Method struct {
+	Index     types.Index
+	Row       types.MethodDef
+	Signature types.MethodSignature
+
+	ctx *types.Context
+}
+
+func methodOf(c *types.Context, idx types.Index) (Method, error) {
+	m, err := types.Get[types.MethodDef](c, idx)
+	if err != nil {
+		return Method{}, err
+	}
+
+	sig, err := m.Signature.Reader().Method(c)
+	if err != nil {
+		return Method{}, fmt.Errorf("method %s: %w", m.Name, err)
+	}
+
+	return Method{
+		Index:     idx,
+		Row:       m,
+		Signature: sig,
+		ctx:       c,
+	}, nil
+}
+
+// Name returns name of Method.
+func (m Method) Name() string {
+	return m.Row.Name
+}
+
+// paramRows returns Param rows of Method by their sequence number.
+func (m Method) paramRows() (map[uint16]types.Index, error) {
+	list := m.Row.ParamList
+
+	result := make(map[uint16]types.Index, list.Size())
+	for i := list.Start(); i < list.End(); i++ {
+		idx, err := m.ctx.ListIndex(md.Param, i)
+		if err != nil {
+			return nil, err
+		}
+
+		seq, err := m.ctx.Uint32(md.Param, idx, 1)
+		if err != nil {
+			return nil, err
+		}
+		result[uint16(seq)] = idx
+	}
+	return result, nil
+}
+
+func (m Method) param(rows map[uint16]types.Index, seq uint16, typ types.Element) (Param, error) {
+	p := Param{
+		Type: typ,
+		ctx:  m.ctx,
+	}
+	p.Row.Sequence = seq
+
+	idx, ok := rows[seq]
+	if !ok {
+		return p, nil
+	}
+
+	row, err := types.Get[types.Param](m.ctx, idx)
+	if err != nil {
+		return Param{}, err
+	}
+	p.Index, p.Row, p.HasRow = idx, row, true
+	return p, nil
+}
+
+// Params returns parameters of Method.
+//
+// Parameters are matched with Param rows by sequence number, parameter without row
+// has no name and attributes.
+func (m Method) Params() ([]Param, error) {
+	rows, err := m.paramRows()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Param, 0, len(m.Signature.Params))
+	for i, typ := range m.Signature.Params {
+		p, err := m.param(rows, uint16(i+1), typ)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Return returns return value of Method.
+func (m Method) Return() (Param, error) {
+	rows, err := m.paramRows()
+	if err != nil {
+		return Param{}, err
+	}
+	return m.param(rows, 0, m.Signature.Return)
+}
+
+// PInvoke is a P/Invoke information of Method.
+type PInvoke struct {
+	Flags      types.PInvokeAttributes
+	ImportName string
+	// Module is a name of imported module, e.g. "KERNEL32.dll".
+	Module string
+}
+
+// PInvoke returns P/Invoke information of Method.
+// If Method is not imported, ok is false.
+func (m Method) PInvoke() (_ PInvoke, ok bool, _ error) {
+	implMap, ok, err := m.ctx.ImplMap(types.CreateMemberForwarded(md.MethodDef, m.Index))
+	if err != nil || !ok {
+		return PInvoke{}, false, err
+	}
+
+	module, err := implMap.ResolveImportScope(m.ctx)
+	if err != nil {
+		return PInvoke{}, false, err
+	}
+
+	return PInvoke{
+		Flags:      implMap.MappingFlags,
+		ImportName: implMap.ImportName,
+		Module:     module.Name,
+	}, true, nil
+}
+
+// Semantics returns semantics of Method, e.g. property getter or event adder.
+//
+// Method without semantics returns zero value.
+func (m Method) Semantics() (types.MethodSemanticsAttributes, error) {
+	rows, err := m.ctx.MethodSemantics(m.Index)
+	if err != nil {
+		return 0, err
+	}
+
+	var result types.MethodSemanticsAttributes
+	for _, row := range rows {
+		result |= row.Semantics
+	}
+	return result, nil
+}
+
+// Attributes returns custom attributes of Method.
+func (m Method) Attributes() ([]Attribute, error) {
+	return attributes(m.ctx, types.CreateHasCustomAttribute(md.MethodDef, m.Index))
+}
+
+// SupportedArchitecture returns set of architectures, on which Method is available.
+func (m Method) SupportedArchitecture() (types.Architecture, error) {
+	return m.ctx.SupportedArchitecture(types.CreateHasCustomAttribute(md.MethodDef, m.Index))
+}
+
+// Param is a parameter or return value of Method.
+type Param struct {
+	// Index is a Param row index, valid only if HasRow is true.
+	Index  types.Index
+	Row    types.Param
+	HasRow bool
+	Type   types.Element
+
+	ctx *types.Context
+}
+
+// Name returns name of Param.
+func (p Param) Name() string {
+	return p.Row.Name
+}
+
+// Attributes returns custom attributes of Param.
+func (p Param) Attributes() ([]Attribute, error) {
+	if !p.HasRow {
+		return nil, nil
+	}
+	return attributes(p.ctx, types.CreateHasCustomAttribute(md.Param, p.Index))
+}
+
+// Marshal returns marshalling descriptor of Param.
+// If Param has no FieldMarshal, ok is false.
+func (p Param) Marshal() (_ types.MarshalSpec, ok bool, _ error) {
+	if !p.HasRow {
+		return types.MarshalSpec{}, false, nil
+	}
+	return marshalSpec(p.ctx, types.CreateHasFieldMarshall(md.Param, p.Index))
+}
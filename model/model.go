@@ -0,0 +1,175 @@
+// Package model provides navigable object model over metadata tables.
+//
+// Model entities are thin wrappers over decoded rows and types.Context, related entities
+// are resolved lazily.
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Types returns all TypeDefs of given Context, including nested ones.
+func Types(c *types.Context) ([]Type, error) {
+	var (
+		count  = c.RowCount(md.TypeDef)
+		result = make([]Type, 0, count)
+	)
+	for i := types.Index(0); i < count; i++ {
+		t, err := TypeOf(c, i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// FindType finds top-level TypeDef with given namespace and name.
+// If there is no such TypeDef, ok is false.
+func FindType(c *types.Context, namespace, name string) (_ Type, ok bool, _ error) {
+	idx, ok, err := c.FindTypeDef(namespace, name)
+	if err != nil || !ok {
+		return Type{}, false, err
+	}
+
+	t, err := TypeOf(c, idx)
+	if err != nil {
+		return Type{}, false, err
+	}
+	return t, true, nil
+}
+
+// FindTypeArch finds top-level TypeDef with given namespace and name, which is available on
+// target architecture.
+//
+// If there is no such TypeDef, ok is false. If TypeDef is defined only for other architectures,
+// types.ArchitectureError is returned.
+func FindTypeArch(c *types.Context, namespace, name string, target types.Architecture) (_ Type, ok bool, _ error) {
+	idx, ok, err := c.FindTypeDefArch(namespace, name, target)
+	if err != nil || !ok {
+		return Type{}, false, err
+	}
+
+	t, err := TypeOf(c, idx)
+	if err != nil {
+		return Type{}, false, err
+	}
+	return t, true, nil
+}
+
+// Ref is a resolved TypeDefOrRef reference.
+//
+// Referenced type may be defined in other metadata file.
+type Ref struct {
+	// Ref is an original reference, it may point to TypeSpec.
+	Ref types.TypeDefOrRef
+	// Type is a TypeDef or TypeRef of referenced type.
+	// If reference is a generic instantiation, it is a generic type.
+	Type types.TypeDefOrRef
+	// Namespace and Name of referenced type.
+	// If reference is a generic instantiation, they are names of generic type.
+	Namespace string
+	Name      string
+	// Generics contains generic arguments of TypeSpec instantiation.
+	Generics []types.ElementType
+}
+
+// String returns full name of referenced type.
+func (r Ref) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "." + r.Name
+}
+
+// Resolve finds referenced TypeDef in given Context.
+// If TypeDef is not defined in this Context, ok is false.
+func (r Ref) Resolve(c *types.Context) (_ Type, ok bool, _ error) {
+	return r.ResolveArch(c, types.ArchitectureAny)
+}
+
+// ResolveArch finds referenced TypeDef in given Context, which is available on target architecture.
+//
+// If TypeDef is not defined in this Context, ok is false. If TypeDef is defined only for
+// other architectures, types.ArchitectureError is returned.
+func (r Ref) ResolveArch(c *types.Context, target types.Architecture) (_ Type, ok bool, _ error) {
+	idx, ok, err := c.ResolveTypeDefOrRefArch(r.Type, target)
+	if err != nil || !ok {
+		return Type{}, false, err
+	}
+
+	t, err := TypeOf(c, idx)
+	if err != nil {
+		return Type{}, false, err
+	}
+	return t, true, nil
+}
+
+func resolveRef(c *types.Context, ref types.TypeDefOrRef) (Ref, error) {
+	tt, ok := ref.Table()
+	if !ok {
+		return Ref{}, fmt.Errorf("unexpected tag %v", ref)
+	}
+	r := Ref{Ref: ref}
+
+	if tt == md.TypeSpec {
+		sig, err := c.Signature(md.TypeSpec, ref.TableIndex(), 0)
+		if err != nil {
+			return Ref{}, err
+		}
+		spec, err := sig.Reader().TypeSpec(c)
+		if err != nil {
+			return Ref{}, fmt.Errorf("decode TypeSpec(%d): %w", ref.TableIndex(), err)
+		}
+		if spec.Kind != types.ELEMENT_TYPE_GENERICINST {
+			return Ref{}, fmt.Errorf("unexpected TypeSpec(%d) kind %v", ref.TableIndex(), spec.Kind)
+		}
+		ref = spec.TypeDef.Index
+		r.Generics = spec.TypeDef.Generics
+	}
+
+	namespace, name, err := c.ResolveTypeDefOrRefName(ref)
+	if err != nil {
+		return Ref{}, err
+	}
+	r.Type, r.Namespace, r.Name = ref, namespace, name
+	return r, nil
+}
+
+// Attribute is a custom attribute attached to metadata entity.
+type Attribute struct {
+	types.NamedAttribute
+
+	ctx *types.Context
+}
+
+// Value decodes attribute value.
+func (a Attribute) Value() (types.CustomAttributeValue, error) {
+	return a.Row.Decode(a.ctx)
+}
+
+// FindAttribute finds attribute with given namespace and name.
+func FindAttribute(attrs []Attribute, namespace, name string) (Attribute, bool) {
+	for _, attr := range attrs {
+		if attr.Namespace == namespace && attr.Name == name {
+			return attr, true
+		}
+	}
+	return Attribute{}, false
+}
+
+func attributes(c *types.Context, parent types.HasCustomAttribute) ([]Attribute, error) {
+	attrs, err := c.NamedAttributes(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Attribute, len(attrs))
+	for i, attr := range attrs {
+		result[i] = Attribute{NamedAttribute: attr, ctx: c}
+	}
+	return result, nil
+}
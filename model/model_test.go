@@ -0,0 +1,470 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// typeDef appends TypeDef which owns all fields and methods added after it.
+func typeDef(w *types.Writer, def types.TypeDef) types.Index {
+	def.FieldList = types.List{w.RowCount(md.Field), w.RowCount(md.Field)}
+	def.MethodList = types.List{w.RowCount(md.MethodDef), w.RowCount(md.MethodDef)}
+	return def.AppendTo(w)
+}
+
+func testWriter() *types.Writer {
+	w := types.NewWriter()
+
+	(&types.Module{Name: "Test.winmd"}).AppendTo(w)
+	mscorlib := (&types.AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	typeRef := func(namespace, name string) types.TypeDefOrRef {
+		idx := (&types.TypeRef{
+			ResolutionScope: types.CreateResolutionScope(md.AssemblyRef, mscorlib-1),
+			TypeName:        name,
+			TypeNamespace:   namespace,
+		}).AppendTo(w)
+		return types.CreateTypeDefOrRef(md.TypeRef, idx-1)
+	}
+	var (
+		object    = typeRef("System", "Object")
+		enum      = typeRef("System", "Enum")
+		valueType = typeRef("System", "ValueType")
+		delegate  = typeRef("System", "MulticastDelegate")
+		guidAttr  = typeRef("Windows.Win32.Foundation.Metadata", "GuidAttribute")
+	)
+	guidCtor := (&types.MemberRef{
+		Class:     types.CreateMemberRefParent(md.TypeRef, guidAttr.TableIndex()),
+		Name:      ".ctor",
+		Signature: types.Signature{0x20, 0x01, 0x01, 0x0e},
+	}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{TypeName: "<Module>"})
+
+	// Interface with property and event.
+	iface := typeDef(w, types.TypeDef{
+		Flags:         0xa1, // Public | Interface | Abstract
+		TypeName:      "IFoo",
+		TypeNamespace: "Test",
+	})
+	getValue := (&types.MethodDef{Flags: 0x9c6, Name: "get_Value", Signature: types.Signature{0x20, 0x00, 0x08}}).AppendTo(w)
+	putValue := (&types.MethodDef{Flags: 0x9c6, Name: "put_Value", Signature: types.Signature{0x20, 0x01, 0x01, 0x08}}).AppendTo(w)
+	addChanged := (&types.MethodDef{Flags: 0x9c6, Name: "add_Changed", Signature: types.Signature{0x20, 0x01, 0x01, 0x12, 0x15}}).AppendTo(w)
+	removeChanged := (&types.MethodDef{Flags: 0x9c6, Name: "remove_Changed", Signature: types.Signature{0x20, 0x01, 0x01, 0x12, 0x15}}).AppendTo(w)
+	value := (&types.Property{Name: "Value", Type: types.Signature{0x28, 0x00, 0x08}}).AppendTo(w)
+	(&types.PropertyMap{Parent: iface, PropertyList: types.List{value - 1, value - 1}}).AppendTo(w)
+	// MethodSemantics are added out of order to check sorting.
+	(&types.MethodSemantics{
+		Semantics:   0x1, // Setter
+		Method:      putValue,
+		Association: types.CreateHasSemantics(md.Property, value-1),
+	}).AppendTo(w)
+	(&types.CustomAttribute{
+		Parent: types.CreateHasCustomAttribute(md.TypeDef, iface-1),
+		Type:   types.CreateCustomAttributeType(md.MemberRef, guidCtor-1),
+		Value:  types.Blob{0x01, 0x00, 0x03, 'I', 'I', 'D', 0x00, 0x00},
+	}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{Flags: 0x101, TypeName: "Color", TypeNamespace: "Test", Extends: enum})
+	(&types.Field{Flags: 0x606, Name: "value__", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	red := (&types.Field{Flags: 0x8056, Name: "Red", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.Constant{Type: types.ELEMENT_TYPE_I4, Parent: types.CreateHasConstant(md.Field, red-1), Value: types.Blob{1, 0, 0, 0}}).AppendTo(w)
+
+	rect := typeDef(w, types.TypeDef{Flags: 0x109, TypeName: "RECT", TypeNamespace: "Test", Extends: valueType})
+	(&types.Field{Flags: 0x6, Name: "left", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.Field{Flags: 0x6, Name: "top", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.ClassLayout{PackingSize: 4, ClassSize: 8, Parent: rect}).AppendTo(w)
+
+	union := typeDef(w, types.TypeDef{Flags: 0x111, TypeName: "_Anonymous_e__Union", Extends: valueType})
+	a := (&types.Field{Flags: 0x6, Name: "a", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.Field{Flags: 0x6, Name: "b", Signature: types.Signature{0x06, 0x0f, 0x01}}).AppendTo(w)
+	(&types.FieldLayout{Offset: 0, Field: a}).AppendTo(w)
+	(&types.NestedClass{NestedClass: union, EnclosingClass: rect}).AppendTo(w)
+
+	handler := typeDef(w, types.TypeDef{Flags: 0x101, TypeName: "Handler", TypeNamespace: "Test", Extends: delegate})
+	changed := (&types.Event{Name: "Changed", EventType: types.CreateTypeDefOrRef(md.TypeDef, handler-1)}).AppendTo(w)
+	(&types.EventMap{Parent: iface, EventList: types.List{changed - 1, changed - 1}}).AppendTo(w)
+	(&types.MethodSemantics{
+		Semantics:   0x8, // AddOn
+		Method:      addChanged,
+		Association: types.CreateHasSemantics(md.Event, changed-1),
+	}).AppendTo(w)
+	(&types.MethodSemantics{
+		Semantics:   0x2, // Getter
+		Method:      getValue,
+		Association: types.CreateHasSemantics(md.Property, value-1),
+	}).AppendTo(w)
+	(&types.MethodSemantics{
+		Semantics:   0x10, // RemoveOn
+		Method:      removeChanged,
+		Association: types.CreateHasSemantics(md.Event, changed-1),
+	}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{Flags: 0x181, TypeName: "Apis", TypeNamespace: "Test", Extends: object})
+	beep := (&types.MethodDef{Flags: 0x2096, Name: "Beep", Signature: types.Signature{0x00, 0x02, 0x02, 0x09, 0x09}}).AppendTo(w)
+	(&types.Param{Sequence: 1, Name: "freq"}).AppendTo(w)
+	kernel32 := (&types.ModuleRef{Name: "KERNEL32.dll"}).AppendTo(w)
+	(&types.ImplMap{
+		MappingFlags:    0x100, // NoMangle
+		MemberForwarded: types.CreateMemberForwarded(md.MethodDef, beep-1),
+		ImportName:      "Beep",
+		ImportScope:     kernel32,
+	}).AppendTo(w)
+
+	bar := typeDef(w, types.TypeDef{Flags: 0x101, TypeName: "Bar", TypeNamespace: "Test", Extends: object})
+	(&types.InterfaceImpl{Class: bar, Interface: types.CreateTypeDefOrRef(md.TypeDef, iface-1)}).AppendTo(w)
+	(&types.GenericParam{Number: 0, Owner: types.CreateTypeOrMethodDef(md.TypeDef, bar-1), Name: "T"}).AppendTo(w)
+
+	return w
+}
+
+func testContext(a *require.Assertions, w *types.Writer) *types.Context {
+	data, err := w.Metadata()
+	a.NoError(err)
+
+	c, err := types.FromBytes(data)
+	a.NoError(err)
+	return c
+}
+
+func findType(a *require.Assertions, c *types.Context, namespace, name string) Type {
+	t, ok, err := FindType(c, namespace, name)
+	a.NoError(err)
+	a.True(ok, "%s.%s", namespace, name)
+	return t
+}
+
+func TestTypes(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, testWriter())
+
+	all, err := Types(c)
+	a.NoError(err)
+
+	kinds := map[string]Kind{}
+	for _, typ := range all {
+		kinds[typ.String()] = typ.Kind
+	}
+	a.Equal(map[string]Kind{
+		"<Module>":            KindClass,
+		"Test.IFoo":           KindInterface,
+		"Test.Color":          KindEnum,
+		"Test.RECT":           KindStruct,
+		"_Anonymous_e__Union": KindUnion,
+		"Test.Handler":        KindDelegate,
+		"Test.Apis":           KindClass,
+		"Test.Bar":            KindClass,
+	}, kinds)
+
+	_, ok, err := FindType(c, "Test", "Baz")
+	a.NoError(err)
+	a.False(ok)
+}
+
+func TestType(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, testWriter())
+
+	t.Run("Fields", func(t *testing.T) {
+		a := require.New(t)
+
+		color := findType(a, c, "Test", "Color")
+		a.Equal("Test", color.Namespace())
+		a.Equal("Color", color.Name())
+		a.Equal("System.Enum", color.BaseType.String())
+
+		fields, err := color.Fields()
+		a.NoError(err)
+		a.Len(fields, 2)
+		a.Equal("value__", fields[0].Name())
+		a.Equal(types.ELEMENT_TYPE_I4, fields[0].Type.Type.Kind)
+
+		v, ok, err := fields[1].Constant()
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(int32(1), v)
+	})
+	t.Run("Layout", func(t *testing.T) {
+		a := require.New(t)
+
+		rect := findType(a, c, "Test", "RECT")
+		l, ok, err := rect.Layout()
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(uint16(4), l.PackingSize)
+		a.Equal(uint32(8), l.ClassSize)
+
+		nested, err := rect.NestedTypes()
+		a.NoError(err)
+		a.Len(nested, 1)
+		union := nested[0]
+		a.Equal(KindUnion, union.Kind)
+
+		enclosing, ok, err := union.EnclosingType()
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(rect.Index, enclosing.Index)
+
+		_, ok, err = rect.EnclosingType()
+		a.NoError(err)
+		a.False(ok)
+
+		fields, err := union.Fields()
+		a.NoError(err)
+		a.Len(fields, 2)
+		offset, ok, err := fields[0].Offset()
+		a.NoError(err)
+		a.True(ok)
+		a.Zero(offset)
+		a.Equal(1, fields[1].Type.Pointers)
+	})
+	t.Run("Interfaces", func(t *testing.T) {
+		a := require.New(t)
+
+		bar := findType(a, c, "Test", "Bar")
+		ifaces, err := bar.Interfaces()
+		a.NoError(err)
+		a.Len(ifaces, 1)
+		a.Equal("Test.IFoo", ifaces[0].String())
+
+		iface, ok, err := ifaces[0].Resolve(c)
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(KindInterface, iface.Kind)
+
+		attrs, err := iface.Attributes()
+		a.NoError(err)
+		guid, ok := FindAttribute(attrs, "Windows.Win32.Foundation.Metadata", "GuidAttribute")
+		a.True(ok)
+		v, err := guid.Value()
+		a.NoError(err)
+		a.Equal("IID", v.Fixed[0].Value)
+
+		params, err := bar.GenericParams()
+		a.NoError(err)
+		a.Len(params, 1)
+		a.Equal("T", params[0].Name)
+
+		_, ok, err = bar.BaseType.Resolve(c)
+		a.NoError(err)
+		a.False(ok)
+	})
+	t.Run("PropertiesEvents", func(t *testing.T) {
+		a := require.New(t)
+
+		iface := findType(a, c, "Test", "IFoo")
+		props, err := iface.Properties()
+		a.NoError(err)
+		a.Len(props, 1)
+		a.Equal("Value", props[0].Name())
+
+		events, err := iface.Events()
+		a.NoError(err)
+		a.Len(events, 1)
+		a.Equal("Changed", events[0].Name())
+		a.Equal("Test.Handler", events[0].Type.String())
+
+		methods, err := iface.Methods()
+		a.NoError(err)
+		a.Len(methods, 4)
+		for i, expect := range []types.MethodSemanticsAttributes{0x2, 0x1, 0x8, 0x10} {
+			s, err := methods[i].Semantics()
+			a.NoError(err)
+			a.Equal(expect, s)
+		}
+
+		props, err = findType(a, c, "Test", "Bar").Properties()
+		a.NoError(err)
+		a.Empty(props)
+	})
+}
+
+func TestMethod(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, testWriter())
+
+	methods, err := findType(a, c, "Test", "Apis").Methods()
+	a.NoError(err)
+	a.Len(methods, 1)
+	beep := methods[0]
+	a.Equal("Beep", beep.Name())
+
+	params, err := beep.Params()
+	a.NoError(err)
+	a.Len(params, 2)
+	a.True(params[0].HasRow)
+	a.Equal("freq", params[0].Name())
+	a.Equal(types.ELEMENT_TYPE_U4, params[0].Type.Type.Kind)
+	a.False(params[1].HasRow)
+	a.Equal(uint16(2), params[1].Row.Sequence)
+
+	ret, err := beep.Return()
+	a.NoError(err)
+	a.False(ret.HasRow)
+	a.Equal(types.ELEMENT_TYPE_BOOLEAN, ret.Type.Type.Kind)
+
+	p, ok, err := beep.PInvoke()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal(PInvoke{Flags: 0x100, ImportName: "Beep", Module: "KERNEL32.dll"}, p)
+
+	s, err := beep.Semantics()
+	a.NoError(err)
+	a.Zero(s)
+
+	assocs, err := beep.Associations()
+	a.NoError(err)
+	a.Empty(assocs)
+}
+
+func TestProperty(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, testWriter())
+
+	props, err := findType(a, c, "Test", "IFoo").Properties()
+	a.NoError(err)
+	a.Len(props, 1)
+	value := props[0]
+	a.True(value.Signature.HasThis)
+	a.Equal(types.ELEMENT_TYPE_I4, value.Type().Type.Kind)
+
+	get, ok, err := value.GetMethod()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal("get_Value", get.Name())
+
+	set, ok, err := value.SetMethod()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal("put_Value", set.Name())
+
+	assocs, err := get.Associations()
+	a.NoError(err)
+	a.Len(assocs, 1)
+	a.True(assocs[0].Semantics.Getter())
+	a.Nil(assocs[0].Event)
+	a.NotNil(assocs[0].Property)
+	a.Equal(value.Index, assocs[0].Property.Index)
+}
+
+func TestEvent(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, testWriter())
+
+	events, err := findType(a, c, "Test", "IFoo").Events()
+	a.NoError(err)
+	a.Len(events, 1)
+	changed := events[0]
+
+	add, ok, err := changed.AddMethod()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal("add_Changed", add.Name())
+
+	remove, ok, err := changed.RemoveMethod()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal("remove_Changed", remove.Name())
+
+	_, ok, err = changed.FireMethod()
+	a.NoError(err)
+	a.False(ok)
+
+	assocs, err := remove.Associations()
+	a.NoError(err)
+	a.Len(assocs, 1)
+	a.True(assocs[0].Semantics.RemoveOn())
+	a.Nil(assocs[0].Property)
+	a.NotNil(assocs[0].Event)
+	a.Equal("Changed", assocs[0].Event.Name())
+	a.Equal("Test.Handler", assocs[0].Event.Type.String())
+}
+
+func TestRef_ResolveArch(t *testing.T) {
+	a := require.New(t)
+
+	const (
+		namespace = "Test"
+		metadata  = "Windows.Win32.Foundation.Metadata"
+	)
+	w := types.NewWriter()
+	(&types.Module{Name: "Test.winmd"}).AppendTo(w)
+	mscorlib := (&types.AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	typeRef := func(scope types.ResolutionScope, namespace, name string) types.Index {
+		return (&types.TypeRef{ResolutionScope: scope, TypeName: name, TypeNamespace: namespace}).AppendTo(w)
+	}
+	var (
+		scope     = types.CreateResolutionScope(md.AssemblyRef, mscorlib-1)
+		valueType = typeRef(scope, "System", "ValueType")
+		archAttr  = typeRef(scope, metadata, "SupportedArchitectureAttribute")
+		// Architecture enum is not defined in this file.
+		archEnum = typeRef(scope, metadata, "Architecture")
+		ref      = typeRef(types.CreateResolutionScope(md.Module, 0), namespace, "POINT")
+	)
+	archCtor := (&types.MemberRef{
+		Class: types.CreateMemberRefParent(md.TypeRef, archAttr-1),
+		Name:  ".ctor",
+		// instance void .ctor(valuetype Architecture)
+		Signature: types.Signature{0x20, 0x01, 0x01, 0x11, byte(archEnum<<2 | 1)},
+	}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{TypeName: "<Module>"})
+	point := func(arch types.Architecture, field types.ElementTypeKind) types.Index {
+		idx := typeDef(w, types.TypeDef{
+			Flags:         0x109,
+			TypeName:      "POINT",
+			TypeNamespace: namespace,
+			Extends:       types.CreateTypeDefOrRef(md.TypeRef, valueType-1),
+		})
+		(&types.Field{Flags: 0x6, Name: "x", Signature: types.Signature{0x06, byte(field)}}).AppendTo(w)
+		(&types.Field{Flags: 0x6, Name: "y", Signature: types.Signature{0x06, byte(field)}}).AppendTo(w)
+		(&types.CustomAttribute{
+			Parent: types.CreateHasCustomAttribute(md.TypeDef, idx-1),
+			Type:   types.CreateCustomAttributeType(md.MemberRef, archCtor-1),
+			Value:  types.Blob{0x01, 0x00, byte(arch), 0x00, 0x00, 0x00, 0x00, 0x00},
+		}).AppendTo(w)
+		return idx
+	}
+	x86 := point(types.ArchitectureX86, types.ELEMENT_TYPE_R4)
+	x64 := point(types.ArchitectureX64, types.ELEMENT_TYPE_R8)
+	typeDef(w, types.TypeDef{
+		Flags:         0x100001, // Public | BeforeFieldInit
+		TypeName:      "Derived",
+		TypeNamespace: namespace,
+		Extends:       types.CreateTypeDefOrRef(md.TypeRef, ref-1),
+	})
+	c := testContext(a, w)
+
+	derived := findType(a, c, namespace, "Derived")
+	a.NotNil(derived.BaseType)
+	for _, tt := range []struct {
+		target types.Architecture
+		expect types.Index
+	}{
+		{types.ArchitectureAny, x86},
+		{types.ArchitectureX86, x86},
+		{types.ArchitectureX64, x64},
+	} {
+		base, ok, err := derived.BaseType.ResolveArch(c, tt.target)
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(tt.expect-1, base.Index, "%s", tt.target)
+	}
+	_, _, err := derived.BaseType.ResolveArch(c, types.ArchitectureArm64)
+	var archErr *types.ArchitectureError
+	a.ErrorAs(err, &archErr)
+
+	// WinRT signatures use the same architecture.
+	elem := types.ElementType{
+		Kind:    types.ELEMENT_TYPE_VALUETYPE,
+		TypeDef: types.ElementTypeTypeDef{Index: types.CreateTypeDefOrRef(md.TypeRef, ref-1)},
+	}
+	sig, err := WinRT{Architecture: types.ArchitectureX64}.Signature(c, elem)
+	a.NoError(err)
+	a.Equal("struct(Test.POINT;f8;f8)", sig)
+	_, err = WinRT{Architecture: types.ArchitectureArm64}.Signature(c, elem)
+	a.ErrorAs(err, &archErr)
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Property is a II.22.34 Property with decoded signature.
+type Property struct {
+	Index     types.Index
+	Row       types.Property
+	Signature types.PropertySignature
+
+	ctx *types.Context
+}
+
+func propertyOf(c *types.Context, idx types.Index) (Property, error) {
+	p, err := types.Get[types.Property](c, idx)
+	if err != nil {
+		return Property{}, err
+	}
+
+	sig, err := p.Type.Reader().Property(c)
+	if err != nil {
+		return Property{}, fmt.Errorf("property %s: %w", p.Name, err)
+	}
+
+	return Property{
+		Index:     idx,
+		Row:       p,
+		Signature: sig,
+		ctx:       c,
+	}, nil
+}
+
+// Properties returns properties of Type.
+func (t Type) Properties() ([]Property, error) {
+	m, ok, err := t.ctx.PropertyMap(t.Index)
+	if err != nil || !ok {
+		return nil, err
+	}
+	list := m.PropertyList
+
+	result := make([]Property, 0, list.Size())
+	for i := list.Start(); i < list.End(); i++ {
+		idx, err := t.ctx.ListIndex(md.Property, i)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := propertyOf(t.ctx, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Name returns name of Property.
+func (p Property) Name() string {
+	return p.Row.Name
+}
+
+// Type returns type of Property.
+func (p Property) Type() types.Element {
+	return p.Signature.Type
+}
+
+func (p Property) semantics() types.HasSemantics {
+	return types.CreateHasSemantics(md.Property, p.Index)
+}
+
+// GetMethod returns getter of Property.
+// If Property has no getter, ok is false.
+func (p Property) GetMethod() (_ Method, ok bool, _ error) {
+	return semanticMethod(p.ctx, p.semantics(), types.MethodSemanticsAttributes.Getter)
+}
+
+// SetMethod returns setter of Property.
+// If Property has no setter, ok is false.
+func (p Property) SetMethod() (_ Method, ok bool, _ error) {
+	return semanticMethod(p.ctx, p.semantics(), types.MethodSemanticsAttributes.Setter)
+}
+
+// Constant returns default value of Property.
+// If Property has no default value, ok is false.
+func (p Property) Constant() (v interface{}, ok bool, _ error) {
+	return p.ctx.PropertyConstant(p.Index)
+}
+
+// Attributes returns custom attributes of Property.
+func (p Property) Attributes() ([]Attribute, error) {
+	return attributes(p.ctx, types.CreateHasCustomAttribute(md.Property, p.Index))
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// semanticMethod finds method of given Property or Event with matching semantics.
+func semanticMethod(
+	c *types.Context,
+	association types.HasSemantics,
+	match func(types.MethodSemanticsAttributes) bool,
+) (_ Method, ok bool, _ error) {
+	rows, err := c.Semantics(association)
+	if err != nil {
+		return Method{}, false, err
+	}
+
+	for _, row := range rows {
+		if !match(row.Semantics) {
+			continue
+		}
+
+		m, err := methodOf(c, row.Method-1)
+		if err != nil {
+			return Method{}, false, err
+		}
+		return m, true, nil
+	}
+	return Method{}, false, nil
+}
+
+// Association is a semantic role of Method, e.g. property getter or event adder.
+type Association struct {
+	Semantics types.MethodSemanticsAttributes
+	// Property is an associated Property, nil if Method is not a property accessor.
+	Property *Property
+	// Event is an associated Event, nil if Method is not an event accessor.
+	Event *Event
+}
+
+// Associations returns properties and events which use Method as accessor.
+func (m Method) Associations() ([]Association, error) {
+	rows, err := m.ctx.MethodSemantics(m.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Association, 0, len(rows))
+	for _, row := range rows {
+		tt, ok := row.Association.Table()
+		if !ok {
+			return nil, fmt.Errorf("unexpected tag %v", row.Association)
+		}
+		a := Association{Semantics: row.Semantics}
+
+		switch idx := row.Association.TableIndex(); tt {
+		case md.Property:
+			p, err := propertyOf(m.ctx, idx)
+			if err != nil {
+				return nil, err
+			}
+			a.Property = &p
+		case md.Event:
+			e, err := eventOf(m.ctx, idx)
+			if err != nil {
+				return nil, err
+			}
+			a.Event = &e
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
@@ -0,0 +1,223 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Kind is a kind of Type.
+type Kind uint8
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=Kind -trimprefix=Kind
+
+const (
+	// KindClass is a reference type, e.g. "Apis" class of Win32 metadata or WinRT runtime class.
+	KindClass Kind = iota
+	// KindInterface is an interface type.
+	KindInterface
+	// KindEnum is a type derived from System.Enum.
+	KindEnum
+	// KindStruct is a type derived from System.ValueType.
+	KindStruct
+	// KindUnion is a type derived from System.ValueType with explicit layout.
+	KindUnion
+	// KindDelegate is a type derived from System.MulticastDelegate.
+	KindDelegate
+)
+
+// Type is a II.22.37 TypeDef with related rows.
+type Type struct {
+	Index types.Index
+	Row   types.TypeDef
+	Kind  Kind
+	// BaseType is a type which this Type extends.
+	// If Type has no base type, BaseType is nil.
+	//
+	// Base TypeDef may be defined for several architectures, use Ref.ResolveArch to select one.
+	BaseType *Ref
+
+	ctx *types.Context
+}
+
+// TypeOf creates Type from TypeDef with given index.
+func TypeOf(c *types.Context, idx types.Index) (Type, error) {
+	def, err := types.Get[types.TypeDef](c, idx)
+	if err != nil {
+		return Type{}, err
+	}
+	t := Type{
+		Index: idx,
+		Row:   def,
+		ctx:   c,
+	}
+
+	if !def.Extends.IsNull() {
+		base, err := resolveRef(c, def.Extends)
+		if err != nil {
+			return Type{}, fmt.Errorf("resolve base type of %s.%s: %w", def.TypeNamespace, def.TypeName, err)
+		}
+		t.BaseType = &base
+	}
+	t.Kind = typeKind(def, t.BaseType)
+
+	return t, nil
+}
+
+func typeKind(def types.TypeDef, base *Ref) Kind {
+	if def.Flags.Interface() {
+		return KindInterface
+	}
+	if base == nil || base.Namespace != "System" {
+		return KindClass
+	}
+
+	switch base.Name {
+	case "Enum":
+		return KindEnum
+	case "ValueType":
+		if def.Flags.ExplicitLayout() {
+			return KindUnion
+		}
+		return KindStruct
+	case "MulticastDelegate":
+		return KindDelegate
+	default:
+		return KindClass
+	}
+}
+
+// Namespace returns namespace of Type.
+func (t Type) Namespace() string {
+	return t.Row.TypeNamespace
+}
+
+// Name returns name of Type.
+func (t Type) Name() string {
+	return t.Row.TypeName
+}
+
+// String returns full name of Type.
+func (t Type) String() string {
+	if t.Row.TypeNamespace == "" {
+		return t.Row.TypeName
+	}
+	return t.Row.TypeNamespace + "." + t.Row.TypeName
+}
+
+// Fields returns fields of Type.
+func (t Type) Fields() ([]Field, error) {
+	list := t.Row.FieldList
+
+	result := make([]Field, 0, list.Size())
+	for i := list.Start(); i < list.End(); i++ {
+		idx, err := t.ctx.ListIndex(md.Field, i)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := fieldOf(t.ctx, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// Methods returns methods of Type.
+func (t Type) Methods() ([]Method, error) {
+	list := t.Row.MethodList
+
+	result := make([]Method, 0, list.Size())
+	for i := list.Start(); i < list.End(); i++ {
+		idx, err := t.ctx.ListIndex(md.MethodDef, i)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := methodOf(t.ctx, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// Interfaces returns interfaces implemented by Type.
+func (t Type) Interfaces() ([]Ref, error) {
+	impls, err := t.ctx.InterfaceImpls(t.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Ref, 0, len(impls))
+	for _, idx := range impls {
+		impl, err := types.Get[types.InterfaceImpl](t.ctx, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		ref, err := resolveRef(t.ctx, impl.Interface)
+		if err != nil {
+			return nil, fmt.Errorf("%s: resolve interface: %w", t, err)
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
+// NestedTypes returns types nested into Type.
+func (t Type) NestedTypes() ([]Type, error) {
+	nested, err := t.ctx.NestedClasses(t.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Type, 0, len(nested))
+	for _, idx := range nested {
+		n, err := TypeOf(t.ctx, idx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// EnclosingType returns type which encloses Type.
+// If Type is not nested, ok is false.
+func (t Type) EnclosingType() (_ Type, ok bool, _ error) {
+	idx, ok, err := t.ctx.EnclosingClass(t.Index)
+	if err != nil || !ok {
+		return Type{}, false, err
+	}
+
+	e, err := TypeOf(t.ctx, idx)
+	if err != nil {
+		return Type{}, false, err
+	}
+	return e, true, nil
+}
+
+// Layout returns explicit layout of Type.
+// If Type has no ClassLayout, ok is false.
+func (t Type) Layout() (_ types.ClassLayout, ok bool, _ error) {
+	return t.ctx.ClassLayout(t.Index)
+}
+
+// GenericParams returns generic parameters of Type, ordered by number.
+func (t Type) GenericParams() ([]types.GenericParam, error) {
+	return t.ctx.GenericParams(types.CreateTypeOrMethodDef(md.TypeDef, t.Index))
+}
+
+// Attributes returns custom attributes of Type.
+func (t Type) Attributes() ([]Attribute, error) {
+	return attributes(t.ctx, types.CreateHasCustomAttribute(md.TypeDef, t.Index))
+}
+
+// SupportedArchitecture returns set of architectures, on which Type is available.
+func (t Type) SupportedArchitecture() (types.Architecture, error) {
+	return t.ctx.SupportedArchitecture(types.CreateHasCustomAttribute(md.TypeDef, t.Index))
+}
@@ -0,0 +1,328 @@
+package model
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// GUID returns GUID of Type from GuidAttribute.
+// If Type has no GuidAttribute, ok is false.
+func (t Type) GUID() (_ md.GUID, ok bool, _ error) {
+	attrs, err := t.Attributes()
+	if err != nil {
+		return md.GUID{}, false, err
+	}
+
+	for _, attr := range attrs {
+		if attr.Name != "GuidAttribute" {
+			continue
+		}
+		v, err := attr.Value()
+		if err != nil {
+			return md.GUID{}, false, err
+		}
+
+		g, err := guidFromArgs(v.Fixed)
+		if err != nil {
+			return md.GUID{}, false, fmt.Errorf("%s: %w", t, err)
+		}
+		return g, true, nil
+	}
+	return md.GUID{}, false, nil
+}
+
+// guidFromArgs decodes GUID from GuidAttribute(UInt32, UInt16, UInt16, Byte, ..., Byte) arguments.
+func guidFromArgs(args []types.CustomAttributeArg) (g md.GUID, _ error) {
+	if len(args) != 11 {
+		return g, fmt.Errorf("unexpected GuidAttribute argument count %d", len(args))
+	}
+
+	a, ok1 := args[0].Value.(uint32)
+	b, ok2 := args[1].Value.(uint16)
+	c, ok3 := args[2].Value.(uint16)
+	if !ok1 || !ok2 || !ok3 {
+		return g, fmt.Errorf("unexpected GuidAttribute argument types")
+	}
+	binary.LittleEndian.PutUint32(g[0:4], a)
+	binary.LittleEndian.PutUint16(g[4:6], b)
+	binary.LittleEndian.PutUint16(g[6:8], c)
+	for i, arg := range args[3:] {
+		v, ok := arg.Value.(uint8)
+		if !ok {
+			return g, fmt.Errorf("unexpected GuidAttribute argument %d type", i+3)
+		}
+		g[8+i] = v
+	}
+	return g, nil
+}
+
+// pinterfaceNamespace is a namespace GUID of WinRT parameterized interface IIDs,
+// 11f47ad5-7b73-42c0-abae-878b1e16adee in big-endian form.
+var pinterfaceNamespace = [16]byte{
+	0x11, 0xf4, 0x7a, 0xd5, 0x7b, 0x73, 0x42, 0xc0,
+	0xab, 0xae, 0x87, 0x8b, 0x1e, 0x16, 0xad, 0xee,
+}
+
+// SignatureIID computes IID of WinRT parameterized type from its type signature
+// using UUIDv5 (SHA-1) algorithm.
+func SignatureIID(signature string) md.GUID {
+	h := sha1.New()
+	h.Write(pinterfaceNamespace[:])
+	h.Write([]byte(signature))
+	sum := h.Sum(nil)
+
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+
+	// Hash is in big-endian form, GUID stores the first three groups as little-endian integers.
+	var g md.GUID
+	binary.LittleEndian.PutUint32(g[0:4], binary.BigEndian.Uint32(sum[0:4]))
+	binary.LittleEndian.PutUint16(g[4:6], binary.BigEndian.Uint16(sum[4:6]))
+	binary.LittleEndian.PutUint16(g[6:8], binary.BigEndian.Uint16(sum[6:8]))
+	copy(g[8:], sum[8:16])
+	return g
+}
+
+// fundamentalSignatures contains WinRT type signatures of fundamental types.
+var fundamentalSignatures = map[types.ElementTypeKind]string{
+	types.ELEMENT_TYPE_BOOLEAN: "b1",
+	types.ELEMENT_TYPE_CHAR:    "c2",
+	types.ELEMENT_TYPE_I1:      "i1",
+	types.ELEMENT_TYPE_U1:      "u1",
+	types.ELEMENT_TYPE_I2:      "i2",
+	types.ELEMENT_TYPE_U2:      "u2",
+	types.ELEMENT_TYPE_I4:      "i4",
+	types.ELEMENT_TYPE_U4:      "u4",
+	types.ELEMENT_TYPE_I8:      "i8",
+	types.ELEMENT_TYPE_U8:      "u8",
+	types.ELEMENT_TYPE_R4:      "f4",
+	types.ELEMENT_TYPE_R8:      "f8",
+	types.ELEMENT_TYPE_STRING:  "string",
+	types.ELEMENT_TYPE_OBJECT:  "cinterface(IInspectable)",
+}
+
+// WinRT computes WinRT type signatures and IIDs of parameterized types.
+type WinRT struct {
+	// Universe is used to resolve types defined in other files.
+	// If nil, types are resolved in the file of the signature.
+	Universe *types.Universe
+	// Architecture is a target architecture used to select TypeDefs.
+	// Zero value is types.ArchitectureAny.
+	Architecture types.Architecture
+}
+
+// Signature returns WinRT type signature of closed type, e.g.
+// "pinterface({faa585ea-6214-4217-afda-7f46de5869b3};string)" for IIterable<String>.
+func (w WinRT) Signature(c *types.Context, t types.ElementType) (string, error) {
+	var buf strings.Builder
+	if err := w.elementType(&buf, c, t); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// IID returns IID of closed generic interface or delegate instantiation.
+func (w WinRT) IID(c *types.Context, t types.ElementType) (md.GUID, error) {
+	if t.Kind != types.ELEMENT_TYPE_GENERICINST {
+		return md.GUID{}, fmt.Errorf("unexpected element type %v", t.Kind)
+	}
+
+	sig, err := w.Signature(c, t)
+	if err != nil {
+		return md.GUID{}, err
+	}
+	return SignatureIID(sig), nil
+}
+
+func (w WinRT) resolve(c *types.Context, ref types.TypeDefOrRef) (Type, error) {
+	if w.Universe != nil {
+		r, err := w.Universe.ResolveTypeDefOrRefArch(c, ref, w.Architecture)
+		if err != nil {
+			return Type{}, err
+		}
+		return TypeOf(r.Context, r.Index)
+	}
+
+	idx, ok, err := c.ResolveTypeDefOrRefArch(ref, w.Architecture)
+	if err != nil {
+		return Type{}, err
+	}
+	if !ok {
+		namespace, name, err := c.ResolveTypeDefOrRefName(ref)
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{}, fmt.Errorf("type %s.%s is not defined", namespace, name)
+	}
+	return TypeOf(c, idx)
+}
+
+func (w WinRT) elementType(buf *strings.Builder, c *types.Context, t types.ElementType) error {
+	if sig, ok := fundamentalSignatures[t.Kind]; ok {
+		buf.WriteString(sig)
+		return nil
+	}
+
+	switch t.Kind {
+	case types.ELEMENT_TYPE_CLASS, types.ELEMENT_TYPE_VALUETYPE:
+		namespace, name, err := c.ResolveTypeDefOrRefName(t.TypeDef.Index)
+		if err != nil {
+			return err
+		}
+		if namespace == "System" && name == "Guid" {
+			buf.WriteString("g16")
+			return nil
+		}
+
+		typ, err := w.resolve(c, t.TypeDef.Index)
+		if err != nil {
+			return err
+		}
+		return w.typeDef(buf, typ)
+	case types.ELEMENT_TYPE_GENERICINST:
+		typ, err := w.resolve(c, t.TypeDef.Index)
+		if err != nil {
+			return err
+		}
+		if err := w.guid(buf, "pinterface(", typ); err != nil {
+			return err
+		}
+		for _, arg := range t.TypeDef.Generics {
+			buf.WriteByte(';')
+			if err := w.elementType(buf, c, arg); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(')')
+		return nil
+	case types.ELEMENT_TYPE_VAR, types.ELEMENT_TYPE_MVAR:
+		return fmt.Errorf("open generic type has no signature")
+	default:
+		return fmt.Errorf("unexpected element type %v", t.Kind)
+	}
+}
+
+// guid writes prefix and braced GUID of given type.
+func (w WinRT) guid(buf *strings.Builder, prefix string, t Type) error {
+	g, ok, err := t.GUID()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s has no GuidAttribute", t)
+	}
+
+	buf.WriteString(prefix)
+	buf.WriteByte('{')
+	buf.WriteString(g.String())
+	buf.WriteByte('}')
+	return nil
+}
+
+func (w WinRT) typeDef(buf *strings.Builder, t Type) error {
+	switch t.Kind {
+	case KindInterface:
+		return w.guid(buf, "", t)
+	case KindDelegate:
+		if err := w.guid(buf, "delegate(", t); err != nil {
+			return err
+		}
+		buf.WriteByte(')')
+		return nil
+	case KindEnum, KindStruct:
+		fields, err := t.Fields()
+		if err != nil {
+			return err
+		}
+
+		prefix := "struct("
+		if t.Kind == KindEnum {
+			prefix = "enum("
+		}
+		buf.WriteString(prefix)
+		buf.WriteString(t.String())
+		for _, f := range fields {
+			if f.Row.Flags.Static() {
+				continue
+			}
+			buf.WriteByte(';')
+			if err := w.elementType(buf, t.ctx, f.Type.Type); err != nil {
+				return fmt.Errorf("%s.%s: %w", t, f.Name(), err)
+			}
+		}
+		buf.WriteByte(')')
+		return nil
+	case KindClass:
+		iface, err := defaultInterface(t)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("rc(")
+		buf.WriteString(t.String())
+		buf.WriteByte(';')
+		if err := w.elementType(buf, t.ctx, iface); err != nil {
+			return err
+		}
+		buf.WriteByte(')')
+		return nil
+	default:
+		return fmt.Errorf("%s: unexpected kind %v", t, t.Kind)
+	}
+}
+
+// defaultInterface finds interface of runtime class marked with DefaultAttribute.
+func defaultInterface(t Type) (types.ElementType, error) {
+	impls, err := t.ctx.InterfaceImpls(t.Index)
+	if err != nil {
+		return types.ElementType{}, err
+	}
+	for _, idx := range impls {
+		attrs, err := attributes(t.ctx, types.CreateHasCustomAttribute(md.InterfaceImpl, idx))
+		if err != nil {
+			return types.ElementType{}, err
+		}
+		if _, ok := FindAttribute(attrs, "Windows.Foundation.Metadata", "DefaultAttribute"); !ok {
+			continue
+		}
+
+		impl, err := types.Get[types.InterfaceImpl](t.ctx, idx)
+		if err != nil {
+			return types.ElementType{}, err
+		}
+		ref, err := resolveRef(t.ctx, impl.Interface)
+		if err != nil {
+			return types.ElementType{}, err
+		}
+		if ref.Generics == nil {
+			return types.ElementType{
+				Kind:    types.ELEMENT_TYPE_CLASS,
+				TypeDef: types.ElementTypeTypeDef{Index: ref.Type},
+			}, nil
+		}
+		return types.ElementType{
+			Kind:    types.ELEMENT_TYPE_GENERICINST,
+			TypeDef: types.ElementTypeTypeDef{Index: ref.Type, Generics: ref.Generics},
+		}, nil
+	}
+	return types.ElementType{}, fmt.Errorf("runtime class %s has no default interface", t)
+}
+
+// Signature returns WinRT type signature of Instance.
+//
+// Referenced types must be defined in the same file, use WinRT to resolve them across files.
+func (i Instance) Signature() (string, error) {
+	return WinRT{}.Signature(i.Type.ctx, i.ElementType())
+}
+
+// IID returns IID of Instance, e.g. IID of IVector<String>.
+//
+// Referenced types must be defined in the same file, use WinRT to resolve them across files.
+func (i Instance) IID() (md.GUID, error) {
+	return WinRT{}.IID(i.Type.ctx, i.ElementType())
+}
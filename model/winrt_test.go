@@ -0,0 +1,220 @@
+package model
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// guidBlob encodes GuidAttribute value from "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" string.
+func guidBlob(s string) types.Blob {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		panic(err)
+	}
+
+	blob := types.Blob{0x01, 0x00}
+	blob = append(blob, b[3], b[2], b[1], b[0], b[5], b[4], b[7], b[6])
+	blob = append(blob, b[8:]...)
+	return append(blob, 0x00, 0x00)
+}
+
+// winrtWriter creates metadata with WinRT types from Windows.Foundation.
+func winrtWriter() *types.Writer {
+	w := types.NewWriter()
+	(&types.Module{Name: "Windows.Foundation.winmd"}).AppendTo(w)
+	mscorlib := (&types.AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	typeRef := func(namespace, name string) types.TypeDefOrRef {
+		idx := (&types.TypeRef{
+			ResolutionScope: types.CreateResolutionScope(md.AssemblyRef, mscorlib-1),
+			TypeName:        name,
+			TypeNamespace:   namespace,
+		}).AppendTo(w)
+		return types.CreateTypeDefOrRef(md.TypeRef, idx-1)
+	}
+	var (
+		object      = typeRef("System", "Object")
+		enum        = typeRef("System", "Enum")
+		valueType   = typeRef("System", "ValueType")
+		delegate    = typeRef("System", "MulticastDelegate")
+		guidAttr    = typeRef("Windows.Foundation.Metadata", "GuidAttribute")
+		defaultAttr = typeRef("Windows.Foundation.Metadata", "DefaultAttribute")
+	)
+	guidCtor := (&types.MemberRef{
+		Class: types.CreateMemberRefParent(md.TypeRef, guidAttr.TableIndex()),
+		Name:  ".ctor",
+		// instance void .ctor(uint32, uint16, uint16, uint8, ..., uint8)
+		Signature: types.Signature{0x20, 0x0b, 0x01, 0x09, 0x07, 0x07, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05},
+	}).AppendTo(w)
+	defaultCtor := (&types.MemberRef{
+		Class:     types.CreateMemberRefParent(md.TypeRef, defaultAttr.TableIndex()),
+		Name:      ".ctor",
+		Signature: types.Signature{0x20, 0x00, 0x01},
+	}).AppendTo(w)
+	guid := func(def types.Index, s string) {
+		(&types.CustomAttribute{
+			Parent: types.CreateHasCustomAttribute(md.TypeDef, def-1),
+			Type:   types.CreateCustomAttributeType(md.MemberRef, guidCtor-1),
+			Value:  guidBlob(s),
+		}).AppendTo(w)
+	}
+
+	typeDef(w, types.TypeDef{TypeName: "<Module>"})
+
+	const (
+		foundation  = "Windows.Foundation"
+		collections = "Windows.Foundation.Collections"
+		iface       = 0x4a1 // Public | Interface | Abstract | WindowsRuntime
+	)
+	generic := func(namespace, name, iid string, params ...string) {
+		def := typeDef(w, types.TypeDef{Flags: iface, TypeName: name, TypeNamespace: namespace})
+		genericParams(w, def-1, params...)
+		guid(def, iid)
+	}
+	generic(collections, "IIterable`1", "faa585ea-6214-4217-afda-7f46de5869b3", "T")
+	generic(collections, "IVector`1", "913337e9-11a1-4345-a3a2-4e7f956e222d", "T")
+	generic(collections, "IKeyValuePair`2", "02b51929-c1c4-4a7e-8940-0312b5c18500", "K", "V")
+	generic(collections, "IMap`2", "3c2925fe-8519-45c1-aa79-197b6718c1c1", "K", "V")
+	generic(foundation, "IReference`1", "61c17706-2d65-11e0-9ae8-d48564015472", "T")
+	generic(foundation, "IAsyncOperation`1", "9fc2b0bb-e446-44e2-aa61-9cab8f636af2", "TResult")
+
+	typeDef(w, types.TypeDef{Flags: 0x4109, TypeName: "Point", TypeNamespace: foundation, Extends: valueType})
+	(&types.Field{Flags: 0x6, Name: "X", Signature: types.Signature{0x06, 0x0c}}).AppendTo(w)
+	(&types.Field{Flags: 0x6, Name: "Y", Signature: types.Signature{0x06, 0x0c}}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{Flags: 0x4101, TypeName: "AsyncStatus", TypeNamespace: foundation, Extends: enum})
+	(&types.Field{Flags: 0x606, Name: "value__", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.Field{Flags: 0x8056, Name: "Started", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+
+	handler := typeDef(w, types.TypeDef{Flags: 0x4101, TypeName: "AsyncActionCompletedHandler", TypeNamespace: foundation, Extends: delegate})
+	guid(handler, "a4ed5c81-76c9-40bd-8be6-b1d90fb20ae7")
+
+	uriClass := typeDef(w, types.TypeDef{Flags: iface, TypeName: "IUriRuntimeClass", TypeNamespace: foundation})
+	guid(uriClass, "9e365e57-48b2-4160-956f-c7385120bbfc")
+	uri := typeDef(w, types.TypeDef{Flags: 0x4101, TypeName: "Uri", TypeNamespace: foundation, Extends: object})
+	impl := (&types.InterfaceImpl{Class: uri, Interface: types.CreateTypeDefOrRef(md.TypeDef, uriClass-1)}).AppendTo(w)
+	(&types.CustomAttribute{
+		Parent: types.CreateHasCustomAttribute(md.InterfaceImpl, impl-1),
+		Type:   types.CreateCustomAttributeType(md.MemberRef, defaultCtor-1),
+		Value:  types.Blob{0x01, 0x00, 0x00, 0x00},
+	}).AppendTo(w)
+
+	typeDef(w, types.TypeDef{Flags: 0x4101, TypeName: "NoGuid", TypeNamespace: foundation, Extends: delegate})
+
+	return w
+}
+
+func TestSignatureIID(t *testing.T) {
+	a := require.New(t)
+
+	a.Equal(
+		"e2fcc7c1-3bfc-5a0b-b2b0-72e769d1cb7e",
+		SignatureIID("pinterface({faa585ea-6214-4217-afda-7f46de5869b3};string)").String(),
+	)
+}
+
+func TestWinRT(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a, winrtWriter())
+
+	def := func(namespace, name string) types.ElementType {
+		typ := findType(a, c, namespace, name)
+		return types.ElementType{
+			Kind:    types.ELEMENT_TYPE_CLASS,
+			TypeDef: types.ElementTypeTypeDef{Index: types.CreateTypeDefOrRef(md.TypeDef, typ.Index)},
+		}
+	}
+	inst := func(namespace, name string, args ...types.ElementType) types.ElementType {
+		return genericInst(findType(a, c, namespace, name).Index, args...)
+	}
+	const (
+		foundation  = "Windows.Foundation"
+		collections = "Windows.Foundation.Collections"
+	)
+
+	var (
+		point       = def(foundation, "Point")
+		status      = def(foundation, "AsyncStatus")
+		uri         = def(foundation, "Uri")
+		handler     = def(foundation, "AsyncActionCompletedHandler")
+		boolType    = types.ElementType{Kind: types.ELEMENT_TYPE_BOOLEAN}
+		objectType  = types.ElementType{Kind: types.ELEMENT_TYPE_OBJECT}
+		stringPair  = inst(collections, "IKeyValuePair`2", stringType, stringType)
+		vectorOfVar = inst(collections, "IVector`1", typeVar(0))
+	)
+
+	t.Run("Signature", func(t *testing.T) {
+		for _, tt := range []struct {
+			typ       types.ElementType
+			signature string
+		}{
+			{int32Type, "i4"},
+			{objectType, "cinterface(IInspectable)"},
+			{point, "struct(Windows.Foundation.Point;f4;f4)"},
+			{status, "enum(Windows.Foundation.AsyncStatus;i4)"},
+			{handler, "delegate({a4ed5c81-76c9-40bd-8be6-b1d90fb20ae7})"},
+			{uri, "rc(Windows.Foundation.Uri;{9e365e57-48b2-4160-956f-c7385120bbfc})"},
+			{
+				inst(collections, "IVector`1", uri),
+				"pinterface({913337e9-11a1-4345-a3a2-4e7f956e222d};rc(Windows.Foundation.Uri;{9e365e57-48b2-4160-956f-c7385120bbfc}))",
+			},
+			{
+				inst(collections, "IMap`2", stringType, objectType),
+				"pinterface({3c2925fe-8519-45c1-aa79-197b6718c1c1};string;cinterface(IInspectable))",
+			},
+		} {
+			signature, err := WinRT{}.Signature(c, tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.signature, signature)
+		}
+	})
+	t.Run("IID", func(t *testing.T) {
+		for _, tt := range []struct {
+			typ types.ElementType
+			iid string
+		}{
+			{inst(collections, "IIterable`1", stringType), "e2fcc7c1-3bfc-5a0b-b2b0-72e769d1cb7e"},
+			{inst(collections, "IVector`1", stringType), "98b9acc1-4b56-532e-ac73-03d5291cca90"},
+			{stringPair, "60310303-49c5-52e6-abc6-a9b36eccc716"},
+			{inst(collections, "IIterable`1", stringPair), "e9bdaaf0-cbf6-5c72-be90-29cbf3a1319b"},
+			{inst(collections, "IMap`2", stringType, stringType), "f6d1f700-49c2-52ae-8154-826f9908773c"},
+			{inst(foundation, "IReference`1", int32Type), "548cefbd-bc8a-5fa0-8df2-957440fc8bf4"},
+			{inst(foundation, "IReference`1", point), "84f14c22-a00a-5272-8d3d-82112e66df00"},
+			{inst(foundation, "IAsyncOperation`1", boolType), "cdb5efb3-5788-509d-9be1-71ccb8a3362a"},
+		} {
+			iid, err := WinRT{}.IID(c, tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.iid, iid.String())
+		}
+	})
+	t.Run("Instance", func(t *testing.T) {
+		a := require.New(t)
+
+		vector := findType(a, c, collections, "IVector`1")
+		g, ok, err := vector.GUID()
+		a.NoError(err)
+		a.True(ok)
+		a.Equal("913337e9-11a1-4345-a3a2-4e7f956e222d", g.String())
+
+		i, err := vector.Instantiate([]types.ElementType{stringType})
+		a.NoError(err)
+		iid, err := i.IID()
+		a.NoError(err)
+		a.Equal("98b9acc1-4b56-532e-ac73-03d5291cca90", iid.String())
+	})
+	t.Run("Error", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := WinRT{}.Signature(c, vectorOfVar)
+		a.Error(err)
+		_, err = WinRT{}.Signature(c, def(foundation, "NoGuid"))
+		a.Error(err)
+		_, err = WinRT{}.IID(c, point)
+		a.Error(err)
+	})
+}
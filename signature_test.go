@@ -0,0 +1,60 @@
+package win32metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func TestSignatureWriter_RoundTrip(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	roundTrip := func(sig types.Signature) {
+		r := sig.Reader()
+		if r.NextIs(0x06) {
+			field, err := sig.Reader().Field(nil)
+			a.NoError(err)
+			encoded, err := field.Encode()
+			a.NoError(err)
+			a.Equal(sig, encoded)
+			return
+		}
+
+		method, err := sig.Reader().Method(nil)
+		a.NoError(err)
+		encoded, err := method.Encode()
+		a.NoError(err)
+		a.Equal(sig, encoded)
+	}
+
+	for _, tt := range []struct {
+		Table  md.TableType
+		Column uint32
+	}{
+		{md.MethodDef, 4},
+		{md.Field, 2},
+		{md.MemberRef, 2},
+	} {
+		for i := uint32(0); i < c.RowCount(tt.Table); i++ {
+			sig, err := c.Signature(tt.Table, i, tt.Column)
+			a.NoError(err)
+			roundTrip(sig)
+		}
+	}
+
+	table := c.Table(md.TypeSpec)
+	for i := uint32(0); i < table.RowCount(); i++ {
+		var spec types.TypeSpec
+		a.NoError(spec.FromRow(table.Row(i)))
+
+		typ, err := spec.Signature.Reader().TypeSpec(c)
+		a.NoError(err)
+		encoded, err := typ.Encode()
+		a.NoError(err)
+		a.Equal(spec.Signature, encoded)
+	}
+}
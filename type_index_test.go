@@ -0,0 +1,61 @@
+package win32metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func TestContext_TypeIndex(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	namespaces, err := c.Namespaces()
+	a.NoError(err)
+	a.Contains(namespaces, "Windows.Win32.System.Com")
+
+	defs, err := c.NamespaceTypeDefs("Windows.Win32.System.Com")
+	a.NoError(err)
+	unknown, ok, err := c.FindTypeDef("Windows.Win32.System.Com", "IUnknown")
+	a.NoError(err)
+	a.True(ok)
+	a.Contains(defs, unknown)
+
+	_, ok, err = c.FindTypeDef("Windows.Win32.System.Com", "IDoesNotExist")
+	a.NoError(err)
+	a.False(ok)
+
+	// Every TypeRef which is defined in this file must be resolved to TypeDef with the same name.
+	var (
+		typeRefs = c.Table(md.TypeRef)
+		ref      types.TypeRef
+		def      types.TypeDef
+	)
+	for i := uint32(0); i < typeRefs.RowCount(); i++ {
+		a.NoError(ref.FromRow(typeRefs.Row(i)))
+
+		idx, ok, err := c.ResolveTypeDefOrRef(types.CreateTypeDefOrRef(md.TypeRef, i))
+		a.NoError(err)
+		if !ok {
+			continue
+		}
+		a.NoError(def.FromRow(c.Table(md.TypeDef).Row(idx)))
+		a.Equal(ref.TypeName, def.TypeName)
+
+		if tt, _ := ref.ResolutionScope.Table(); tt != md.TypeRef {
+			a.Equal(ref.TypeNamespace, def.TypeNamespace)
+			continue
+		}
+		enclosing, ok, err := c.EnclosingClass(idx)
+		a.NoError(err)
+		a.True(ok)
+
+		nested, ok, err := c.FindNestedTypeDef(enclosing, ref.TypeName)
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(idx, nested)
+	}
+}
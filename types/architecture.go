@@ -0,0 +1,204 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// Architecture is a set of processor architectures, see
+// Windows.Win32.Foundation.Metadata.Architecture enum.
+type Architecture int32
+
+const (
+	// ArchitectureAny disables architecture filtering.
+	ArchitectureAny   Architecture = 0
+	ArchitectureX86   Architecture = 1
+	ArchitectureX64   Architecture = 2
+	ArchitectureArm64 Architecture = 4
+	// ArchitectureAll is a set of all architectures.
+	ArchitectureAll = ArchitectureX86 | ArchitectureX64 | ArchitectureArm64
+)
+
+var architectureNames = []struct {
+	arch Architecture
+	name string
+}{
+	{ArchitectureX86, "X86"},
+	{ArchitectureX64, "X64"},
+	{ArchitectureArm64, "Arm64"},
+}
+
+// String implements fmt.Stringer.
+func (a Architecture) String() string {
+	if a == ArchitectureAny {
+		return "Any"
+	}
+
+	var names []string
+	for _, n := range architectureNames {
+		if a&n.arch != 0 {
+			names = append(names, n.name)
+			a &^= n.arch
+		}
+	}
+	if a != 0 {
+		names = append(names, fmt.Sprintf("0x%x", int32(a)))
+	}
+	return strings.Join(names, "|")
+}
+
+// Supports denotes that set contains given target architecture.
+// Any set supports ArchitectureAny.
+func (a Architecture) Supports(target Architecture) bool {
+	return target == ArchitectureAny || a&target != 0
+}
+
+// ArchitectureOf returns Architecture of given GOARCH value.
+// If architecture is not supported by Windows metadata, ok is false.
+func ArchitectureOf(goarch string) (_ Architecture, ok bool) {
+	switch goarch {
+	case "386":
+		return ArchitectureX86, true
+	case "amd64":
+		return ArchitectureX64, true
+	case "arm64":
+		return ArchitectureArm64, true
+	default:
+		return ArchitectureAny, false
+	}
+}
+
+// ArchitectureError is returned when entity is not available on target architecture.
+type ArchitectureError struct {
+	Namespace string
+	Name      string
+	// Target is a requested architecture.
+	Target Architecture
+	// Supported is a set of architectures, on which entity is available.
+	Supported Architecture
+}
+
+// Error implements error.
+func (e *ArchitectureError) Error() string {
+	return fmt.Sprintf("%s.%s is not available on %s, supported architectures: %s",
+		e.Namespace, e.Name, e.Target, e.Supported)
+}
+
+// architectureDecoder decodes SupportedArchitectureAttribute, whose Architecture enum
+// may be defined in other file than the attribute user.
+var architectureDecoder = CustomAttributeDecoder{
+	ResolveEnum: func(namespace, name string) (ElementTypeKind, error) {
+		if namespace != "Windows.Win32.Foundation.Metadata" || name != "Architecture" {
+			return 0, fmt.Errorf("can't resolve underlying type of enum %s.%s", namespace, name)
+		}
+		return ELEMENT_TYPE_I4, nil
+	},
+}
+
+// SupportedArchitecture returns set of architectures from SupportedArchitectureAttribute of given entity.
+// If entity has no such attribute, ArchitectureAll is returned.
+func (t *Context) SupportedArchitecture(parent HasCustomAttribute) (Architecture, error) {
+	attrs, err := t.NamedAttributes(parent)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, attr := range attrs {
+		if attr.Namespace != "Windows.Win32.Foundation.Metadata" || attr.Name != "SupportedArchitectureAttribute" {
+			continue
+		}
+
+		v, err := architectureDecoder.Decode(t, &attr.Row)
+		if err != nil {
+			return 0, fmt.Errorf("decode SupportedArchitectureAttribute: %w", err)
+		}
+		if len(v.Fixed) != 1 {
+			return 0, fmt.Errorf("unexpected SupportedArchitectureAttribute argument count %d", len(v.Fixed))
+		}
+		arch, ok := v.Fixed[0].Value.(int32)
+		if !ok {
+			return 0, fmt.Errorf("unexpected SupportedArchitectureAttribute argument type %T", v.Fixed[0].Value)
+		}
+		return Architecture(arch), nil
+	}
+	return ArchitectureAll, nil
+}
+
+// selectArch selects first TypeDef available on target architecture.
+//
+// If there are candidates, but none of them is available, ArchitectureError is returned.
+func (t *Context) selectArch(namespace, name string, defs []Index, target Architecture) (_ Index, ok bool, _ error) {
+	if len(defs) < 1 {
+		return 0, false, nil
+	}
+	if target == ArchitectureAny {
+		return defs[0], true, nil
+	}
+
+	var supported Architecture
+	for _, idx := range defs {
+		arch, err := t.SupportedArchitecture(CreateHasCustomAttribute(md.TypeDef, idx))
+		if err != nil {
+			return 0, false, err
+		}
+		if arch.Supports(target) {
+			return idx, true, nil
+		}
+		supported |= arch
+	}
+
+	return 0, false, &ArchitectureError{
+		Namespace: namespace,
+		Name:      name,
+		Target:    target,
+		Supported: supported,
+	}
+}
+
+// FindTypeDefArch returns index of top-level TypeDef with given namespace and name, which
+// is available on target architecture.
+//
+// If there is no such TypeDef, ok is false. If TypeDef is defined only for other architectures,
+// ArchitectureError is returned.
+func (t *Context) FindTypeDefArch(namespace, name string, target Architecture) (_ Index, ok bool, _ error) {
+	defs, err := t.FindTypeDefs(namespace, name)
+	if err != nil {
+		return 0, false, err
+	}
+	return t.selectArch(namespace, name, defs, target)
+}
+
+// FindNestedTypeDefArch returns index of TypeDef with given name nested into given enclosing TypeDef,
+// which is available on target architecture.
+//
+// See FindTypeDefArch.
+func (t *Context) FindNestedTypeDefArch(enclosing Index, name string, target Architecture) (_ Index, ok bool, _ error) {
+	idx, err := t.typeIndex()
+	if err != nil {
+		return 0, false, err
+	}
+	return t.selectArch("", name, idx.types[typeKey{Name: name, Enclosing: enclosing + 1}], target)
+}
+
+// NamespaceTypeDefsArch returns indexes of all top-level TypeDefs in given namespace, which are
+// available on target architecture.
+func (t *Context) NamespaceTypeDefsArch(namespace string, target Architecture) ([]Index, error) {
+	defs, err := t.NamespaceTypeDefs(namespace)
+	if err != nil || target == ArchitectureAny {
+		return defs, err
+	}
+
+	result := make([]Index, 0, len(defs))
+	for _, idx := range defs {
+		arch, err := t.SupportedArchitecture(CreateHasCustomAttribute(md.TypeDef, idx))
+		if err != nil {
+			return nil, err
+		}
+		if arch.Supports(target) {
+			result = append(result, idx)
+		}
+	}
+	return result, nil
+}
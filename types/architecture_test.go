@@ -0,0 +1,156 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+const debugNamespace = "Windows.Win32.System.Diagnostics.Debug"
+
+// archWriter creates metadata with TypeDefs defined for different architectures.
+func archWriter() *Writer {
+	w := NewWriter()
+	(&Module{Name: "Windows.Win32.winmd"}).AppendTo(w)
+	mscorlib := (&AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	scope := CreateResolutionScope(md.AssemblyRef, mscorlib-1)
+	attr := (&TypeRef{
+		ResolutionScope: scope,
+		TypeName:        "SupportedArchitectureAttribute",
+		TypeNamespace:   "Windows.Win32.Foundation.Metadata",
+	}).AppendTo(w)
+	// Architecture enum is not defined in this file.
+	arch := (&TypeRef{
+		ResolutionScope: scope,
+		TypeName:        "Architecture",
+		TypeNamespace:   "Windows.Win32.Foundation.Metadata",
+	}).AppendTo(w)
+	ctor := (&MemberRef{
+		Class: CreateMemberRefParent(md.TypeRef, attr-1),
+		Name:  ".ctor",
+		// instance void .ctor(valuetype Architecture)
+		Signature: Signature{0x20, 0x01, 0x01, 0x11, byte(arch<<2 | 1)},
+	}).AppendTo(w)
+	// TypeRef(2) to CONTEXT defined in this file.
+	(&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.Module, 0),
+		TypeName:        "CONTEXT",
+		TypeNamespace:   debugNamespace,
+	}).AppendTo(w)
+
+	typeDef := func(name string, arch Architecture) {
+		idx := (&TypeDef{
+			Flags:         0x109,
+			TypeName:      name,
+			TypeNamespace: debugNamespace,
+		}).AppendTo(w)
+		if arch == ArchitectureAll {
+			return
+		}
+		(&CustomAttribute{
+			Parent: CreateHasCustomAttribute(md.TypeDef, idx-1),
+			Type:   CreateCustomAttributeType(md.MemberRef, ctor-1),
+			Value:  Blob{0x01, 0x00, byte(arch), 0x00, 0x00, 0x00, 0x00, 0x00},
+		}).AppendTo(w)
+	}
+	(&TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	typeDef("CONTEXT", ArchitectureX86)
+	typeDef("CONTEXT", ArchitectureX64|ArchitectureArm64)
+	typeDef("EXCEPTION_RECORD", ArchitectureAll)
+	typeDef("KNONVOLATILE_CONTEXT_POINTERS", ArchitectureX64)
+
+	return w
+}
+
+func TestArchitecture_String(t *testing.T) {
+	a := require.New(t)
+
+	a.Equal("Any", ArchitectureAny.String())
+	a.Equal("X86", ArchitectureX86.String())
+	a.Equal("X64|Arm64", (ArchitectureX64 | ArchitectureArm64).String())
+	a.Equal("X86|0x8", (ArchitectureX86 | 8).String())
+}
+
+func TestArchitectureOf(t *testing.T) {
+	a := require.New(t)
+
+	arch, ok := ArchitectureOf("amd64")
+	a.True(ok)
+	a.Equal(ArchitectureX64, arch)
+
+	_, ok = ArchitectureOf("riscv64")
+	a.False(ok)
+}
+
+func TestContext_FindTypeDefArch(t *testing.T) {
+	a := require.New(t)
+	c := readPE(a, writePE(a, archWriter()))
+
+	for _, tt := range []struct {
+		name   string
+		target Architecture
+		expect Index
+	}{
+		{"CONTEXT", ArchitectureAny, 1},
+		{"CONTEXT", ArchitectureX86, 1},
+		{"CONTEXT", ArchitectureX64, 2},
+		{"CONTEXT", ArchitectureArm64, 2},
+		{"EXCEPTION_RECORD", ArchitectureArm64, 3},
+		{"KNONVOLATILE_CONTEXT_POINTERS", ArchitectureX64, 4},
+	} {
+		idx, ok, err := c.FindTypeDefArch(debugNamespace, tt.name, tt.target)
+		a.NoError(err)
+		a.True(ok)
+		a.Equal(tt.expect, idx, "%s on %s", tt.name, tt.target)
+	}
+
+	_, ok, err := c.FindTypeDefArch(debugNamespace, "KNONVOLATILE_CONTEXT_POINTERS", ArchitectureArm64)
+	a.False(ok)
+	var archErr *ArchitectureError
+	a.True(errors.As(err, &archErr))
+	a.Equal(ArchitectureX64, archErr.Supported)
+	a.EqualError(err, debugNamespace+".KNONVOLATILE_CONTEXT_POINTERS is not available on Arm64, supported architectures: X64")
+
+	_, ok, err = c.FindTypeDefArch(debugNamespace, "NOT_FOUND", ArchitectureX86)
+	a.NoError(err)
+	a.False(ok)
+
+	// TypeRef resolution.
+	idx, ok, err := c.ResolveTypeDefOrRefArch(CreateTypeDefOrRef(md.TypeRef, 2), ArchitectureX64)
+	a.NoError(err)
+	a.True(ok)
+	a.Equal(Index(2), idx)
+
+	// Name index.
+	defs, err := c.NamespaceTypeDefsArch(debugNamespace, ArchitectureX86)
+	a.NoError(err)
+	a.Equal([]Index{1, 3}, defs)
+	defs, err = c.NamespaceTypeDefsArch(debugNamespace, ArchitectureAny)
+	a.NoError(err)
+	a.Equal([]Index{1, 2, 3, 4}, defs)
+}
+
+func TestUniverse_FindTypeDefArch(t *testing.T) {
+	a := require.New(t)
+	c := readPE(a, writePE(a, archWriter()))
+
+	u, err := NewUniverse(c)
+	a.NoError(err)
+
+	r, ok, err := u.FindTypeDefArch(debugNamespace, "CONTEXT", ArchitectureArm64)
+	a.NoError(err)
+	a.True(ok)
+	a.Same(c, r.Context)
+	a.Equal(Index(2), r.Index)
+
+	_, _, err = u.FindTypeDefArch(debugNamespace, "KNONVOLATILE_CONTEXT_POINTERS", ArchitectureX86)
+	var archErr *ArchitectureError
+	a.True(errors.As(err, &archErr))
+
+	r, err = u.ResolveTypeRefArch(c, 2, ArchitectureX86)
+	a.NoError(err)
+	a.Equal(Index(1), r.Index)
+}
@@ -40,6 +40,12 @@ func (f AssemblyFlags) Retargetable() bool {
 	return f&256 != 0
 }
 
+// WindowsRuntime check WindowsRuntime flag.
+// Denotes: The assembly reference refers to Windows Runtime metadata.
+func (f AssemblyFlags) WindowsRuntime() bool {
+	return f&3584 == 512
+}
+
 // DisableJITcompileOptimizer check DisableJITcompileOptimizer flag.
 func (f AssemblyFlags) DisableJITcompileOptimizer() bool {
 	return f&16384 != 0
@@ -82,6 +82,11 @@ func (t TypeDefOrRef) TableIndex() uint32 {
 	return uint32((t >> 2) - 1)
 }
 
+// IsNull denotes that TypeDefOrRef is a null reference.
+func (t TypeDefOrRef) IsNull() bool {
+	return t>>2 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t TypeDefOrRef) String() string {
 	switch t.Tag() {
@@ -169,6 +174,11 @@ func (t HasConstant) TableIndex() uint32 {
 	return uint32((t >> 2) - 1)
 }
 
+// IsNull denotes that HasConstant is a null reference.
+func (t HasConstant) IsNull() bool {
+	return t>>2 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t HasConstant) String() string {
 	switch t.Tag() {
@@ -368,6 +378,11 @@ func (t HasCustomAttribute) TableIndex() uint32 {
 	return uint32((t >> 5) - 1)
 }
 
+// IsNull denotes that HasCustomAttribute is a null reference.
+func (t HasCustomAttribute) IsNull() bool {
+	return t>>5 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t HasCustomAttribute) String() string {
 	switch t.Tag() {
@@ -420,6 +435,288 @@ func (t HasCustomAttribute) String() string {
 	}
 }
 
+// HasCustomDebugInformation represents composite index one of
+//
+//	MethodDef
+//	Field
+//	TypeRef
+//	TypeDef
+//	Param
+//	InterfaceImpl
+//	MemberRef
+//	Module
+//	Permission
+//	Property
+//	Event
+//	StandAloneSig
+//	ModuleRef
+//	TypeSpec
+//	Assembly
+//	AssemblyRef
+//	File
+//	ExportedType
+//	ManifestResource
+//	GenericParam
+//	GenericParamConstraint
+//	MethodSpec
+//	Document
+//	LocalScope
+//	LocalVariable
+//	LocalConstant
+//	ImportScope
+//
+// table.
+type HasCustomDebugInformation uint32
+
+// CreateHasCustomDebugInformation creates new composite index from given tag and table index.
+func CreateHasCustomDebugInformation(tt md.TableType, idx uint32) HasCustomDebugInformation {
+	var t HasCustomDebugInformation
+	t.Set(tt, idx)
+	return t
+}
+
+// Tag returns HasCustomDebugInformation tag.
+// Tag table:
+//
+//	MethodDef = 0
+//	Field = 1
+//	TypeRef = 2
+//	TypeDef = 3
+//	Param = 4
+//	InterfaceImpl = 5
+//	MemberRef = 6
+//	Module = 7
+//	Permission = 8
+//	Property = 9
+//	Event = 10
+//	StandAloneSig = 11
+//	ModuleRef = 12
+//	TypeSpec = 13
+//	Assembly = 14
+//	AssemblyRef = 15
+//	File = 16
+//	ExportedType = 17
+//	ManifestResource = 18
+//	GenericParam = 19
+//	GenericParamConstraint = 20
+//	MethodSpec = 21
+//	Document = 22
+//	LocalScope = 23
+//	LocalVariable = 24
+//	LocalConstant = 25
+//	ImportScope = 26
+func (t HasCustomDebugInformation) Tag() uint32 {
+	return uint32(t & ((1 << 5) - 1))
+}
+
+// Set sets HasCustomDebugInformation tag and index.
+func (t *HasCustomDebugInformation) Set(tt md.TableType, idx uint32) {
+	var tag uint32
+	switch tt {
+	case md.MethodDef:
+		tag = 0
+	case md.Field:
+		tag = 1
+	case md.TypeRef:
+		tag = 2
+	case md.TypeDef:
+		tag = 3
+	case md.Param:
+		tag = 4
+	case md.InterfaceImpl:
+		tag = 5
+	case md.MemberRef:
+		tag = 6
+	case md.Module:
+		tag = 7
+	// Skip 8 "Permission", there is not such table
+	case md.Property:
+		tag = 9
+	case md.Event:
+		tag = 10
+	case md.StandAloneSig:
+		tag = 11
+	case md.ModuleRef:
+		tag = 12
+	case md.TypeSpec:
+		tag = 13
+	case md.Assembly:
+		tag = 14
+	case md.AssemblyRef:
+		tag = 15
+	case md.File:
+		tag = 16
+	case md.ExportedType:
+		tag = 17
+	case md.ManifestResource:
+		tag = 18
+	case md.GenericParam:
+		tag = 19
+	case md.GenericParamConstraint:
+		tag = 20
+	case md.MethodSpec:
+		tag = 21
+	case md.Document:
+		tag = 22
+	case md.LocalScope:
+		tag = 23
+	case md.LocalVariable:
+		tag = 24
+	case md.LocalConstant:
+		tag = 25
+	case md.ImportScope:
+		tag = 26
+	default:
+		panic(fmt.Sprintf("unexpected table type %v", tt))
+	}
+	val := ((idx + 1) << 5) | tag
+	*t = HasCustomDebugInformation(val)
+}
+
+// Table returns associated TableType using tag.
+func (t HasCustomDebugInformation) Table() (md.TableType, bool) {
+	switch t.Tag() {
+	case 0:
+		return md.MethodDef, true
+	case 1:
+		return md.Field, true
+	case 2:
+		return md.TypeRef, true
+	case 3:
+		return md.TypeDef, true
+	case 4:
+		return md.Param, true
+	case 5:
+		return md.InterfaceImpl, true
+	case 6:
+		return md.MemberRef, true
+	case 7:
+		return md.Module, true
+	// Skip 8 "Permission", there is not such table
+	case 9:
+		return md.Property, true
+	case 10:
+		return md.Event, true
+	case 11:
+		return md.StandAloneSig, true
+	case 12:
+		return md.ModuleRef, true
+	case 13:
+		return md.TypeSpec, true
+	case 14:
+		return md.Assembly, true
+	case 15:
+		return md.AssemblyRef, true
+	case 16:
+		return md.File, true
+	case 17:
+		return md.ExportedType, true
+	case 18:
+		return md.ManifestResource, true
+	case 19:
+		return md.GenericParam, true
+	case 20:
+		return md.GenericParamConstraint, true
+	case 21:
+		return md.MethodSpec, true
+	case 22:
+		return md.Document, true
+	case 23:
+		return md.LocalScope, true
+	case 24:
+		return md.LocalVariable, true
+	case 25:
+		return md.LocalConstant, true
+	case 26:
+		return md.ImportScope, true
+	default:
+		return 0, false
+	}
+}
+
+// Row creates new Row using given Context and this index.
+func (t HasCustomDebugInformation) Row(c *Context) (Row, bool) {
+	table, ok := t.Table()
+	if !ok {
+		var zero Row
+		return zero, false
+	}
+
+	return c.Table(table).Row(t.TableIndex()), true
+}
+
+// TableIndex returns HasCustomDebugInformation index.
+func (t HasCustomDebugInformation) TableIndex() uint32 {
+	return uint32((t >> 5) - 1)
+}
+
+// IsNull denotes that HasCustomDebugInformation is a null reference.
+func (t HasCustomDebugInformation) IsNull() bool {
+	return t>>5 == 0
+}
+
+// String implements fmt.Stringer method.
+func (t HasCustomDebugInformation) String() string {
+	switch t.Tag() {
+	case 0:
+		return fmt.Sprintf("MethodDef(%d)", t.TableIndex())
+	case 1:
+		return fmt.Sprintf("Field(%d)", t.TableIndex())
+	case 2:
+		return fmt.Sprintf("TypeRef(%d)", t.TableIndex())
+	case 3:
+		return fmt.Sprintf("TypeDef(%d)", t.TableIndex())
+	case 4:
+		return fmt.Sprintf("Param(%d)", t.TableIndex())
+	case 5:
+		return fmt.Sprintf("InterfaceImpl(%d)", t.TableIndex())
+	case 6:
+		return fmt.Sprintf("MemberRef(%d)", t.TableIndex())
+	case 7:
+		return fmt.Sprintf("Module(%d)", t.TableIndex())
+	case 8:
+		return fmt.Sprintf("Permission(%d)", t.TableIndex())
+	case 9:
+		return fmt.Sprintf("Property(%d)", t.TableIndex())
+	case 10:
+		return fmt.Sprintf("Event(%d)", t.TableIndex())
+	case 11:
+		return fmt.Sprintf("StandAloneSig(%d)", t.TableIndex())
+	case 12:
+		return fmt.Sprintf("ModuleRef(%d)", t.TableIndex())
+	case 13:
+		return fmt.Sprintf("TypeSpec(%d)", t.TableIndex())
+	case 14:
+		return fmt.Sprintf("Assembly(%d)", t.TableIndex())
+	case 15:
+		return fmt.Sprintf("AssemblyRef(%d)", t.TableIndex())
+	case 16:
+		return fmt.Sprintf("File(%d)", t.TableIndex())
+	case 17:
+		return fmt.Sprintf("ExportedType(%d)", t.TableIndex())
+	case 18:
+		return fmt.Sprintf("ManifestResource(%d)", t.TableIndex())
+	case 19:
+		return fmt.Sprintf("GenericParam(%d)", t.TableIndex())
+	case 20:
+		return fmt.Sprintf("GenericParamConstraint(%d)", t.TableIndex())
+	case 21:
+		return fmt.Sprintf("MethodSpec(%d)", t.TableIndex())
+	case 22:
+		return fmt.Sprintf("Document(%d)", t.TableIndex())
+	case 23:
+		return fmt.Sprintf("LocalScope(%d)", t.TableIndex())
+	case 24:
+		return fmt.Sprintf("LocalVariable(%d)", t.TableIndex())
+	case 25:
+		return fmt.Sprintf("LocalConstant(%d)", t.TableIndex())
+	case 26:
+		return fmt.Sprintf("ImportScope(%d)", t.TableIndex())
+	default:
+		return "unknown"
+	}
+}
+
 // HasFieldMarshall represents composite index one of
 //
 //	Field
@@ -487,6 +784,11 @@ func (t HasFieldMarshall) TableIndex() uint32 {
 	return uint32((t >> 1) - 1)
 }
 
+// IsNull denotes that HasFieldMarshall is a null reference.
+func (t HasFieldMarshall) IsNull() bool {
+	return t>>1 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t HasFieldMarshall) String() string {
 	switch t.Tag() {
@@ -572,6 +874,11 @@ func (t HasDeclSecurity) TableIndex() uint32 {
 	return uint32((t >> 2) - 1)
 }
 
+// IsNull denotes that HasDeclSecurity is a null reference.
+func (t HasDeclSecurity) IsNull() bool {
+	return t>>2 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t HasDeclSecurity) String() string {
 	switch t.Tag() {
@@ -671,6 +978,11 @@ func (t MemberRefParent) TableIndex() uint32 {
 	return uint32((t >> 3) - 1)
 }
 
+// IsNull denotes that MemberRefParent is a null reference.
+func (t MemberRefParent) IsNull() bool {
+	return t>>3 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t MemberRefParent) String() string {
 	switch t.Tag() {
@@ -756,6 +1068,11 @@ func (t HasSemantics) TableIndex() uint32 {
 	return uint32((t >> 1) - 1)
 }
 
+// IsNull denotes that HasSemantics is a null reference.
+func (t HasSemantics) IsNull() bool {
+	return t>>1 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t HasSemantics) String() string {
 	switch t.Tag() {
@@ -835,6 +1152,11 @@ func (t MethodDefOrRef) TableIndex() uint32 {
 	return uint32((t >> 1) - 1)
 }
 
+// IsNull denotes that MethodDefOrRef is a null reference.
+func (t MethodDefOrRef) IsNull() bool {
+	return t>>1 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t MethodDefOrRef) String() string {
 	switch t.Tag() {
@@ -914,6 +1236,11 @@ func (t MemberForwarded) TableIndex() uint32 {
 	return uint32((t >> 1) - 1)
 }
 
+// IsNull denotes that MemberForwarded is a null reference.
+func (t MemberForwarded) IsNull() bool {
+	return t>>1 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t MemberForwarded) String() string {
 	switch t.Tag() {
@@ -999,6 +1326,11 @@ func (t Implementation) TableIndex() uint32 {
 	return uint32((t >> 2) - 1)
 }
 
+// IsNull denotes that Implementation is a null reference.
+func (t Implementation) IsNull() bool {
+	return t>>2 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t Implementation) String() string {
 	switch t.Tag() {
@@ -1092,6 +1424,11 @@ func (t CustomAttributeType) TableIndex() uint32 {
 	return uint32((t >> 3) - 1)
 }
 
+// IsNull denotes that CustomAttributeType is a null reference.
+func (t CustomAttributeType) IsNull() bool {
+	return t>>3 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t CustomAttributeType) String() string {
 	switch t.Tag() {
@@ -1189,6 +1526,11 @@ func (t ResolutionScope) TableIndex() uint32 {
 	return uint32((t >> 2) - 1)
 }
 
+// IsNull denotes that ResolutionScope is a null reference.
+func (t ResolutionScope) IsNull() bool {
+	return t>>2 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t ResolutionScope) String() string {
 	switch t.Tag() {
@@ -1272,6 +1614,11 @@ func (t TypeOrMethodDef) TableIndex() uint32 {
 	return uint32((t >> 1) - 1)
 }
 
+// IsNull denotes that TypeOrMethodDef is a null reference.
+func (t TypeOrMethodDef) IsNull() bool {
+	return t>>1 == 0
+}
+
 // String implements fmt.Stringer method.
 func (t TypeOrMethodDef) String() string {
 	switch t.Tag() {
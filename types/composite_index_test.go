@@ -15,6 +15,11 @@ func TestCreateIndex(t *testing.T) {
 	a.True(ok)
 	a.Equal(md.Param, tt)
 	a.Equal(uint32(10), idx.TableIndex())
+	a.False(idx.IsNull())
+	a.True(HasConstant(0).IsNull())
+	// Null index may have non-zero tag.
+	a.True(HasConstant(2).IsNull())
+	a.False(CreateHasConstant(md.Field, 0).IsNull())
 	a.Panics(func() {
 		CreateHasConstant(md.CustomAttribute, 10)
 	})
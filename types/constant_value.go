@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// Decode decodes constant value blob.
+//
+// Returned value is one of
+//
+//	bool, rune (char, UTF-16 code unit), int8, uint8, int16, uint16, int32, uint32, int64, uint64, float32, float64
+//	string (decoded from UTF-16)
+//
+// For null class constant, value is nil.
+func (f *Constant) Decode() (interface{}, error) {
+	switch f.Type {
+	case ELEMENT_TYPE_STRING:
+		if len(f.Value)%2 != 0 {
+			return nil, fmt.Errorf("invalid UTF-16 string constant length %d", len(f.Value))
+		}
+
+		r := attributeReader{buf: f.Value}
+		s := make([]uint16, 0, len(f.Value)/2)
+		for r.len() > 0 {
+			v, err := r.uint16()
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return string(utf16.Decode(s)), nil
+	case ELEMENT_TYPE_CLASS:
+		// II.22.9 Constant: for ELEMENT_TYPE_CLASS, Value must be 4-byte zero.
+		if len(f.Value) != 4 || f.Value[0]|f.Value[1]|f.Value[2]|f.Value[3] != 0 {
+			return nil, fmt.Errorf("invalid null class constant %x", f.Value)
+		}
+		return nil, nil
+	}
+
+	r := attributeReader{buf: f.Value}
+	v, err := r.primitive(f.Type)
+	if err != nil {
+		return nil, err
+	}
+	if r.len() != 0 {
+		return nil, fmt.Errorf("invalid %v constant length %d", f.Type, len(f.Value))
+	}
+	return v, nil
+}
+
+// ConstantValue finds and decodes constant value of given parent.
+// If parent has no constant, ok is false.
+func (t *Context) ConstantValue(parent HasConstant) (v interface{}, ok bool, _ error) {
+	c, ok, err := t.Constant(parent)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	v, err = c.Decode()
+	if err != nil {
+		return nil, false, fmt.Errorf("decode %v constant: %w", parent, err)
+	}
+	return v, true, nil
+}
+
+// FieldConstant finds and decodes constant value of given Field.
+// If Field has no constant, ok is false.
+func (t *Context) FieldConstant(field Index) (v interface{}, ok bool, _ error) {
+	return t.ConstantValue(CreateHasConstant(md.Field, field))
+}
+
+// PropertyConstant finds and decodes constant value of given Property.
+// If Property has no constant, ok is false.
+func (t *Context) PropertyConstant(property Index) (v interface{}, ok bool, _ error) {
+	return t.ConstantValue(CreateHasConstant(md.Property, property))
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstant_Decode(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      Constant
+		expect interface{}
+	}{
+		{"Bool", Constant{Type: ELEMENT_TYPE_BOOLEAN, Value: Blob{1}}, true},
+		{"Char", Constant{Type: ELEMENT_TYPE_CHAR, Value: Blob{'A', 0}}, rune('A')},
+		{"I1", Constant{Type: ELEMENT_TYPE_I1, Value: Blob{0xFF}}, int8(-1)},
+		{"U1", Constant{Type: ELEMENT_TYPE_U1, Value: Blob{0xFF}}, uint8(0xFF)},
+		{"I2", Constant{Type: ELEMENT_TYPE_I2, Value: Blob{0xFE, 0xFF}}, int16(-2)},
+		{"U2", Constant{Type: ELEMENT_TYPE_U2, Value: Blob{0x34, 0x12}}, uint16(0x1234)},
+		// ENABLE_VIRTUAL_TERMINAL_INPUT
+		{"I4", Constant{Type: ELEMENT_TYPE_I4, Value: Blob{0x00, 0x02, 0x00, 0x00}}, int32(0x200)},
+		// STATUS_ACCESS_VIOLATION
+		{"U4", Constant{Type: ELEMENT_TYPE_U4, Value: Blob{0x05, 0x00, 0x00, 0xC0}}, uint32(0xC0000005)},
+		{"I8", Constant{Type: ELEMENT_TYPE_I8, Value: Blob{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}}, int64(-1)},
+		{"U8", Constant{Type: ELEMENT_TYPE_U8, Value: Blob{1, 0, 0, 0, 0, 0, 0, 0x80}}, uint64(0x8000000000000001)},
+		{"R4", Constant{Type: ELEMENT_TYPE_R4, Value: Blob{0x00, 0x00, 0xC0, 0x3F}}, float32(1.5)},
+		{"R8", Constant{Type: ELEMENT_TYPE_R8, Value: Blob{0, 0, 0, 0, 0, 0, 0xF8, 0x3F}}, float64(1.5)},
+		{"String", Constant{Type: ELEMENT_TYPE_STRING, Value: Blob{'S', 0, 'E', 0, 0x3D, 0xD8, 0x00, 0xDE}}, "SE\U0001F600"},
+		{"EmptyString", Constant{Type: ELEMENT_TYPE_STRING, Value: Blob{}}, ""},
+		{"NullClass", Constant{Type: ELEMENT_TYPE_CLASS, Value: Blob{0, 0, 0, 0}}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := require.New(t)
+
+			v, err := test.c.Decode()
+			a.NoError(err)
+			a.Equal(test.expect, v)
+		})
+	}
+
+	for _, c := range []Constant{
+		{Type: ELEMENT_TYPE_I4, Value: Blob{1, 2}},
+		{Type: ELEMENT_TYPE_I2, Value: Blob{1, 2, 3}},
+		{Type: ELEMENT_TYPE_STRING, Value: Blob{'a'}},
+		{Type: ELEMENT_TYPE_CLASS, Value: Blob{1, 0, 0, 0}},
+		{Type: ELEMENT_TYPE_VALUETYPE, Value: Blob{1}},
+	} {
+		_, err := c.Decode()
+		require.Error(t, err, "%v %x", c.Type, c.Value)
+	}
+}
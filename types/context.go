@@ -4,15 +4,41 @@ import (
 	"debug/pe"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/tdakkota/win32metadata/md"
 )
 
 // Context is a simple helper for accessing file heaps and tables.
+//
+// Context is safe for concurrent use by multiple goroutines.
 type Context struct {
 	Metadata *md.Metadata
-	section  *io.SectionReader
+	// Tables stream reader, md.Bytes if metadata is in memory.
+	section io.ReaderAt
 	md.TablesHeader
+	// Underlying file, if Context was created by Open.
+	closer io.Closer
+
+	// Lazily built type name index.
+	indexOnce sync.Once
+	index     typeIndex
+	indexErr  error
+
+	// Lazily built enclosing TypeDef to nested TypeDefs index.
+	nestedOnce sync.Once
+	nested     map[Index][]Index
+	nestedErr  error
+
+	// Lazily built MethodDef to MethodSemantics index.
+	semanticsOnce sync.Once
+	semantics     map[Index][]MethodSemantics
+	semanticsErr  error
+
+	// Lazily built target table row to Ptr table row index.
+	positionsOnce sync.Once
+	positions     map[md.TableType]map[Index]Index
+	positionsErr  error
 }
 
 // FromPE creates new Context from PE file.
@@ -22,16 +48,35 @@ func FromPE(f *pe.File) (*Context, error) {
 		return nil, err
 	}
 
+	return FromMetadata(metadata)
+}
+
+// FromMetadata creates new Context from parsed Metadata.
+//
+// Can be used to access portable PDB tables, see md.ParseMetadataBytes.
+func FromMetadata(metadata *md.Metadata) (*Context, error) {
 	tables, section, err := metadata.Tables()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Context{
+	c := &Context{
 		Metadata:     metadata,
 		section:      section,
 		TablesHeader: tables,
-	}, nil
+	}
+	if data, ok := md.BytesOf(section); ok {
+		c.section = data
+	}
+	return c, nil
+}
+
+// Close closes underlying file, if Context was created by Open.
+func (t *Context) Close() error {
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer.Close()
 }
 
 // RowCount returns row count of given table type.
@@ -69,6 +114,21 @@ func (t *Context) Blob(tt md.TableType, row, column uint32) (Blob, error) {
 	return t.Metadata.ReadBlob(idx)
 }
 
+// GUID finds GUID value from #GUID heap using given index column.
+func (t *Context) GUID(tt md.TableType, row, column uint32) (md.GUID, error) {
+	idx, err := t.Uint64(tt, row, column)
+	if err != nil {
+		return md.GUID{}, err
+	}
+
+	return t.Metadata.ReadGUID(idx)
+}
+
+// UserString finds string value from #US heap using given index.
+func (t *Context) UserString(idx uint32) (string, error) {
+	return t.Metadata.ReadUserString(uint64(idx))
+}
+
 // Signature finds signature blob value from #Blob heap using given index column.
 func (t *Context) Signature(tt md.TableType, row, column uint32) (Signature, error) {
 	sig, err := t.Blob(tt, row, column)
@@ -80,6 +140,9 @@ func (t *Context) Signature(tt md.TableType, row, column uint32) (Signature, err
 }
 
 // List returns range of indexes using given index.
+//
+// If target table has a Ptr table (e.g. FieldPtr for Field), List is a range of Ptr table
+// indexes, use ListIndex to get target row index.
 func (t *Context) List(tt md.TableType, row, column uint32, target md.TableType) (List, error) {
 	f, err := t.Uint32(tt, row, column)
 	if err != nil {
@@ -87,7 +150,11 @@ func (t *Context) List(tt md.TableType, row, column uint32, target md.TableType)
 	}
 	first := f - 1
 
-	last := t.Tables[target].RowCount
+	count := t.Tables[target].RowCount
+	if ptr, ok := t.ptrTable(target); ok {
+		count = t.Tables[ptr].RowCount
+	}
+	last := count
 	if row+1 < t.Tables[tt].RowCount {
 		l, err := t.Uint32(tt, row+1, column)
 		if err != nil {
@@ -96,9 +163,128 @@ func (t *Context) List(tt md.TableType, row, column uint32, target md.TableType)
 		last = l - 1
 	}
 
+	if f == 0 || first > last || last > count {
+		return List{}, fmt.Errorf("%v(%d) list [%d:%d] is out of %v bounds (%d): %w",
+			tt, row, f, last+1, target, count, md.ErrOutOfBounds)
+	}
+
 	return List{first, last}, nil
 }
 
+// ptrTable returns Ptr table of given table, if it is present.
+func (t *Context) ptrTable(target md.TableType) (md.TableType, bool) {
+	var ptr md.TableType
+	switch target {
+	case md.Field:
+		ptr = md.FieldPtr
+	case md.MethodDef:
+		ptr = md.MethodPtr
+	case md.Param:
+		ptr = md.ParamPtr
+	case md.Event:
+		ptr = md.EventPtr
+	case md.Property:
+		ptr = md.PropertyPtr
+	default:
+		return 0, false
+	}
+	return ptr, t.Tables[ptr].RowCount > 0
+}
+
+// ListIndex maps List element to row index of target table.
+//
+// If target table has no Ptr table, idx is returned as is.
+func (t *Context) ListIndex(target md.TableType, idx Index) (Index, error) {
+	ptr, ok := t.ptrTable(target)
+	if !ok {
+		return idx, nil
+	}
+
+	v, err := t.Uint32(ptr, idx, 0)
+	if err != nil {
+		return 0, err
+	}
+	return v - 1, nil
+}
+
+func (t *Context) buildPositionIndex() (map[md.TableType]map[Index]Index, error) {
+	result := map[md.TableType]map[Index]Index{}
+	for _, target := range []md.TableType{md.Field, md.MethodDef, md.Param, md.Event, md.Property} {
+		ptr, ok := t.ptrTable(target)
+		if !ok {
+			continue
+		}
+
+		positions := make(map[Index]Index, t.Tables[ptr].RowCount)
+		for i := uint32(0); i < t.Tables[ptr].RowCount; i++ {
+			v, err := t.Uint32(ptr, i, 0)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := positions[v-1]; !ok {
+				positions[v-1] = i
+			}
+		}
+		result[target] = positions
+	}
+	return result, nil
+}
+
+// listPosition maps row index of target table to List element, reverse to ListIndex.
+//
+// Reverse index of Ptr tables is built on first call.
+func (t *Context) listPosition(target md.TableType, row Index) (Index, error) {
+	if _, ok := t.ptrTable(target); !ok {
+		return row, nil
+	}
+
+	t.positionsOnce.Do(func() {
+		t.positions, t.positionsErr = t.buildPositionIndex()
+	})
+	if t.positionsErr != nil {
+		return 0, t.positionsErr
+	}
+
+	pos, ok := t.positions[target][row]
+	if !ok {
+		ptr, _ := t.ptrTable(target)
+		return 0, fmt.Errorf("%v(%d) is not referenced by %v", target, row, ptr)
+	}
+	return pos, nil
+}
+
+// methodOwner finds TypeDef index which owns given MethodDef.
+func (t *Context) methodOwner(method Index) (Index, error) {
+	typeDefs := t.Table(md.TypeDef)
+
+	pos, err := t.listPosition(md.MethodDef, method)
+	if err != nil {
+		return 0, err
+	}
+
+	// MethodList is monotonic, so use binary search.
+	lo, hi := uint32(0), typeDefs.RowCount()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		list, err := t.List(md.TypeDef, mid, 5, md.MethodDef)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case pos < list.Start():
+			hi = mid
+		case pos >= list.End():
+			lo = mid + 1
+		default:
+			return mid, nil
+		}
+	}
+
+	return 0, fmt.Errorf("owner of MethodDef(%d) not found", method)
+}
+
 // Table creates new Table associated with this Context.
 func (t *Context) Table(tt md.TableType) Table {
 	return Table{
@@ -154,6 +340,11 @@ func (t *Row) Blob(column uint32) (Blob, error) {
 	return t.Table.ctx.Blob(t.Table.Type, t.Row, column)
 }
 
+// GUID finds GUID value from #GUID heap using given index column.
+func (t *Row) GUID(column uint32) (md.GUID, error) {
+	return t.Table.ctx.GUID(t.Table.Type, t.Row, column)
+}
+
 // Signature finds signature blob value from #Blob heap using given index column.
 func (t *Row) Signature(column uint32) (Signature, error) {
 	return t.Table.ctx.Signature(t.Table.Type, t.Row, column)
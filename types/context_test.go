@@ -0,0 +1,113 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// readAllTables reads every column of every table, including heap values.
+func readAllTables(c *Context) ([]interface{}, error) {
+	var values []interface{}
+	for tt := md.Module; tt <= md.CustomDebugInformation; tt++ {
+		kinds := heapColumns[tt]
+		for row := uint32(0); row < c.RowCount(tt); row++ {
+			for column, kind := range kinds {
+				if c.Tables[tt].Columns[column].Zero() {
+					break
+				}
+
+				var (
+					v   interface{}
+					err error
+				)
+				switch kind {
+				case stringHeap:
+					v, err = c.String(tt, row, uint32(column))
+				case blobHeap:
+					v, err = c.Blob(tt, row, uint32(column))
+				case guidHeap:
+					v, err = c.GUID(tt, row, uint32(column))
+				default:
+					v, err = c.Uint64(tt, row, uint32(column))
+				}
+				if err != nil {
+					return nil, fmt.Errorf("%v(%d).%d: %w", tt, row, column, err)
+				}
+				values = append(values, v)
+			}
+		}
+	}
+
+	ns, err := c.Namespaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range ns {
+		defs, err := c.NamespaceTypeDefs(namespace)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, namespace, defs)
+	}
+	return values, nil
+}
+
+func TestContext_Concurrent(t *testing.T) {
+	data := writePE(require.New(t), testWriter())
+
+	backends := []struct {
+		name string
+		open func() (*Context, error)
+	}{
+		{"Reader", func() (*Context, error) {
+			return fromReaderAt(bytes.NewReader(data), int64(len(data)))
+		}},
+		{"Bytes", func() (*Context, error) {
+			return FromBytes(data)
+		}},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			a := require.New(t)
+
+			expect, err := readAllTables(readPE(a, data))
+			a.NoError(err)
+
+			c, err := backend.open()
+			a.NoError(err)
+
+			var (
+				wg   sync.WaitGroup
+				errs = make(chan error, 16)
+			)
+			for g := 0; g < cap(errs); g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					values, err := readAllTables(c)
+					if err != nil {
+						errs <- err
+						return
+					}
+					if !reflect.DeepEqual(expect, values) {
+						errs <- fmt.Errorf("values mismatch")
+					}
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				a.NoError(err)
+			}
+		})
+	}
+}
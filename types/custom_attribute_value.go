@@ -0,0 +1,586 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// CustomAttributeArgType describes type of custom attribute argument.
+//
+// Kind is one of primitive types, ELEMENT_TYPE_STRING, ELEMENT_TYPE_SZARRAY,
+// ELEMENT_TYPE_OBJECT (boxed value), ELEMENT_TYPE_CLASS (System.Type) or
+// ELEMENT_TYPE_VALUETYPE (enum).
+type CustomAttributeArgType struct {
+	Kind ElementTypeKind
+	// Namespace and Name of enum or System.Type.
+	Namespace string
+	Name      string
+	// Underlying is an underlying type of enum.
+	Underlying ElementTypeKind
+	// Elem is an element type of SZARRAY.
+	Elem *CustomAttributeArgType
+}
+
+// CustomAttributeArg is a II.23.3 FixedArg representation.
+//
+// Value is one of
+//
+//	bool, rune (char, UTF-16 code unit), int8, uint8, int16, uint16, int32, uint32, int64, uint64, float32, float64
+//	string (for strings and System.Type)
+//	[]CustomAttributeArg (for SZARRAY)
+//	CustomAttributeArg (for boxed values)
+//
+// Value is nil for null strings and arrays.
+// Enum values are stored as values of underlying type.
+type CustomAttributeArg struct {
+	Type  CustomAttributeArgType
+	Value interface{}
+}
+
+// CustomAttributeNamedArg is a II.23.3 NamedArg representation.
+type CustomAttributeNamedArg struct {
+	// Property denotes that argument sets property, otherwise it sets field.
+	Property bool
+	Name     string
+	CustomAttributeArg
+}
+
+// CustomAttributeValue is a decoded II.23.3 CustomAttrib blob.
+type CustomAttributeValue struct {
+	Fixed []CustomAttributeArg
+	Named []CustomAttributeNamedArg
+}
+
+// CustomAttributeDecoder decodes custom attribute values.
+type CustomAttributeDecoder struct {
+	// ResolveEnum resolves underlying type of enum which is not defined in decoding Context,
+	// e.g. enum defined in other winmd file.
+	//
+	// If nil, only enums defined in Context are supported. See Universe.ResolveEnum.
+	ResolveEnum func(namespace, name string) (ElementTypeKind, error)
+}
+
+func (d CustomAttributeDecoder) enumUnderlyingType(c *Context, namespace, name string) (ElementTypeKind, error) {
+	if c != nil {
+		kind, ok, err := c.enumUnderlyingType(namespace, name)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return kind, nil
+		}
+	}
+
+	if d.ResolveEnum != nil {
+		return d.ResolveEnum(namespace, name)
+	}
+	return 0, fmt.Errorf("can't resolve underlying type of enum %s.%s", namespace, name)
+}
+
+// enumUnderlyingType finds enum TypeDef by name and returns type of its value__ field.
+func (t *Context) enumUnderlyingType(namespace, name string) (ElementTypeKind, bool, error) {
+	idx, ok, err := t.FindTypeDef(namespace, name)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+
+	var def TypeDef
+	if err := def.FromRow(t.Table(md.TypeDef).Row(idx)); err != nil {
+		return 0, false, err
+	}
+
+	fields, err := def.ResolveFieldList(t)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, field := range fields {
+		if field.Flags.Static() {
+			continue
+		}
+
+		sig, err := field.Signature.Reader().Field(t)
+		if err != nil {
+			return 0, false, err
+		}
+		return sig.Field.Type.Kind, true, nil
+	}
+	return 0, false, fmt.Errorf("enum %s.%s has no value field", namespace, name)
+}
+
+// resolveTypeName returns namespace and name of TypeDef or TypeRef row.
+func resolveTypeName(row Row) (namespace, name string, err error) {
+	switch row.Table.Type {
+	case md.TypeDef, md.TypeRef:
+	default:
+		return "", "", fmt.Errorf("unexpected table %v", row.Table.Type)
+	}
+
+	name, err = row.String(1)
+	if err != nil {
+		return "", "", err
+	}
+
+	namespace, err = row.String(2)
+	if err != nil {
+		return "", "", err
+	}
+
+	return namespace, name, nil
+}
+
+// ResolveConstructor resolves attribute constructor signature and attribute type name.
+func (f *CustomAttribute) ResolveConstructor(c *Context) (namespace, name string, sig MethodSignature, _ error) {
+	row, ok := f.Type.Row(c)
+	if !ok {
+		return "", "", sig, fmt.Errorf("unexpected tag %v", f.Type)
+	}
+
+	var (
+		typeRow   Row
+		signature Signature
+	)
+	switch row.Table.Type {
+	case md.MethodDef:
+		var def MethodDef
+		if err := def.FromRow(row); err != nil {
+			return "", "", sig, err
+		}
+		signature = def.Signature
+
+		owner, err := c.methodOwner(row.Row)
+		if err != nil {
+			return "", "", sig, err
+		}
+		typeRow = c.Table(md.TypeDef).Row(owner)
+	case md.MemberRef:
+		var ref MemberRef
+		if err := ref.FromRow(row); err != nil {
+			return "", "", sig, err
+		}
+		signature = ref.Signature
+
+		typeRow, ok = ref.Class.Row(c)
+		if !ok {
+			return "", "", sig, fmt.Errorf("unexpected tag %v", ref.Class)
+		}
+	default:
+		return "", "", sig, fmt.Errorf("unexpected constructor table %v", row.Table.Type)
+	}
+
+	namespace, name, err := resolveTypeName(typeRow)
+	if err != nil {
+		return "", "", sig, err
+	}
+
+	sig, err = signature.Reader().Method(c)
+	if err != nil {
+		return "", "", sig, fmt.Errorf("decode constructor signature: %w", err)
+	}
+
+	return namespace, name, sig, nil
+}
+
+// Decode decodes attribute value using constructor signature.
+func (f *CustomAttribute) Decode(c *Context) (CustomAttributeValue, error) {
+	return CustomAttributeDecoder{}.Decode(c, f)
+}
+
+// Decode decodes attribute value using constructor signature.
+func (d CustomAttributeDecoder) Decode(c *Context, f *CustomAttribute) (CustomAttributeValue, error) {
+	_, _, sig, err := f.ResolveConstructor(c)
+	if err != nil {
+		return CustomAttributeValue{}, err
+	}
+
+	params := make([]CustomAttributeArgType, 0, len(sig.Params))
+	for i, param := range sig.Params {
+		typ, err := d.argType(c, param.Type)
+		if err != nil {
+			return CustomAttributeValue{}, fmt.Errorf("param %d: %w", i, err)
+		}
+		params = append(params, typ)
+	}
+
+	return d.DecodeBlob(c, params, f.Value)
+}
+
+func (d CustomAttributeDecoder) argType(c *Context, e ElementType) (CustomAttributeArgType, error) {
+	switch e.Kind {
+	case ELEMENT_TYPE_BOOLEAN, ELEMENT_TYPE_CHAR,
+		ELEMENT_TYPE_I1, ELEMENT_TYPE_U1,
+		ELEMENT_TYPE_I2, ELEMENT_TYPE_U2,
+		ELEMENT_TYPE_I4, ELEMENT_TYPE_U4,
+		ELEMENT_TYPE_I8, ELEMENT_TYPE_U8,
+		ELEMENT_TYPE_R4, ELEMENT_TYPE_R8,
+		ELEMENT_TYPE_STRING, ELEMENT_TYPE_OBJECT:
+		return CustomAttributeArgType{Kind: e.Kind}, nil
+	case ELEMENT_TYPE_SZARRAY:
+		elem, err := d.argType(c, e.SZArray.Elem.Type)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		return CustomAttributeArgType{Kind: e.Kind, Elem: &elem}, nil
+	case ELEMENT_TYPE_CLASS:
+		namespace, name, err := c.ResolveTypeDefOrRefName(e.TypeDef.Index)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		if namespace != "System" || name != "Type" {
+			return CustomAttributeArgType{}, fmt.Errorf("unexpected class %s.%s", namespace, name)
+		}
+		return CustomAttributeArgType{Kind: e.Kind, Namespace: namespace, Name: name}, nil
+	case ELEMENT_TYPE_VALUETYPE:
+		namespace, name, err := c.ResolveTypeDefOrRefName(e.TypeDef.Index)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		underlying, err := d.enumUnderlyingType(c, namespace, name)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		return CustomAttributeArgType{
+			Kind:       e.Kind,
+			Namespace:  namespace,
+			Name:       name,
+			Underlying: underlying,
+		}, nil
+	default:
+		return CustomAttributeArgType{}, fmt.Errorf("unexpected custom attribute argument type %v", e.Kind)
+	}
+}
+
+// DecodeBlob decodes II.23.3 CustomAttrib blob using given constructor parameter types.
+//
+// Context is used only to resolve enums of named arguments and may be nil.
+func (d CustomAttributeDecoder) DecodeBlob(c *Context, params []CustomAttributeArgType, blob Blob) (CustomAttributeValue, error) {
+	r := attributeReader{buf: blob}
+
+	prolog, err := r.uint16()
+	if err != nil {
+		return CustomAttributeValue{}, err
+	}
+	if prolog != 0x0001 {
+		return CustomAttributeValue{}, fmt.Errorf("invalid custom attribute prolog %#x", prolog)
+	}
+
+	var v CustomAttributeValue
+	for i, param := range params {
+		arg, err := d.fixedArg(c, &r, param)
+		if err != nil {
+			return CustomAttributeValue{}, fmt.Errorf("fixed arg %d: %w", i, err)
+		}
+		v.Fixed = append(v.Fixed, arg)
+	}
+
+	numNamed, err := r.uint16()
+	if err != nil {
+		return CustomAttributeValue{}, err
+	}
+	for i := 0; i < int(numNamed); i++ {
+		arg, err := d.namedArg(c, &r)
+		if err != nil {
+			return CustomAttributeValue{}, fmt.Errorf("named arg %d: %w", i, err)
+		}
+		v.Named = append(v.Named, arg)
+	}
+
+	return v, nil
+}
+
+func (d CustomAttributeDecoder) namedArg(c *Context, r *attributeReader) (CustomAttributeNamedArg, error) {
+	const (
+		FIELD    = 0x53
+		PROPERTY = 0x54
+	)
+
+	var arg CustomAttributeNamedArg
+	kind, err := r.uint8()
+	if err != nil {
+		return arg, err
+	}
+	switch kind {
+	case FIELD:
+	case PROPERTY:
+		arg.Property = true
+	default:
+		return arg, fmt.Errorf("unexpected named argument kind %#x", kind)
+	}
+
+	typ, err := d.fieldOrPropType(c, r)
+	if err != nil {
+		return arg, err
+	}
+
+	name, ok, err := r.serString()
+	if err != nil {
+		return arg, err
+	}
+	if !ok {
+		return arg, fmt.Errorf("null named argument name")
+	}
+	arg.Name = name
+
+	arg.CustomAttributeArg, err = d.fixedArg(c, r, typ)
+	if err != nil {
+		return arg, fmt.Errorf("%q: %w", name, err)
+	}
+
+	return arg, nil
+}
+
+// fieldOrPropType decodes II.23.3 FieldOrPropType.
+func (d CustomAttributeDecoder) fieldOrPropType(c *Context, r *attributeReader) (CustomAttributeArgType, error) {
+	const (
+		TYPE  = 0x50
+		BOXED = 0x51
+		ENUM  = 0x55
+	)
+
+	code, err := r.uint8()
+	if err != nil {
+		return CustomAttributeArgType{}, err
+	}
+
+	switch code {
+	case TYPE:
+		return CustomAttributeArgType{
+			Kind:      ELEMENT_TYPE_CLASS,
+			Namespace: "System",
+			Name:      "Type",
+		}, nil
+	case BOXED:
+		return CustomAttributeArgType{Kind: ELEMENT_TYPE_OBJECT}, nil
+	case ENUM:
+		typeName, ok, err := r.serString()
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		if !ok {
+			return CustomAttributeArgType{}, fmt.Errorf("null enum type name")
+		}
+		namespace, name := splitSerTypeName(typeName)
+
+		underlying, err := d.enumUnderlyingType(c, namespace, name)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		return CustomAttributeArgType{
+			Kind:       ELEMENT_TYPE_VALUETYPE,
+			Namespace:  namespace,
+			Name:       name,
+			Underlying: underlying,
+		}, nil
+	case uint8(ELEMENT_TYPE_SZARRAY):
+		elem, err := d.fieldOrPropType(c, r)
+		if err != nil {
+			return CustomAttributeArgType{}, err
+		}
+		return CustomAttributeArgType{Kind: ELEMENT_TYPE_SZARRAY, Elem: &elem}, nil
+	default:
+		var e ElementType
+		if !e.FromCode(uint32(code)) ||
+			e.Kind == ELEMENT_TYPE_VOID ||
+			e.Kind == ELEMENT_TYPE_TYPEDBYREF ||
+			e.Kind == ELEMENT_TYPE_I ||
+			e.Kind == ELEMENT_TYPE_U {
+			return CustomAttributeArgType{}, fmt.Errorf("unexpected FieldOrPropType %#x", code)
+		}
+		return CustomAttributeArgType{Kind: e.Kind}, nil
+	}
+}
+
+// splitSerTypeName splits serialized (possibly assembly-qualified) type name to namespace and name.
+func splitSerTypeName(typeName string) (namespace, name string) {
+	if idx := strings.IndexByte(typeName, ','); idx >= 0 {
+		typeName = typeName[:idx]
+	}
+	typeName = strings.TrimSpace(typeName)
+
+	idx := strings.LastIndexByte(typeName, '.')
+	if idx < 0 {
+		return "", typeName
+	}
+	return typeName[:idx], typeName[idx+1:]
+}
+
+func (d CustomAttributeDecoder) fixedArg(c *Context, r *attributeReader, typ CustomAttributeArgType) (CustomAttributeArg, error) {
+	arg := CustomAttributeArg{Type: typ}
+
+	switch typ.Kind {
+	case ELEMENT_TYPE_STRING, ELEMENT_TYPE_CLASS:
+		s, ok, err := r.serString()
+		if err != nil {
+			return arg, err
+		}
+		if ok {
+			arg.Value = s
+		}
+		return arg, nil
+	case ELEMENT_TYPE_OBJECT:
+		boxed, err := d.fieldOrPropType(c, r)
+		if err != nil {
+			return arg, err
+		}
+		v, err := d.fixedArg(c, r, boxed)
+		if err != nil {
+			return arg, err
+		}
+		arg.Value = v
+		return arg, nil
+	case ELEMENT_TYPE_SZARRAY:
+		n, err := r.uint32()
+		if err != nil {
+			return arg, err
+		}
+		if n == math.MaxUint32 {
+			return arg, nil
+		}
+		if uint64(n) > uint64(r.len()) {
+			return arg, io.ErrUnexpectedEOF
+		}
+
+		elems := make([]CustomAttributeArg, 0, n)
+		for i := uint32(0); i < n; i++ {
+			elem, err := d.fixedArg(c, r, *typ.Elem)
+			if err != nil {
+				return arg, err
+			}
+			elems = append(elems, elem)
+		}
+		arg.Value = elems
+		return arg, nil
+	case ELEMENT_TYPE_VALUETYPE:
+		v, err := r.primitive(typ.Underlying)
+		if err != nil {
+			return arg, err
+		}
+		arg.Value = v
+		return arg, nil
+	default:
+		v, err := r.primitive(typ.Kind)
+		if err != nil {
+			return arg, err
+		}
+		arg.Value = v
+		return arg, nil
+	}
+}
+
+// attributeReader is a little-endian reader of custom attribute blob.
+type attributeReader struct {
+	buf []byte
+	off int
+}
+
+func (r *attributeReader) len() int {
+	return len(r.buf) - r.off
+}
+
+func (r *attributeReader) next(n int) ([]byte, error) {
+	if n < 0 || r.len() < n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := r.buf[r.off : r.off+n]
+	r.off += n
+	return b, nil
+}
+
+func (r *attributeReader) uint8() (uint8, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *attributeReader) uint16() (uint16, error) {
+	b, err := r.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *attributeReader) uint32() (uint32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *attributeReader) uint64() (uint64, error) {
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// serString reads II.23.3 SerString. If string is null, ok is false.
+func (r *attributeReader) serString() (s string, ok bool, _ error) {
+	if r.len() < 1 {
+		return "", false, io.ErrUnexpectedEOF
+	}
+	if r.buf[r.off] == 0xFF {
+		r.off++
+		return "", false, nil
+	}
+
+	sr := Signature(r.buf[r.off:]).Reader()
+	n, ok := sr.Read()
+	if !ok {
+		return "", false, io.ErrUnexpectedEOF
+	}
+	r.off += sr.offset
+
+	b, err := r.next(int(n))
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+func (r *attributeReader) primitive(kind ElementTypeKind) (interface{}, error) {
+	switch kind {
+	case ELEMENT_TYPE_BOOLEAN:
+		v, err := r.uint8()
+		return v != 0, err
+	case ELEMENT_TYPE_CHAR:
+		v, err := r.uint16()
+		return rune(v), err
+	case ELEMENT_TYPE_U2:
+		return r.uint16()
+	case ELEMENT_TYPE_I1:
+		v, err := r.uint8()
+		return int8(v), err
+	case ELEMENT_TYPE_U1:
+		return r.uint8()
+	case ELEMENT_TYPE_I2:
+		v, err := r.uint16()
+		return int16(v), err
+	case ELEMENT_TYPE_I4:
+		v, err := r.uint32()
+		return int32(v), err
+	case ELEMENT_TYPE_U4:
+		return r.uint32()
+	case ELEMENT_TYPE_I8:
+		v, err := r.uint64()
+		return int64(v), err
+	case ELEMENT_TYPE_U8:
+		return r.uint64()
+	case ELEMENT_TYPE_R4:
+		v, err := r.uint32()
+		return math.Float32frombits(v), err
+	case ELEMENT_TYPE_R8:
+		v, err := r.uint64()
+		return math.Float64frombits(v), err
+	default:
+		return nil, fmt.Errorf("unexpected primitive type %v", kind)
+	}
+}
@@ -0,0 +1,249 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomAttributeDecoder_DecodeBlob(t *testing.T) {
+	architecture := CustomAttributeArgType{
+		Kind:       ELEMENT_TYPE_VALUETYPE,
+		Namespace:  "Windows.Win32.Foundation.Metadata",
+		Name:       "Architecture",
+		Underlying: ELEMENT_TYPE_I4,
+	}
+	d := CustomAttributeDecoder{
+		ResolveEnum: func(namespace, name string) (ElementTypeKind, error) {
+			return ELEMENT_TYPE_U1, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		params []CustomAttributeArgType
+		blob   Blob
+		expect CustomAttributeValue
+	}{
+		{
+			// [Guid(0x00000000, 0x0000, 0x0000, 0xC0, 0, 0, 0, 0, 0, 0, 0x46)]
+			"GuidAttribute",
+			[]CustomAttributeArgType{
+				{Kind: ELEMENT_TYPE_U4},
+				{Kind: ELEMENT_TYPE_U2},
+				{Kind: ELEMENT_TYPE_U2},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+				{Kind: ELEMENT_TYPE_U1},
+			},
+			Blob{
+				0x01, 0x00, // Prolog
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00,
+				0x00, 0x00,
+				0xC0, 0, 0, 0, 0, 0, 0, 0x46,
+				0x00, 0x00, // NumNamed
+			},
+			CustomAttributeValue{
+				Fixed: []CustomAttributeArg{
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U4}, Value: uint32(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U2}, Value: uint16(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U2}, Value: uint16(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0xC0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0)},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U1}, Value: uint8(0x46)},
+				},
+			},
+		},
+		{
+			// [SupportedArchitecture(Architecture.X64 | Architecture.Arm64)]
+			"Enum",
+			[]CustomAttributeArgType{architecture},
+			Blob{
+				0x01, 0x00, // Prolog
+				0x06, 0x00, 0x00, 0x00,
+				0x00, 0x00, // NumNamed
+			},
+			CustomAttributeValue{
+				Fixed: []CustomAttributeArg{
+					{Type: architecture, Value: int32(6)},
+				},
+			},
+		},
+		{
+			// [NativeTypedef("HANDLE"), Obsolete(null)]
+			"Strings",
+			[]CustomAttributeArgType{
+				{Kind: ELEMENT_TYPE_STRING},
+				{Kind: ELEMENT_TYPE_STRING},
+			},
+			Blob{
+				0x01, 0x00, // Prolog
+				0x06, 'H', 'A', 'N', 'D', 'L', 'E',
+				0xFF,       // null
+				0x00, 0x00, // NumNamed
+			},
+			CustomAttributeValue{
+				Fixed: []CustomAttributeArg{
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_STRING}, Value: "HANDLE"},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_STRING}},
+				},
+			},
+		},
+		{
+			// [Attr('A', (ushort)'A')]
+			"CharU2",
+			[]CustomAttributeArgType{
+				{Kind: ELEMENT_TYPE_CHAR},
+				{Kind: ELEMENT_TYPE_U2},
+			},
+			Blob{
+				0x01, 0x00, // Prolog
+				'A', 0x00,
+				'A', 0x00,
+				0x00, 0x00, // NumNamed
+			},
+			CustomAttributeValue{
+				Fixed: []CustomAttributeArg{
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_CHAR}, Value: rune('A')},
+					{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_U2}, Value: uint16('A')},
+				},
+			},
+		},
+		{
+			// [Attr(typeof(Foo.Bar), new int[]{1, 2}, (object)(short)-1)]
+			"TypeArrayBoxed",
+			[]CustomAttributeArgType{
+				{Kind: ELEMENT_TYPE_CLASS, Namespace: "System", Name: "Type"},
+				{Kind: ELEMENT_TYPE_SZARRAY, Elem: &CustomAttributeArgType{Kind: ELEMENT_TYPE_I4}},
+				{Kind: ELEMENT_TYPE_OBJECT},
+			},
+			Blob{
+				0x01, 0x00, // Prolog
+				0x07, 'F', 'o', 'o', '.', 'B', 'a', 'r',
+				0x02, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00,
+				0x06, 0xFF, 0xFF, // boxed int16
+				0x00, 0x00, // NumNamed
+			},
+			CustomAttributeValue{
+				Fixed: []CustomAttributeArg{
+					{
+						Type:  CustomAttributeArgType{Kind: ELEMENT_TYPE_CLASS, Namespace: "System", Name: "Type"},
+						Value: "Foo.Bar",
+					},
+					{
+						Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_SZARRAY, Elem: &CustomAttributeArgType{Kind: ELEMENT_TYPE_I4}},
+						Value: []CustomAttributeArg{
+							{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_I4}, Value: int32(1)},
+							{Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_I4}, Value: int32(2)},
+						},
+					},
+					{
+						Type: CustomAttributeArgType{Kind: ELEMENT_TYPE_OBJECT},
+						Value: CustomAttributeArg{
+							Type:  CustomAttributeArgType{Kind: ELEMENT_TYPE_I2},
+							Value: int16(-1),
+						},
+					},
+				},
+			},
+		},
+		{
+			// [NativeArrayInfo(CountParamIndex = 2), AttributeUsage(..., AllowMultiple = true), Foo(Kind = Other.Kind.A)]
+			"NamedArgs",
+			nil,
+			Blob{
+				0x01, 0x00, // Prolog
+				0x03, 0x00, // NumNamed
+				0x53, 0x06, // FIELD int16
+				0x0f, 'C', 'o', 'u', 'n', 't', 'P', 'a', 'r', 'a', 'm', 'I', 'n', 'd', 'e', 'x',
+				0x02, 0x00,
+				0x54, 0x02, // PROPERTY bool
+				0x0d, 'A', 'l', 'l', 'o', 'w', 'M', 'u', 'l', 't', 'i', 'p', 'l', 'e',
+				0x01,
+				0x53, 0x55, // FIELD enum
+				0x16, 'O', 't', 'h', 'e', 'r', '.', 'K', 'i', 'n', 'd', ',', ' ', 'O', 't', 'h', 'e', 'r', ',', ' ', 'V', '=', '1',
+				0x04, 'K', 'i', 'n', 'd',
+				0x02,
+			},
+			CustomAttributeValue{
+				Named: []CustomAttributeNamedArg{
+					{
+						Name: "CountParamIndex",
+						CustomAttributeArg: CustomAttributeArg{
+							Type:  CustomAttributeArgType{Kind: ELEMENT_TYPE_I2},
+							Value: int16(2),
+						},
+					},
+					{
+						Property: true,
+						Name:     "AllowMultiple",
+						CustomAttributeArg: CustomAttributeArg{
+							Type:  CustomAttributeArgType{Kind: ELEMENT_TYPE_BOOLEAN},
+							Value: true,
+						},
+					},
+					{
+						Name: "Kind",
+						CustomAttributeArg: CustomAttributeArg{
+							Type: CustomAttributeArgType{
+								Kind:       ELEMENT_TYPE_VALUETYPE,
+								Namespace:  "Other",
+								Name:       "Kind",
+								Underlying: ELEMENT_TYPE_U1,
+							},
+							Value: uint8(2),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := require.New(t)
+
+			v, err := d.DecodeBlob(nil, test.params, test.blob)
+			a.NoError(err)
+			a.Equal(test.expect, v)
+		})
+	}
+
+	t.Run("Truncated", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := d.DecodeBlob(nil, []CustomAttributeArgType{
+			{Kind: ELEMENT_TYPE_U4},
+		}, Blob{0x01, 0x00, 0x01})
+		a.Error(err)
+
+		_, err = d.DecodeBlob(nil, nil, Blob{0x02, 0x00, 0x00, 0x00})
+		a.Error(err)
+	})
+	t.Run("UnresolvedEnum", func(t *testing.T) {
+		a := require.New(t)
+
+		// Enum is not defined in Context and there is no resolver.
+		_, err := CustomAttributeDecoder{}.DecodeBlob(nil, nil, Blob{
+			0x01, 0x00, // Prolog
+			0x01, 0x00, // NumNamed
+			0x53, 0x55, // FIELD enum
+			0x0a, 'O', 't', 'h', 'e', 'r', '.', 'K', 'i', 'n', 'd',
+			0x04, 'K', 'i', 'n', 'd',
+			0x02,
+		})
+		a.EqualError(err, "named arg 0: can't resolve underlying type of enum Other.Kind")
+	})
+}
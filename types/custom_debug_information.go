@@ -0,0 +1,12 @@
+package types
+
+import "github.com/tdakkota/win32metadata/md"
+
+// CustomDebugInformation is a portable PDB CustomDebugInformation representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type CustomDebugInformation struct {
+	Parent HasCustomDebugInformation
+	Kind   md.GUID
+	Value  Blob
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// Document is a portable PDB Document representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type Document struct {
+	Name          Blob
+	HashAlgorithm md.GUID
+	Hash          Blob
+	Language      md.GUID
+}
+
+// ResolveName decodes document name blob using given Context.
+func (f *Document) ResolveName(c *Context) (string, error) {
+	// Document name blob is a separator character followed by
+	// blob indexes of UTF-8 encoded name parts.
+	if len(f.Name) < 1 {
+		return "", fmt.Errorf("empty document name blob")
+	}
+
+	var (
+		separator = f.Name[0]
+		r         = Signature(f.Name[1:]).Reader()
+		b         strings.Builder
+	)
+	for i := 0; r.Len() > 0; i++ {
+		idx, ok := r.Read()
+		if !ok {
+			return "", fmt.Errorf("invalid part #%d", i)
+		}
+		if i > 0 && separator != 0 {
+			b.WriteByte(separator)
+		}
+		if idx == 0 {
+			continue
+		}
+
+		part, err := c.Metadata.ReadBlob(uint64(idx))
+		if err != nil {
+			return "", fmt.Errorf("read part #%d: %w", i, err)
+		}
+		b.Write(part)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestDocument_ResolveName(t *testing.T) {
+	a := require.New(t)
+
+	var pdb, tables bytes.Buffer
+	for _, v := range []interface{}{
+		[20]byte{1},               // PdbId
+		uint32(0),                 // EntryPoint
+		uint64(1 << md.MethodDef), // ReferencedTypeSystemTables
+		uint32(1),                 // MethodDef rows
+	} {
+		a.NoError(binary.Write(&pdb, binary.LittleEndian, v))
+	}
+	for _, v := range []interface{}{
+		[4]byte{},                // Reserved
+		uint8(2),                 // MajorVersion
+		uint8(0),                 // MinorVersion
+		uint8(0),                 // HeapSizes
+		uint8(1),                 // Reserved
+		uint64(1 << md.Document), // Valid
+		uint64(0),                // Sorted
+		uint32(1),                // Document rows
+		// Document row.
+		uint16(13), // Name
+		uint16(0),  // HashAlgorithm
+		uint16(0),  // Hash
+		uint16(0),  // Language
+	} {
+		a.NoError(binary.Write(&tables, binary.LittleEndian, v))
+	}
+	blobs := []byte{
+		0x00,
+		0x02, 'C', ':',
+		0x03, 's', 'r', 'c',
+		0x04, 'a', '.', 'c', 's',
+		0x04, '\\', 0x01, 0x04, 0x08,
+	}
+
+	m, err := md.ParseMetadataBytes(md.EncodeMetadata("PDB v1.0",
+		md.Stream{Name: "#Pdb", Data: pdb.Bytes()},
+		md.Stream{Name: "#~", Data: tables.Bytes()},
+		md.Stream{Name: "#Blob", Data: blobs},
+	))
+	a.NoError(err)
+	a.Equal("PDB v1.0", m.Version)
+
+	p, ok, err := m.Pdb()
+	a.NoError(err)
+	a.True(ok)
+	a.Equal(uint32(1), p.TypeSystemTableRows[md.MethodDef])
+
+	c, err := FromMetadata(m)
+	a.NoError(err)
+	a.Equal(uint32(1), c.RowCount(md.Document))
+
+	var doc Document
+	a.NoError(doc.FromRow(c.Table(md.Document).Row(0)))
+	name, err := doc.ResolveName(c)
+	a.NoError(err)
+	a.Equal(`C:\src\a.cs`, name)
+
+	_, err = md.ParseMetadataBytes([]byte("not a metadata"))
+	a.Error(err)
+}
+
+func TestMethodDebugInformation_DecodeSequencePoints(t *testing.T) {
+	a := require.New(t)
+
+	info := MethodDebugInformation{
+		Document: 1,
+		SequencePoints: Blob{
+			0x00,                         // LocalSignature
+			0x00, 0x00, 0x05, 0x0A, 0x09, // IL_0000 10:9-10:14
+			0x06, 0x01, 0x7D, 0x04, 0x7F, // IL_0006 12:8-13:6
+			0x02, 0x00, 0x00, // IL_0008 hidden
+			0x00, 0x02, // Document record
+			0x04, 0x00, 0x03, 0x06, 0x02, // IL_000c 15:9-15:12
+		},
+	}
+
+	sp, err := info.DecodeSequencePoints()
+	a.NoError(err)
+	a.Equal(SequencePoints{
+		Points: []SequencePoint{
+			{Document: 1, Offset: 0, StartLine: 10, StartColumn: 9, EndLine: 10, EndColumn: 14},
+			{Document: 1, Offset: 6, StartLine: 12, StartColumn: 8, EndLine: 13, EndColumn: 6},
+			{Document: 1, Offset: 8, Hidden: true},
+			{Document: 2, Offset: 12, StartLine: 15, StartColumn: 9, EndLine: 15, EndColumn: 12},
+		},
+	}, sp)
+
+	t.Run("InitialDocument", func(t *testing.T) {
+		a := require.New(t)
+
+		info := MethodDebugInformation{
+			SequencePoints: Blob{0x01, 0x03, 0x00, 0x00, 0x01, 0x01, 0x01},
+		}
+		sp, err := info.DecodeSequencePoints()
+		a.NoError(err)
+		a.Equal(SequencePoints{
+			LocalSignature: 1,
+			Points: []SequencePoint{
+				{Document: 3, StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 2},
+			},
+		}, sp)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		a := require.New(t)
+
+		info := MethodDebugInformation{
+			Document:       1,
+			SequencePoints: Blob{0x00, 0x00, 0x00},
+		}
+		_, err := info.DecodeSequencePoints()
+		a.Error(err)
+	})
+}
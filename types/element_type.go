@@ -78,10 +78,17 @@ const (
 	ELEMENT_TYPE_PINNED ElementTypeKind = 0x45
 )
 
+// ArrayShape is a II.23.2.13 ArrayShape representation.
+type ArrayShape struct {
+	Rank     uint32
+	Sizes    []uint32
+	LoBounds []int32
+}
+
 // ElementTypeArray is a ElementType union variant structure.
 type ElementTypeArray struct {
-	Elem *Element
-	Size uint32
+	Elem  *Element
+	Shape ArrayShape
 }
 
 // ElementTypeSZArray is a ElementType union variant structure.
@@ -91,8 +98,20 @@ type ElementTypeSZArray struct {
 
 // ElementTypeTypeDef is a ElementType union variant structure.
 type ElementTypeTypeDef struct {
-	Index    TypeDefOrRef
-	Generics []ElementType
+	Index TypeDefOrRef
+	// IsValueType denotes that GENERICINST is instantiated using VALUETYPE, not CLASS.
+	IsValueType bool
+	Generics    []ElementType
+}
+
+// ElementTypePtr is a ElementType union variant structure.
+type ElementTypePtr struct {
+	Elem *Element
+}
+
+// ElementTypeFnPtr is a ElementType union variant structure.
+type ElementTypeFnPtr struct {
+	Signature *MethodSignature
 }
 
 // ElementTypeGenericTypeVar is a ElementType union variant structure.
@@ -107,13 +126,15 @@ type ElementTypeGenericMethodVar struct {
 
 // ElementType is a II.23.1.16 Element types used in signatures representation kind.
 //
-// Only one of GenericParam, Array, SZArray, MethodDef, Field, TypeDef, GenericTypeVar, GenericMethodVar
-// fields should be present.
+// Only one of GenericParam, Array, SZArray, Ptr, FnPtr, MethodDef, Field, TypeDef, GenericTypeVar,
+// GenericMethodVar fields should be present.
 type ElementType struct {
 	Kind             ElementTypeKind
 	GenericParam     Index `table:"GenericParam"`
 	Array            ElementTypeArray
 	SZArray          ElementTypeSZArray
+	Ptr              ElementTypePtr
+	FnPtr            ElementTypeFnPtr
 	MethodDef        Index `table:"MethodDef"`
 	Field            Index `table:"Field"`
 	TypeDef          ElementTypeTypeDef
@@ -158,6 +179,8 @@ func (e *ElementType) FromCode(code uint32) bool {
 		e.Kind = ELEMENT_TYPE_STRING
 	case 0x1c:
 		e.Kind = ELEMENT_TYPE_OBJECT
+	case 0x16:
+		e.Kind = ELEMENT_TYPE_TYPEDBYREF
 	default:
 		e.Kind = ElementTypeKind(code)
 		return false
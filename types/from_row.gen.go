@@ -7,6 +7,110 @@ import (
 	"github.com/tdakkota/win32metadata/md"
 )
 
+// heapColumns contains kinds of heaps referenced by table columns.
+var heapColumns = map[md.TableType][6]heapKind{
+	md.Assembly:               {noHeap, noHeap, noHeap, blobHeap, stringHeap, stringHeap},
+	md.AssemblyOs:             {noHeap, noHeap, noHeap},
+	md.AssemblyProcessor:      {noHeap},
+	md.AssemblyRefOs:          {noHeap, noHeap, noHeap, noHeap},
+	md.AssemblyRefProcessor:   {noHeap, noHeap},
+	md.AssemblyRef:            {noHeap, noHeap, blobHeap, stringHeap, stringHeap, blobHeap},
+	md.ClassLayout:            {noHeap, noHeap, noHeap},
+	md.Constant:               {noHeap, noHeap, blobHeap},
+	md.CustomAttribute:        {noHeap, noHeap, blobHeap},
+	md.CustomDebugInformation: {noHeap, guidHeap, blobHeap},
+	md.DeclSecurity:           {noHeap, noHeap, blobHeap},
+	md.Document:               {blobHeap, guidHeap, blobHeap, guidHeap},
+	md.Event:                  {noHeap, stringHeap, noHeap},
+	md.EventMap:               {noHeap, noHeap},
+	md.ExportedType:           {noHeap, noHeap, stringHeap, stringHeap, noHeap},
+	md.Field:                  {noHeap, stringHeap, blobHeap},
+	md.FieldLayout:            {noHeap, noHeap},
+	md.FieldMarshal:           {noHeap, blobHeap},
+	md.FieldRva:               {noHeap, noHeap},
+	md.File:                   {noHeap, stringHeap, blobHeap},
+	md.GenericParam:           {noHeap, noHeap, noHeap, stringHeap},
+	md.GenericParamConstraint: {noHeap, noHeap},
+	md.ImplMap:                {noHeap, noHeap, stringHeap, noHeap},
+	md.ImportScope:            {noHeap, blobHeap},
+	md.InterfaceImpl:          {noHeap, noHeap},
+	md.LocalConstant:          {stringHeap, blobHeap},
+	md.LocalScope:             {noHeap, noHeap, noHeap, noHeap, noHeap, noHeap},
+	md.LocalVariable:          {noHeap, noHeap, stringHeap},
+	md.ManifestResource:       {noHeap, noHeap, stringHeap, noHeap},
+	md.MemberRef:              {noHeap, stringHeap, blobHeap},
+	md.MethodDebugInformation: {noHeap, blobHeap},
+	md.MethodDef:              {noHeap, noHeap, noHeap, stringHeap, blobHeap, noHeap},
+	md.MethodImpl:             {noHeap, noHeap, noHeap},
+	md.MethodSemantics:        {noHeap, noHeap, noHeap},
+	md.MethodSpec:             {noHeap, blobHeap},
+	md.Module:                 {noHeap, stringHeap, guidHeap, guidHeap, guidHeap},
+	md.ModuleRef:              {stringHeap},
+	md.NestedClass:            {noHeap, noHeap},
+	md.Param:                  {noHeap, noHeap, stringHeap},
+	md.Property:               {noHeap, stringHeap, blobHeap},
+	md.PropertyMap:            {noHeap, noHeap},
+	md.StateMachineMethod:     {noHeap, noHeap},
+	md.TypeDef:                {noHeap, stringHeap, stringHeap, noHeap, noHeap, noHeap},
+	md.TypeRef:                {noHeap, stringHeap, stringHeap},
+	md.TypeSpec:               {blobHeap},
+}
+
+// indexColumns contains functions updating references to moved rows
+// stored in table columns.
+var indexColumns = map[md.TableType][6]remapFunc{
+	md.Assembly:               {nil, nil, nil, nil, nil, nil},
+	md.AssemblyOs:             {nil, nil, nil},
+	md.AssemblyProcessor:      {nil},
+	md.AssemblyRefOs:          {nil, nil, nil, remapIndex(md.AssemblyRef)},
+	md.AssemblyRefProcessor:   {nil, remapIndex(md.AssemblyRef)},
+	md.AssemblyRef:            {nil, nil, nil, nil, nil, nil},
+	md.ClassLayout:            {nil, nil, remapIndex(md.TypeDef)},
+	md.Constant:               {nil, remapCoded[HasConstant], nil},
+	md.CustomAttribute:        {remapCoded[HasCustomAttribute], remapCoded[CustomAttributeType], nil},
+	md.CustomDebugInformation: {remapCoded[HasCustomDebugInformation], nil, nil},
+	md.DeclSecurity:           {nil, remapCoded[HasDeclSecurity], nil},
+	md.Document:               {nil, nil, nil, nil},
+	md.Event:                  {nil, nil, remapCoded[TypeDefOrRef]},
+	md.EventMap:               {remapIndex(md.TypeDef), nil},
+	md.ExportedType:           {nil, nil, nil, nil, remapCoded[Implementation]},
+	md.Field:                  {nil, nil, nil},
+	md.FieldLayout:            {nil, remapIndex(md.Field)},
+	md.FieldMarshal:           {remapCoded[HasFieldMarshall], nil},
+	md.FieldRva:               {nil, remapIndex(md.Field)},
+	md.File:                   {nil, nil, nil},
+	md.GenericParam:           {nil, nil, remapCoded[TypeOrMethodDef], nil},
+	md.GenericParamConstraint: {remapIndex(md.GenericParam), remapCoded[TypeDefOrRef]},
+	md.ImplMap:                {nil, remapCoded[MemberForwarded], nil, remapIndex(md.ModuleRef)},
+	md.ImportScope:            {remapIndex(md.ImportScope), nil},
+	md.InterfaceImpl:          {remapIndex(md.TypeDef), remapCoded[TypeDefOrRef]},
+	md.LocalConstant:          {nil, nil},
+	md.LocalScope:             {remapIndex(md.MethodDef), remapIndex(md.ImportScope), nil, nil, nil, nil},
+	md.LocalVariable:          {nil, nil, nil},
+	md.ManifestResource:       {nil, nil, nil, remapCoded[Implementation]},
+	md.MemberRef:              {remapCoded[MemberRefParent], nil, nil},
+	md.MethodDebugInformation: {remapIndex(md.Document), nil},
+	md.MethodDef:              {nil, nil, nil, nil, nil, nil},
+	md.MethodImpl:             {remapIndex(md.TypeDef), remapCoded[MethodDefOrRef], remapCoded[MethodDefOrRef]},
+	md.MethodSemantics:        {nil, remapIndex(md.MethodDef), remapCoded[HasSemantics]},
+	md.MethodSpec:             {remapCoded[MethodDefOrRef], nil},
+	md.Module:                 {nil, nil, nil, nil, nil},
+	md.ModuleRef:              {nil},
+	md.NestedClass:            {remapIndex(md.TypeDef), remapIndex(md.TypeDef)},
+	md.Param:                  {nil, nil, nil},
+	md.Property:               {nil, nil, nil},
+	md.PropertyMap:            {remapIndex(md.TypeDef), nil},
+	md.StateMachineMethod:     {remapIndex(md.MethodDef), remapIndex(md.MethodDef)},
+	md.TypeDef:                {nil, nil, nil, remapCoded[TypeDefOrRef], nil, nil},
+	md.TypeRef:                {remapCoded[ResolutionScope], nil, nil},
+	md.TypeSpec:               {nil},
+}
+
+// TableType returns type of Assembly table.
+func (*Assembly) TableType() md.TableType {
+	return md.Assembly
+}
+
 // FromRow creates Assembly from given Row.
 func (f *Assembly) FromRow(r Row) error {
 	{
@@ -54,6 +158,24 @@ func (f *Assembly) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Assembly and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Assembly) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Assembly, md.RowValues{
+		uint64(f.HashAlgId),
+		uint64(f.Version),
+		uint64(f.Flags),
+		uint64(w.Blob(f.PublicKey)),
+		uint64(w.String(f.Name)),
+		uint64(w.String(f.Culture)),
+	})
+}
+
+// TableType returns type of AssemblyOS table.
+func (*AssemblyOS) TableType() md.TableType {
+	return md.AssemblyOs
+}
+
 // FromRow creates AssemblyOS from given Row.
 func (f *AssemblyOS) FromRow(r Row) error {
 	{
@@ -80,6 +202,21 @@ func (f *AssemblyOS) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes AssemblyOS and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *AssemblyOS) AppendTo(w *Writer) Index {
+	return w.AddRow(md.AssemblyOs, md.RowValues{
+		uint64(f.OSPlatformID),
+		uint64(f.OSMajorVersion),
+		uint64(f.OSMinorVersion),
+	})
+}
+
+// TableType returns type of AssemblyProcessor table.
+func (*AssemblyProcessor) TableType() md.TableType {
+	return md.AssemblyProcessor
+}
+
 // FromRow creates AssemblyProcessor from given Row.
 func (f *AssemblyProcessor) FromRow(r Row) error {
 	{
@@ -92,6 +229,19 @@ func (f *AssemblyProcessor) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes AssemblyProcessor and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *AssemblyProcessor) AppendTo(w *Writer) Index {
+	return w.AddRow(md.AssemblyProcessor, md.RowValues{
+		uint64(f.Processor),
+	})
+}
+
+// TableType returns type of AssemblyRefOS table.
+func (*AssemblyRefOS) TableType() md.TableType {
+	return md.AssemblyRefOs
+}
+
 // FromRow creates AssemblyRefOS from given Row.
 func (f *AssemblyRefOS) FromRow(r Row) error {
 	{
@@ -125,6 +275,17 @@ func (f *AssemblyRefOS) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes AssemblyRefOS and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *AssemblyRefOS) AppendTo(w *Writer) Index {
+	return w.AddRow(md.AssemblyRefOs, md.RowValues{
+		uint64(f.OSPlatformID),
+		uint64(f.OSMajorVersion),
+		uint64(f.OSMinorVersion),
+		uint64(f.AssemblyRef),
+	})
+}
+
 // ResolveAssemblyRef resolves AssemblyRef index using given Context.
 func (f *AssemblyRefOS) ResolveAssemblyRef(c *Context) (AssemblyRef, error) {
 	table := c.Table(md.AssemblyRef)
@@ -135,6 +296,11 @@ func (f *AssemblyRefOS) ResolveAssemblyRef(c *Context) (AssemblyRef, error) {
 	return t, nil
 }
 
+// TableType returns type of AssemblyRefProcessor table.
+func (*AssemblyRefProcessor) TableType() md.TableType {
+	return md.AssemblyRefProcessor
+}
+
 // FromRow creates AssemblyRefProcessor from given Row.
 func (f *AssemblyRefProcessor) FromRow(r Row) error {
 	{
@@ -154,6 +320,15 @@ func (f *AssemblyRefProcessor) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes AssemblyRefProcessor and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *AssemblyRefProcessor) AppendTo(w *Writer) Index {
+	return w.AddRow(md.AssemblyRefProcessor, md.RowValues{
+		uint64(f.Processor),
+		uint64(f.AssemblyRef),
+	})
+}
+
 // ResolveAssemblyRef resolves AssemblyRef index using given Context.
 func (f *AssemblyRefProcessor) ResolveAssemblyRef(c *Context) (AssemblyRef, error) {
 	table := c.Table(md.AssemblyRef)
@@ -164,6 +339,11 @@ func (f *AssemblyRefProcessor) ResolveAssemblyRef(c *Context) (AssemblyRef, erro
 	return t, nil
 }
 
+// TableType returns type of AssemblyRef table.
+func (*AssemblyRef) TableType() md.TableType {
+	return md.AssemblyRef
+}
+
 // FromRow creates AssemblyRef from given Row.
 func (f *AssemblyRef) FromRow(r Row) error {
 	{
@@ -211,6 +391,24 @@ func (f *AssemblyRef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes AssemblyRef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *AssemblyRef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.AssemblyRef, md.RowValues{
+		uint64(f.Version),
+		uint64(f.Flags),
+		uint64(w.Blob(f.PublicKeyOrToken)),
+		uint64(w.String(f.Name)),
+		uint64(w.String(f.Culture)),
+		uint64(w.Blob(f.HashValue)),
+	})
+}
+
+// TableType returns type of ClassLayout table.
+func (*ClassLayout) TableType() md.TableType {
+	return md.ClassLayout
+}
+
 // FromRow creates ClassLayout from given Row.
 func (f *ClassLayout) FromRow(r Row) error {
 	{
@@ -237,6 +435,16 @@ func (f *ClassLayout) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes ClassLayout and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ClassLayout) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ClassLayout, md.RowValues{
+		uint64(f.PackingSize),
+		uint64(f.ClassSize),
+		uint64(f.Parent),
+	})
+}
+
 // ResolveParent resolves Parent index using given Context.
 func (f *ClassLayout) ResolveParent(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -247,6 +455,11 @@ func (f *ClassLayout) ResolveParent(c *Context) (TypeDef, error) {
 	return t, nil
 }
 
+// TableType returns type of Constant table.
+func (*Constant) TableType() md.TableType {
+	return md.Constant
+}
+
 // FromRow creates Constant from given Row.
 func (f *Constant) FromRow(r Row) error {
 	{
@@ -273,6 +486,21 @@ func (f *Constant) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Constant and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Constant) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Constant, md.RowValues{
+		uint64(f.Type),
+		uint64(f.Parent),
+		uint64(w.Blob(f.Value)),
+	})
+}
+
+// TableType returns type of CustomAttribute table.
+func (*CustomAttribute) TableType() md.TableType {
+	return md.CustomAttribute
+}
+
 // FromRow creates CustomAttribute from given Row.
 func (f *CustomAttribute) FromRow(r Row) error {
 	{
@@ -299,6 +527,62 @@ func (f *CustomAttribute) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes CustomAttribute and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *CustomAttribute) AppendTo(w *Writer) Index {
+	return w.AddRow(md.CustomAttribute, md.RowValues{
+		uint64(f.Parent),
+		uint64(f.Type),
+		uint64(w.Blob(f.Value)),
+	})
+}
+
+// TableType returns type of CustomDebugInformation table.
+func (*CustomDebugInformation) TableType() md.TableType {
+	return md.CustomDebugInformation
+}
+
+// FromRow creates CustomDebugInformation from given Row.
+func (f *CustomDebugInformation) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field Parent: %w", err)
+		}
+		f.Parent = HasCustomDebugInformation(v)
+	}
+	{
+		v, err := r.GUID(1)
+		if err != nil {
+			return fmt.Errorf("decode field Kind: %w", err)
+		}
+		f.Kind = md.GUID(v)
+	}
+	{
+		v, err := r.Blob(2)
+		if err != nil {
+			return fmt.Errorf("decode field Value: %w", err)
+		}
+		f.Value = Blob(v)
+	}
+	return nil
+}
+
+// AppendTo encodes CustomDebugInformation and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *CustomDebugInformation) AppendTo(w *Writer) Index {
+	return w.AddRow(md.CustomDebugInformation, md.RowValues{
+		uint64(f.Parent),
+		uint64(w.GUID(f.Kind)),
+		uint64(w.Blob(f.Value)),
+	})
+}
+
+// TableType returns type of DeclSecurity table.
+func (*DeclSecurity) TableType() md.TableType {
+	return md.DeclSecurity
+}
+
 // FromRow creates DeclSecurity from given Row.
 func (f *DeclSecurity) FromRow(r Row) error {
 	{
@@ -325,6 +609,70 @@ func (f *DeclSecurity) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes DeclSecurity and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *DeclSecurity) AppendTo(w *Writer) Index {
+	return w.AddRow(md.DeclSecurity, md.RowValues{
+		uint64(f.Action),
+		uint64(f.Parent),
+		uint64(w.Blob(f.PermissionSet)),
+	})
+}
+
+// TableType returns type of Document table.
+func (*Document) TableType() md.TableType {
+	return md.Document
+}
+
+// FromRow creates Document from given Row.
+func (f *Document) FromRow(r Row) error {
+	{
+		v, err := r.Blob(0)
+		if err != nil {
+			return fmt.Errorf("decode field Name: %w", err)
+		}
+		f.Name = Blob(v)
+	}
+	{
+		v, err := r.GUID(1)
+		if err != nil {
+			return fmt.Errorf("decode field HashAlgorithm: %w", err)
+		}
+		f.HashAlgorithm = md.GUID(v)
+	}
+	{
+		v, err := r.Blob(2)
+		if err != nil {
+			return fmt.Errorf("decode field Hash: %w", err)
+		}
+		f.Hash = Blob(v)
+	}
+	{
+		v, err := r.GUID(3)
+		if err != nil {
+			return fmt.Errorf("decode field Language: %w", err)
+		}
+		f.Language = md.GUID(v)
+	}
+	return nil
+}
+
+// AppendTo encodes Document and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Document) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Document, md.RowValues{
+		uint64(w.Blob(f.Name)),
+		uint64(w.GUID(f.HashAlgorithm)),
+		uint64(w.Blob(f.Hash)),
+		uint64(w.GUID(f.Language)),
+	})
+}
+
+// TableType returns type of Event table.
+func (*Event) TableType() md.TableType {
+	return md.Event
+}
+
 // FromRow creates Event from given Row.
 func (f *Event) FromRow(r Row) error {
 	{
@@ -351,6 +699,21 @@ func (f *Event) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Event and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Event) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Event, md.RowValues{
+		uint64(f.EventFlags),
+		uint64(w.String(f.Name)),
+		uint64(f.EventType),
+	})
+}
+
+// TableType returns type of EventMap table.
+func (*EventMap) TableType() md.TableType {
+	return md.EventMap
+}
+
 // FromRow creates EventMap from given Row.
 func (f *EventMap) FromRow(r Row) error {
 	{
@@ -370,6 +733,15 @@ func (f *EventMap) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes EventMap and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *EventMap) AppendTo(w *Writer) Index {
+	return w.AddRow(md.EventMap, md.RowValues{
+		uint64(f.Parent),
+		uint64(f.EventList.Start()) + 1,
+	})
+}
+
 // ResolveParent resolves Parent index using given Context.
 func (f *EventMap) ResolveParent(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -392,7 +764,11 @@ func (f *EventMap) ResolveEventList(c *Context) ([]Event, error) {
 		result = make([]Event, 0, f.EventList.Size())
 	)
 	for i := f.EventList.Start(); i < f.EventList.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.Event, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
@@ -401,6 +777,11 @@ func (f *EventMap) ResolveEventList(c *Context) ([]Event, error) {
 	return result, nil
 }
 
+// TableType returns type of ExportedType table.
+func (*ExportedType) TableType() md.TableType {
+	return md.ExportedType
+}
+
 // FromRow creates ExportedType from given Row.
 func (f *ExportedType) FromRow(r Row) error {
 	{
@@ -441,6 +822,23 @@ func (f *ExportedType) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes ExportedType and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ExportedType) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ExportedType, md.RowValues{
+		uint64(f.Flags),
+		uint64(f.TypeDefId),
+		uint64(w.String(f.TypeName)),
+		uint64(w.String(f.TypeNamespace)),
+		uint64(f.Implementation),
+	})
+}
+
+// TableType returns type of Field table.
+func (*Field) TableType() md.TableType {
+	return md.Field
+}
+
 // FromRow creates Field from given Row.
 func (f *Field) FromRow(r Row) error {
 	{
@@ -467,6 +865,21 @@ func (f *Field) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Field and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Field) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Field, md.RowValues{
+		uint64(f.Flags),
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(Blob(f.Signature))),
+	})
+}
+
+// TableType returns type of FieldLayout table.
+func (*FieldLayout) TableType() md.TableType {
+	return md.FieldLayout
+}
+
 // FromRow creates FieldLayout from given Row.
 func (f *FieldLayout) FromRow(r Row) error {
 	{
@@ -486,6 +899,15 @@ func (f *FieldLayout) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes FieldLayout and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *FieldLayout) AppendTo(w *Writer) Index {
+	return w.AddRow(md.FieldLayout, md.RowValues{
+		uint64(f.Offset),
+		uint64(f.Field),
+	})
+}
+
 // ResolveField resolves Field index using given Context.
 func (f *FieldLayout) ResolveField(c *Context) (Field, error) {
 	table := c.Table(md.Field)
@@ -496,6 +918,11 @@ func (f *FieldLayout) ResolveField(c *Context) (Field, error) {
 	return t, nil
 }
 
+// TableType returns type of FieldMarshal table.
+func (*FieldMarshal) TableType() md.TableType {
+	return md.FieldMarshal
+}
+
 // FromRow creates FieldMarshal from given Row.
 func (f *FieldMarshal) FromRow(r Row) error {
 	{
@@ -503,7 +930,7 @@ func (f *FieldMarshal) FromRow(r Row) error {
 		if err != nil {
 			return fmt.Errorf("decode field Parent: %w", err)
 		}
-		f.Parent = Index(v)
+		f.Parent = HasFieldMarshall(v)
 	}
 	{
 		v, err := r.Blob(1)
@@ -515,6 +942,20 @@ func (f *FieldMarshal) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes FieldMarshal and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *FieldMarshal) AppendTo(w *Writer) Index {
+	return w.AddRow(md.FieldMarshal, md.RowValues{
+		uint64(f.Parent),
+		uint64(w.Blob(f.NativeType)),
+	})
+}
+
+// TableType returns type of FieldRVA table.
+func (*FieldRVA) TableType() md.TableType {
+	return md.FieldRva
+}
+
 // FromRow creates FieldRVA from given Row.
 func (f *FieldRVA) FromRow(r Row) error {
 	{
@@ -534,6 +975,15 @@ func (f *FieldRVA) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes FieldRVA and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *FieldRVA) AppendTo(w *Writer) Index {
+	return w.AddRow(md.FieldRva, md.RowValues{
+		uint64(f.RVA),
+		uint64(f.Field),
+	})
+}
+
 // ResolveField resolves Field index using given Context.
 func (f *FieldRVA) ResolveField(c *Context) (Field, error) {
 	table := c.Table(md.Field)
@@ -544,6 +994,11 @@ func (f *FieldRVA) ResolveField(c *Context) (Field, error) {
 	return t, nil
 }
 
+// TableType returns type of File table.
+func (*File) TableType() md.TableType {
+	return md.File
+}
+
 // FromRow creates File from given Row.
 func (f *File) FromRow(r Row) error {
 	{
@@ -570,6 +1025,21 @@ func (f *File) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes File and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *File) AppendTo(w *Writer) Index {
+	return w.AddRow(md.File, md.RowValues{
+		uint64(f.Flags),
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(f.HashValue)),
+	})
+}
+
+// TableType returns type of GenericParam table.
+func (*GenericParam) TableType() md.TableType {
+	return md.GenericParam
+}
+
 // FromRow creates GenericParam from given Row.
 func (f *GenericParam) FromRow(r Row) error {
 	{
@@ -603,6 +1073,22 @@ func (f *GenericParam) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes GenericParam and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *GenericParam) AppendTo(w *Writer) Index {
+	return w.AddRow(md.GenericParam, md.RowValues{
+		uint64(f.Number),
+		uint64(f.Flags),
+		uint64(f.Owner),
+		uint64(w.String(f.Name)),
+	})
+}
+
+// TableType returns type of GenericParamConstraint table.
+func (*GenericParamConstraint) TableType() md.TableType {
+	return md.GenericParamConstraint
+}
+
 // FromRow creates GenericParamConstraint from given Row.
 func (f *GenericParamConstraint) FromRow(r Row) error {
 	{
@@ -622,6 +1108,15 @@ func (f *GenericParamConstraint) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes GenericParamConstraint and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *GenericParamConstraint) AppendTo(w *Writer) Index {
+	return w.AddRow(md.GenericParamConstraint, md.RowValues{
+		uint64(f.Owner),
+		uint64(f.Constraint),
+	})
+}
+
 // ResolveOwner resolves Owner index using given Context.
 func (f *GenericParamConstraint) ResolveOwner(c *Context) (GenericParam, error) {
 	table := c.Table(md.GenericParam)
@@ -632,6 +1127,11 @@ func (f *GenericParamConstraint) ResolveOwner(c *Context) (GenericParam, error)
 	return t, nil
 }
 
+// TableType returns type of ImplMap table.
+func (*ImplMap) TableType() md.TableType {
+	return md.ImplMap
+}
+
 // FromRow creates ImplMap from given Row.
 func (f *ImplMap) FromRow(r Row) error {
 	{
@@ -665,6 +1165,17 @@ func (f *ImplMap) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes ImplMap and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ImplMap) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ImplMap, md.RowValues{
+		uint64(f.MappingFlags),
+		uint64(f.MemberForwarded),
+		uint64(w.String(f.ImportName)),
+		uint64(f.ImportScope),
+	})
+}
+
 // ResolveImportScope resolves ImportScope index using given Context.
 func (f *ImplMap) ResolveImportScope(c *Context) (ModuleRef, error) {
 	table := c.Table(md.ModuleRef)
@@ -675,6 +1186,54 @@ func (f *ImplMap) ResolveImportScope(c *Context) (ModuleRef, error) {
 	return t, nil
 }
 
+// TableType returns type of ImportScope table.
+func (*ImportScope) TableType() md.TableType {
+	return md.ImportScope
+}
+
+// FromRow creates ImportScope from given Row.
+func (f *ImportScope) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field Parent: %w", err)
+		}
+		f.Parent = Index(v)
+	}
+	{
+		v, err := r.Blob(1)
+		if err != nil {
+			return fmt.Errorf("decode field Imports: %w", err)
+		}
+		f.Imports = Blob(v)
+	}
+	return nil
+}
+
+// AppendTo encodes ImportScope and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ImportScope) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ImportScope, md.RowValues{
+		uint64(f.Parent),
+		uint64(w.Blob(f.Imports)),
+	})
+}
+
+// ResolveParent resolves Parent index using given Context.
+func (f *ImportScope) ResolveParent(c *Context) (ImportScope, error) {
+	table := c.Table(md.ImportScope)
+	var t ImportScope
+	if err := t.FromRow(table.Row(uint32(f.Parent) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// TableType returns type of InterfaceImpl table.
+func (*InterfaceImpl) TableType() md.TableType {
+	return md.InterfaceImpl
+}
+
 // FromRow creates InterfaceImpl from given Row.
 func (f *InterfaceImpl) FromRow(r Row) error {
 	{
@@ -694,6 +1253,15 @@ func (f *InterfaceImpl) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes InterfaceImpl and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *InterfaceImpl) AppendTo(w *Writer) Index {
+	return w.AddRow(md.InterfaceImpl, md.RowValues{
+		uint64(f.Class),
+		uint64(f.Interface),
+	})
+}
+
 // ResolveClass resolves Class index using given Context.
 func (f *InterfaceImpl) ResolveClass(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -704,6 +1272,220 @@ func (f *InterfaceImpl) ResolveClass(c *Context) (TypeDef, error) {
 	return t, nil
 }
 
+// TableType returns type of LocalConstant table.
+func (*LocalConstant) TableType() md.TableType {
+	return md.LocalConstant
+}
+
+// FromRow creates LocalConstant from given Row.
+func (f *LocalConstant) FromRow(r Row) error {
+	{
+		v, err := r.String(0)
+		if err != nil {
+			return fmt.Errorf("decode field Name: %w", err)
+		}
+		f.Name = string(v)
+	}
+	{
+		v, err := r.Signature(1)
+		if err != nil {
+			return fmt.Errorf("decode field Signature: %w", err)
+		}
+		f.Signature = Signature(v)
+	}
+	return nil
+}
+
+// AppendTo encodes LocalConstant and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *LocalConstant) AppendTo(w *Writer) Index {
+	return w.AddRow(md.LocalConstant, md.RowValues{
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(Blob(f.Signature))),
+	})
+}
+
+// TableType returns type of LocalScope table.
+func (*LocalScope) TableType() md.TableType {
+	return md.LocalScope
+}
+
+// FromRow creates LocalScope from given Row.
+func (f *LocalScope) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field Method: %w", err)
+		}
+		f.Method = Index(v)
+	}
+	{
+		v, err := r.Uint64(1)
+		if err != nil {
+			return fmt.Errorf("decode field ImportScope: %w", err)
+		}
+		f.ImportScope = Index(v)
+	}
+	{
+		v, err := r.List(2, md.LocalVariable)
+		if err != nil {
+			return fmt.Errorf("decode field VariableList: %w", err)
+		}
+		f.VariableList = List(v)
+	}
+	{
+		v, err := r.List(3, md.LocalConstant)
+		if err != nil {
+			return fmt.Errorf("decode field ConstantList: %w", err)
+		}
+		f.ConstantList = List(v)
+	}
+	{
+		v, err := r.Uint64(4)
+		if err != nil {
+			return fmt.Errorf("decode field StartOffset: %w", err)
+		}
+		f.StartOffset = uint32(v)
+	}
+	{
+		v, err := r.Uint64(5)
+		if err != nil {
+			return fmt.Errorf("decode field Length: %w", err)
+		}
+		f.Length = uint32(v)
+	}
+	return nil
+}
+
+// AppendTo encodes LocalScope and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *LocalScope) AppendTo(w *Writer) Index {
+	return w.AddRow(md.LocalScope, md.RowValues{
+		uint64(f.Method),
+		uint64(f.ImportScope),
+		uint64(f.VariableList.Start()) + 1,
+		uint64(f.ConstantList.Start()) + 1,
+		uint64(f.StartOffset),
+		uint64(f.Length),
+	})
+}
+
+// ResolveMethod resolves Method index using given Context.
+func (f *LocalScope) ResolveMethod(c *Context) (MethodDef, error) {
+	table := c.Table(md.MethodDef)
+	var t MethodDef
+	if err := t.FromRow(table.Row(uint32(f.Method) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// ResolveImportScope resolves ImportScope index using given Context.
+func (f *LocalScope) ResolveImportScope(c *Context) (ImportScope, error) {
+	table := c.Table(md.ImportScope)
+	var t ImportScope
+	if err := t.FromRow(table.Row(uint32(f.ImportScope) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// ResolveVariableList resolves VariableList index using given Context.
+func (f *LocalScope) ResolveVariableList(c *Context) ([]LocalVariable, error) {
+	table := c.Table(md.LocalVariable)
+	if f.VariableList.Empty() {
+		return nil, nil
+	}
+
+	var (
+		t      LocalVariable
+		result = make([]LocalVariable, 0, f.VariableList.Size())
+	)
+	for i := f.VariableList.Start(); i < f.VariableList.End(); i++ {
+		row, err := c.ListIndex(md.LocalVariable, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
+			return result, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// ResolveConstantList resolves ConstantList index using given Context.
+func (f *LocalScope) ResolveConstantList(c *Context) ([]LocalConstant, error) {
+	table := c.Table(md.LocalConstant)
+	if f.ConstantList.Empty() {
+		return nil, nil
+	}
+
+	var (
+		t      LocalConstant
+		result = make([]LocalConstant, 0, f.ConstantList.Size())
+	)
+	for i := f.ConstantList.Start(); i < f.ConstantList.End(); i++ {
+		row, err := c.ListIndex(md.LocalConstant, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
+			return result, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// TableType returns type of LocalVariable table.
+func (*LocalVariable) TableType() md.TableType {
+	return md.LocalVariable
+}
+
+// FromRow creates LocalVariable from given Row.
+func (f *LocalVariable) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field Attributes: %w", err)
+		}
+		f.Attributes = uint16(v)
+	}
+	{
+		v, err := r.Uint64(1)
+		if err != nil {
+			return fmt.Errorf("decode field Index: %w", err)
+		}
+		f.Index = uint16(v)
+	}
+	{
+		v, err := r.String(2)
+		if err != nil {
+			return fmt.Errorf("decode field Name: %w", err)
+		}
+		f.Name = string(v)
+	}
+	return nil
+}
+
+// AppendTo encodes LocalVariable and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *LocalVariable) AppendTo(w *Writer) Index {
+	return w.AddRow(md.LocalVariable, md.RowValues{
+		uint64(f.Attributes),
+		uint64(f.Index),
+		uint64(w.String(f.Name)),
+	})
+}
+
+// TableType returns type of ManifestResource table.
+func (*ManifestResource) TableType() md.TableType {
+	return md.ManifestResource
+}
+
 // FromRow creates ManifestResource from given Row.
 func (f *ManifestResource) FromRow(r Row) error {
 	{
@@ -737,6 +1519,22 @@ func (f *ManifestResource) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes ManifestResource and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ManifestResource) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ManifestResource, md.RowValues{
+		uint64(f.Offset),
+		uint64(f.Flags),
+		uint64(w.String(f.Name)),
+		uint64(f.Implementation),
+	})
+}
+
+// TableType returns type of MemberRef table.
+func (*MemberRef) TableType() md.TableType {
+	return md.MemberRef
+}
+
 // FromRow creates MemberRef from given Row.
 func (f *MemberRef) FromRow(r Row) error {
 	{
@@ -763,6 +1561,64 @@ func (f *MemberRef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes MemberRef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MemberRef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MemberRef, md.RowValues{
+		uint64(f.Class),
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(Blob(f.Signature))),
+	})
+}
+
+// TableType returns type of MethodDebugInformation table.
+func (*MethodDebugInformation) TableType() md.TableType {
+	return md.MethodDebugInformation
+}
+
+// FromRow creates MethodDebugInformation from given Row.
+func (f *MethodDebugInformation) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field Document: %w", err)
+		}
+		f.Document = Index(v)
+	}
+	{
+		v, err := r.Blob(1)
+		if err != nil {
+			return fmt.Errorf("decode field SequencePoints: %w", err)
+		}
+		f.SequencePoints = Blob(v)
+	}
+	return nil
+}
+
+// AppendTo encodes MethodDebugInformation and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MethodDebugInformation) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MethodDebugInformation, md.RowValues{
+		uint64(f.Document),
+		uint64(w.Blob(f.SequencePoints)),
+	})
+}
+
+// ResolveDocument resolves Document index using given Context.
+func (f *MethodDebugInformation) ResolveDocument(c *Context) (Document, error) {
+	table := c.Table(md.Document)
+	var t Document
+	if err := t.FromRow(table.Row(uint32(f.Document) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// TableType returns type of MethodDef table.
+func (*MethodDef) TableType() md.TableType {
+	return md.MethodDef
+}
+
 // FromRow creates MethodDef from given Row.
 func (f *MethodDef) FromRow(r Row) error {
 	{
@@ -810,6 +1666,19 @@ func (f *MethodDef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes MethodDef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MethodDef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MethodDef, md.RowValues{
+		uint64(f.RVA),
+		uint64(f.ImplFlags),
+		uint64(f.Flags),
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(Blob(f.Signature))),
+		uint64(f.ParamList.Start()) + 1,
+	})
+}
+
 // ResolveParamList resolves ParamList index using given Context.
 func (f *MethodDef) ResolveParamList(c *Context) ([]Param, error) {
 	table := c.Table(md.Param)
@@ -822,7 +1691,11 @@ func (f *MethodDef) ResolveParamList(c *Context) ([]Param, error) {
 		result = make([]Param, 0, f.ParamList.Size())
 	)
 	for i := f.ParamList.Start(); i < f.ParamList.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.Param, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
@@ -831,6 +1704,11 @@ func (f *MethodDef) ResolveParamList(c *Context) ([]Param, error) {
 	return result, nil
 }
 
+// TableType returns type of MethodImpl table.
+func (*MethodImpl) TableType() md.TableType {
+	return md.MethodImpl
+}
+
 // FromRow creates MethodImpl from given Row.
 func (f *MethodImpl) FromRow(r Row) error {
 	{
@@ -857,6 +1735,16 @@ func (f *MethodImpl) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes MethodImpl and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MethodImpl) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MethodImpl, md.RowValues{
+		uint64(f.Class),
+		uint64(f.MethodBody),
+		uint64(f.MethodDeclaration),
+	})
+}
+
 // ResolveClass resolves Class index using given Context.
 func (f *MethodImpl) ResolveClass(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -867,6 +1755,11 @@ func (f *MethodImpl) ResolveClass(c *Context) (TypeDef, error) {
 	return t, nil
 }
 
+// TableType returns type of MethodSemantics table.
+func (*MethodSemantics) TableType() md.TableType {
+	return md.MethodSemantics
+}
+
 // FromRow creates MethodSemantics from given Row.
 func (f *MethodSemantics) FromRow(r Row) error {
 	{
@@ -893,6 +1786,16 @@ func (f *MethodSemantics) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes MethodSemantics and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MethodSemantics) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MethodSemantics, md.RowValues{
+		uint64(f.Semantics),
+		uint64(f.Method),
+		uint64(f.Association),
+	})
+}
+
 // ResolveMethod resolves Method index using given Context.
 func (f *MethodSemantics) ResolveMethod(c *Context) (MethodDef, error) {
 	table := c.Table(md.MethodDef)
@@ -903,6 +1806,11 @@ func (f *MethodSemantics) ResolveMethod(c *Context) (MethodDef, error) {
 	return t, nil
 }
 
+// TableType returns type of MethodSpec table.
+func (*MethodSpec) TableType() md.TableType {
+	return md.MethodSpec
+}
+
 // FromRow creates MethodSpec from given Row.
 func (f *MethodSpec) FromRow(r Row) error {
 	{
@@ -922,6 +1830,20 @@ func (f *MethodSpec) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes MethodSpec and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *MethodSpec) AppendTo(w *Writer) Index {
+	return w.AddRow(md.MethodSpec, md.RowValues{
+		uint64(f.Method),
+		uint64(w.Blob(f.Instantiation)),
+	})
+}
+
+// TableType returns type of Module table.
+func (*Module) TableType() md.TableType {
+	return md.Module
+}
+
 // FromRow creates Module from given Row.
 func (f *Module) FromRow(r Row) error {
 	{
@@ -939,29 +1861,46 @@ func (f *Module) FromRow(r Row) error {
 		f.Name = string(v)
 	}
 	{
-		v, err := r.Uint64(2)
+		v, err := r.GUID(2)
 		if err != nil {
 			return fmt.Errorf("decode field Mvid: %w", err)
 		}
-		f.Mvid = GUID(v)
+		f.Mvid = md.GUID(v)
 	}
 	{
-		v, err := r.Uint64(3)
+		v, err := r.GUID(3)
 		if err != nil {
 			return fmt.Errorf("decode field EncId: %w", err)
 		}
-		f.EncId = GUID(v)
+		f.EncId = md.GUID(v)
 	}
 	{
-		v, err := r.Uint64(4)
+		v, err := r.GUID(4)
 		if err != nil {
 			return fmt.Errorf("decode field EncBaseId: %w", err)
 		}
-		f.EncBaseId = GUID(v)
+		f.EncBaseId = md.GUID(v)
 	}
 	return nil
 }
 
+// AppendTo encodes Module and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Module) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Module, md.RowValues{
+		uint64(f.Generation),
+		uint64(w.String(f.Name)),
+		uint64(w.GUID(f.Mvid)),
+		uint64(w.GUID(f.EncId)),
+		uint64(w.GUID(f.EncBaseId)),
+	})
+}
+
+// TableType returns type of ModuleRef table.
+func (*ModuleRef) TableType() md.TableType {
+	return md.ModuleRef
+}
+
 // FromRow creates ModuleRef from given Row.
 func (f *ModuleRef) FromRow(r Row) error {
 	{
@@ -974,6 +1913,19 @@ func (f *ModuleRef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes ModuleRef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *ModuleRef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.ModuleRef, md.RowValues{
+		uint64(w.String(f.Name)),
+	})
+}
+
+// TableType returns type of NestedClass table.
+func (*NestedClass) TableType() md.TableType {
+	return md.NestedClass
+}
+
 // FromRow creates NestedClass from given Row.
 func (f *NestedClass) FromRow(r Row) error {
 	{
@@ -993,6 +1945,15 @@ func (f *NestedClass) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes NestedClass and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *NestedClass) AppendTo(w *Writer) Index {
+	return w.AddRow(md.NestedClass, md.RowValues{
+		uint64(f.NestedClass),
+		uint64(f.EnclosingClass),
+	})
+}
+
 // ResolveNestedClass resolves NestedClass index using given Context.
 func (f *NestedClass) ResolveNestedClass(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -1013,6 +1974,11 @@ func (f *NestedClass) ResolveEnclosingClass(c *Context) (TypeDef, error) {
 	return t, nil
 }
 
+// TableType returns type of Param table.
+func (*Param) TableType() md.TableType {
+	return md.Param
+}
+
 // FromRow creates Param from given Row.
 func (f *Param) FromRow(r Row) error {
 	{
@@ -1039,6 +2005,21 @@ func (f *Param) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Param and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Param) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Param, md.RowValues{
+		uint64(f.Flags),
+		uint64(f.Sequence),
+		uint64(w.String(f.Name)),
+	})
+}
+
+// TableType returns type of Property table.
+func (*Property) TableType() md.TableType {
+	return md.Property
+}
+
 // FromRow creates Property from given Row.
 func (f *Property) FromRow(r Row) error {
 	{
@@ -1065,6 +2046,21 @@ func (f *Property) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes Property and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *Property) AppendTo(w *Writer) Index {
+	return w.AddRow(md.Property, md.RowValues{
+		uint64(f.Flags),
+		uint64(w.String(f.Name)),
+		uint64(w.Blob(Blob(f.Type))),
+	})
+}
+
+// TableType returns type of PropertyMap table.
+func (*PropertyMap) TableType() md.TableType {
+	return md.PropertyMap
+}
+
 // FromRow creates PropertyMap from given Row.
 func (f *PropertyMap) FromRow(r Row) error {
 	{
@@ -1084,6 +2080,15 @@ func (f *PropertyMap) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes PropertyMap and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *PropertyMap) AppendTo(w *Writer) Index {
+	return w.AddRow(md.PropertyMap, md.RowValues{
+		uint64(f.Parent),
+		uint64(f.PropertyList.Start()) + 1,
+	})
+}
+
 // ResolveParent resolves Parent index using given Context.
 func (f *PropertyMap) ResolveParent(c *Context) (TypeDef, error) {
 	table := c.Table(md.TypeDef)
@@ -1106,7 +2111,11 @@ func (f *PropertyMap) ResolvePropertyList(c *Context) ([]Property, error) {
 		result = make([]Property, 0, f.PropertyList.Size())
 	)
 	for i := f.PropertyList.Start(); i < f.PropertyList.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.Property, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
@@ -1115,6 +2124,64 @@ func (f *PropertyMap) ResolvePropertyList(c *Context) ([]Property, error) {
 	return result, nil
 }
 
+// TableType returns type of StateMachineMethod table.
+func (*StateMachineMethod) TableType() md.TableType {
+	return md.StateMachineMethod
+}
+
+// FromRow creates StateMachineMethod from given Row.
+func (f *StateMachineMethod) FromRow(r Row) error {
+	{
+		v, err := r.Uint64(0)
+		if err != nil {
+			return fmt.Errorf("decode field MoveNextMethod: %w", err)
+		}
+		f.MoveNextMethod = Index(v)
+	}
+	{
+		v, err := r.Uint64(1)
+		if err != nil {
+			return fmt.Errorf("decode field KickoffMethod: %w", err)
+		}
+		f.KickoffMethod = Index(v)
+	}
+	return nil
+}
+
+// AppendTo encodes StateMachineMethod and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *StateMachineMethod) AppendTo(w *Writer) Index {
+	return w.AddRow(md.StateMachineMethod, md.RowValues{
+		uint64(f.MoveNextMethod),
+		uint64(f.KickoffMethod),
+	})
+}
+
+// ResolveMoveNextMethod resolves MoveNextMethod index using given Context.
+func (f *StateMachineMethod) ResolveMoveNextMethod(c *Context) (MethodDef, error) {
+	table := c.Table(md.MethodDef)
+	var t MethodDef
+	if err := t.FromRow(table.Row(uint32(f.MoveNextMethod) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// ResolveKickoffMethod resolves KickoffMethod index using given Context.
+func (f *StateMachineMethod) ResolveKickoffMethod(c *Context) (MethodDef, error) {
+	table := c.Table(md.MethodDef)
+	var t MethodDef
+	if err := t.FromRow(table.Row(uint32(f.KickoffMethod) - 1)); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// TableType returns type of TypeDef table.
+func (*TypeDef) TableType() md.TableType {
+	return md.TypeDef
+}
+
 // FromRow creates TypeDef from given Row.
 func (f *TypeDef) FromRow(r Row) error {
 	{
@@ -1162,6 +2229,19 @@ func (f *TypeDef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes TypeDef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *TypeDef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.TypeDef, md.RowValues{
+		uint64(f.Flags),
+		uint64(w.String(f.TypeName)),
+		uint64(w.String(f.TypeNamespace)),
+		uint64(f.Extends),
+		uint64(f.FieldList.Start()) + 1,
+		uint64(f.MethodList.Start()) + 1,
+	})
+}
+
 // ResolveFieldList resolves FieldList index using given Context.
 func (f *TypeDef) ResolveFieldList(c *Context) ([]Field, error) {
 	table := c.Table(md.Field)
@@ -1174,7 +2254,11 @@ func (f *TypeDef) ResolveFieldList(c *Context) ([]Field, error) {
 		result = make([]Field, 0, f.FieldList.Size())
 	)
 	for i := f.FieldList.Start(); i < f.FieldList.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.Field, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
@@ -1195,7 +2279,11 @@ func (f *TypeDef) ResolveMethodList(c *Context) ([]MethodDef, error) {
 		result = make([]MethodDef, 0, f.MethodList.Size())
 	)
 	for i := f.MethodList.Start(); i < f.MethodList.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.MethodDef, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
@@ -1204,6 +2292,11 @@ func (f *TypeDef) ResolveMethodList(c *Context) ([]MethodDef, error) {
 	return result, nil
 }
 
+// TableType returns type of TypeRef table.
+func (*TypeRef) TableType() md.TableType {
+	return md.TypeRef
+}
+
 // FromRow creates TypeRef from given Row.
 func (f *TypeRef) FromRow(r Row) error {
 	{
@@ -1230,6 +2323,21 @@ func (f *TypeRef) FromRow(r Row) error {
 	return nil
 }
 
+// AppendTo encodes TypeRef and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *TypeRef) AppendTo(w *Writer) Index {
+	return w.AddRow(md.TypeRef, md.RowValues{
+		uint64(f.ResolutionScope),
+		uint64(w.String(f.TypeName)),
+		uint64(w.String(f.TypeNamespace)),
+	})
+}
+
+// TableType returns type of TypeSpec table.
+func (*TypeSpec) TableType() md.TableType {
+	return md.TypeSpec
+}
+
 // FromRow creates TypeSpec from given Row.
 func (f *TypeSpec) FromRow(r Row) error {
 	{
@@ -1241,3 +2349,11 @@ func (f *TypeSpec) FromRow(r Row) error {
 	}
 	return nil
 }
+
+// AppendTo encodes TypeSpec and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *TypeSpec) AppendTo(w *Writer) Index {
+	return w.AddRow(md.TypeSpec, md.RowValues{
+		uint64(w.Blob(Blob(f.Signature))),
+	})
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func FuzzSignatureReader_Method(f *testing.F) {
+	for _, sig := range []Signature{
+		{0x00, 0x01, 0x09, 0x11, 0x83, 0xdd},
+		{0x00, 0x02, 0x01, 0x0f, 0x11, 0x80, 0xb5, 0x1d, 0x08},
+		{0x10, 0x01, 0x01, 0x1e, 0x00},
+		{0x05, 0x02, 0x01, 0x08, 0x41, 0x0e},
+		{0x00, 0x01, 0x01, 0x14, 0x08, 0x02, 0x02, 0x03, 0x04, 0x01, 0x7f},
+		{0x00, 0x01, 0x01, 0x1b, 0x00, 0x00, 0x01, 0x08},
+		{0x00, 0x01, 0x01, 0x15, 0x12, 0x05, 0x02, 0x08, 0x13, 0x00},
+		// Deeply nested SZARRAY.
+		append(Signature{0x00, 0x01, 0x01}, bytes.Repeat([]byte{0x1d}, 4096)...),
+	} {
+		f.Add([]byte(sig))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := Signature(data).Reader().Method(nil)
+		if err != nil {
+			if !errors.Is(err, ErrBadSignature) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+
+		// Decoded signature must be encodable and decodable again.
+		encoded, err := m.Encode()
+		if err != nil {
+			return
+		}
+		if _, err := encoded.Reader().Method(nil); err != nil {
+			t.Fatalf("decode encoded %x: %v", []byte(encoded), err)
+		}
+	})
+}
+
+func FuzzSignatureReader_Decode(f *testing.F) {
+	for _, sig := range []Signature{
+		{0x00, 0x01, 0x09, 0x11, 0x83, 0xdd},
+		{0x06, 0x1d, 0x13, 0x01},
+		{0x28, 0x01, 0x13, 0x00, 0x08},
+		{0x07, 0x02, 0x08, 0x45, 0x10, 0x05},
+		{0x0a, 0x02, 0x0e, 0x15, 0x12, 0x09, 0x01, 0x1e, 0x00},
+	} {
+		f.Add([]byte(sig))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := Signature(data).Reader().Decode(nil)
+		if err != nil {
+			if !errors.Is(err, ErrBadSignature) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+
+		// Decoded signature must be encodable and decodable again.
+		var encoded Signature
+		switch d.Kind {
+		case SignatureKindMethod:
+			encoded, err = d.Method.Encode()
+		case SignatureKindField:
+			encoded, err = d.Field.Encode()
+		case SignatureKindProperty:
+			encoded, err = d.Property.Encode()
+		case SignatureKindLocalVar:
+			encoded, err = d.LocalVar.Encode()
+		case SignatureKindMethodSpec:
+			encoded, err = d.MethodSpec.Encode()
+		}
+		if err != nil {
+			return
+		}
+
+		again, err := encoded.Reader().Decode(nil)
+		if err != nil {
+			t.Fatalf("decode encoded %x: %v", []byte(encoded), err)
+		}
+		if again.Kind != d.Kind {
+			t.Fatalf("kind mismatch: %v != %v", again.Kind, d.Kind)
+		}
+	})
+}
+
+func FuzzSignatureReader_MarshalSpec(f *testing.F) {
+	for _, blob := range []Blob{
+		{0x15},
+		{0x1e, 0x80, 0x80, 0x15},
+		{0x2a, 0x15, 0x02, 0x00, 0x01},
+		{0x1d, 0x08, 0x03, 'I', 'F', 'o'},
+		{0x2c, 0x00, 0x00, 0x01, 'M', 0x00},
+	} {
+		f.Add([]byte(blob))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := Signature(data).Reader().MarshalSpec()
+		if err != nil {
+			if !errors.Is(err, ErrBadSignature) {
+				t.Fatalf("unexpected error type: %v", err)
+			}
+			return
+		}
+
+		// Decoded descriptor must be encodable and decodable again.
+		encoded, err := m.Encode()
+		if err != nil {
+			return
+		}
+		if _, err := Signature(encoded).Reader().MarshalSpec(); err != nil {
+			t.Fatalf("decode encoded %x: %v", []byte(encoded), err)
+		}
+	})
+}
@@ -0,0 +1,9 @@
+package types
+
+// ImportScope is a portable PDB ImportScope representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type ImportScope struct {
+	Parent  Index `table:"ImportScope"`
+	Imports Blob
+}
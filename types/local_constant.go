@@ -0,0 +1,9 @@
+package types
+
+// LocalConstant is a portable PDB LocalConstant representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type LocalConstant struct {
+	Name      string
+	Signature Signature
+}
@@ -0,0 +1,13 @@
+package types
+
+// LocalScope is a portable PDB LocalScope representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type LocalScope struct {
+	Method       Index `table:"MethodDef"`
+	ImportScope  Index `table:"ImportScope"`
+	VariableList List  `table:"LocalVariable"`
+	ConstantList List  `table:"LocalConstant"`
+	StartOffset  uint32
+	Length       uint32
+}
@@ -0,0 +1,10 @@
+package types
+
+// LocalVariable is a portable PDB LocalVariable representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type LocalVariable struct {
+	Attributes uint16
+	Index      uint16
+	Name       string
+}
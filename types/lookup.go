@@ -0,0 +1,340 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// IsSorted denotes that given table is sorted, according to TablesHeader.Sorted bitmask.
+func (t *Context) IsSorted(tt md.TableType) bool {
+	return t.Sorted>>uint(tt)&1 == 1
+}
+
+// lookup returns indexes of rows which have given key in given column.
+//
+// Uses binary search if table is sorted, otherwise scans the whole table.
+func (t *Context) lookup(tt md.TableType, column, key uint32) ([]uint32, error) {
+	count := int(t.RowCount(tt))
+
+	if !t.IsSorted(tt) {
+		var rows []uint32
+		for i := uint32(0); i < uint32(count); i++ {
+			v, err := t.Uint32(tt, i, column)
+			if err != nil {
+				return nil, err
+			}
+			if v == key {
+				rows = append(rows, i)
+			}
+		}
+		return rows, nil
+	}
+
+	var searchErr error
+	search := func(f func(v uint32) bool) int {
+		return sort.Search(count, func(i int) bool {
+			if searchErr != nil {
+				return true
+			}
+			v, err := t.Uint32(tt, uint32(i), column)
+			if err != nil {
+				searchErr = err
+				return true
+			}
+			return f(v)
+		})
+	}
+
+	start := search(func(v uint32) bool { return v >= key })
+	end := search(func(v uint32) bool { return v > key })
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	if start >= end {
+		return nil, nil
+	}
+	rows := make([]uint32, 0, end-start)
+	for i := start; i < end; i++ {
+		rows = append(rows, uint32(i))
+	}
+	return rows, nil
+}
+
+// lookupOne returns index of first row which has given key in given column.
+func (t *Context) lookupOne(tt md.TableType, column, key uint32) (uint32, bool, error) {
+	rows, err := t.lookup(tt, column, key)
+	if err != nil || len(rows) < 1 {
+		return 0, false, err
+	}
+	return rows[0], true, nil
+}
+
+// CustomAttributes returns custom attributes attached to given parent.
+func (t *Context) CustomAttributes(parent HasCustomAttribute) ([]CustomAttribute, error) {
+	rows, err := t.lookup(md.CustomAttribute, 0, uint32(parent))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		table  = t.Table(md.CustomAttribute)
+		result = make([]CustomAttribute, len(rows))
+	)
+	for i, row := range rows {
+		if err := result[i].FromRow(table.Row(row)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// NamedAttribute is a custom attribute with resolved attribute type name.
+type NamedAttribute struct {
+	// Namespace and Name of attribute type.
+	Namespace string
+	Name      string
+	Row       CustomAttribute
+}
+
+// NamedAttributes returns custom attributes attached to given parent and resolves their type names.
+func (t *Context) NamedAttributes(parent HasCustomAttribute) ([]NamedAttribute, error) {
+	rows, err := t.CustomAttributes(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NamedAttribute, 0, len(rows))
+	for _, row := range rows {
+		namespace, name, _, err := row.ResolveConstructor(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, NamedAttribute{
+			Namespace: namespace,
+			Name:      name,
+			Row:       row,
+		})
+	}
+	return result, nil
+}
+
+// Constant returns constant value of given parent.
+// If parent has no constant, ok is false.
+func (t *Context) Constant(parent HasConstant) (c Constant, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.Constant, 1, uint32(parent))
+	if err != nil || !ok {
+		return c, false, err
+	}
+
+	if err := c.FromRow(t.Table(md.Constant).Row(row)); err != nil {
+		return c, false, err
+	}
+	return c, true, nil
+}
+
+// FieldMarshal returns marshalling information of given parent.
+// If parent has no marshalling information, ok is false.
+func (t *Context) FieldMarshal(parent HasFieldMarshall) (m FieldMarshal, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.FieldMarshal, 0, uint32(parent))
+	if err != nil || !ok {
+		return m, false, err
+	}
+
+	if err := m.FromRow(t.Table(md.FieldMarshal).Row(row)); err != nil {
+		return m, false, err
+	}
+	return m, true, nil
+}
+
+// ClassLayout returns layout of given TypeDef.
+// If TypeDef has no explicit layout, ok is false.
+func (t *Context) ClassLayout(typeDef Index) (l ClassLayout, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.ClassLayout, 2, typeDef+1)
+	if err != nil || !ok {
+		return l, false, err
+	}
+
+	if err := l.FromRow(t.Table(md.ClassLayout).Row(row)); err != nil {
+		return l, false, err
+	}
+	return l, true, nil
+}
+
+// FieldLayout returns layout of given Field.
+// If Field has no explicit offset, ok is false.
+func (t *Context) FieldLayout(field Index) (l FieldLayout, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.FieldLayout, 1, field+1)
+	if err != nil || !ok {
+		return l, false, err
+	}
+
+	if err := l.FromRow(t.Table(md.FieldLayout).Row(row)); err != nil {
+		return l, false, err
+	}
+	return l, true, nil
+}
+
+// ImplMap returns P/Invoke information of given member.
+// If member is not imported, ok is false.
+func (t *Context) ImplMap(member MemberForwarded) (m ImplMap, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.ImplMap, 1, uint32(member))
+	if err != nil || !ok {
+		return m, false, err
+	}
+
+	if err := m.FromRow(t.Table(md.ImplMap).Row(row)); err != nil {
+		return m, false, err
+	}
+	return m, true, nil
+}
+
+// EnclosingClass returns index of TypeDef which encloses given nested TypeDef.
+// If TypeDef is not nested, ok is false.
+func (t *Context) EnclosingClass(nested Index) (enclosing Index, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.NestedClass, 0, nested+1)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+
+	v, err := t.Uint32(md.NestedClass, row, 1)
+	if err != nil {
+		return 0, false, err
+	}
+	return v - 1, true, nil
+}
+
+func (t *Context) buildNestedIndex() (map[Index][]Index, error) {
+	table := t.Table(md.NestedClass)
+
+	var (
+		class  NestedClass
+		result = map[Index][]Index{}
+	)
+	for i := uint32(0); i < table.RowCount(); i++ {
+		if err := class.FromRow(table.Row(i)); err != nil {
+			return nil, err
+		}
+		result[class.EnclosingClass-1] = append(result[class.EnclosingClass-1], class.NestedClass-1)
+	}
+	return result, nil
+}
+
+// NestedClasses returns indexes of TypeDefs nested into given TypeDef.
+//
+// NestedClass table is sorted by nested class, so reverse index is built on first call.
+func (t *Context) NestedClasses(enclosing Index) ([]Index, error) {
+	t.nestedOnce.Do(func() {
+		t.nested, t.nestedErr = t.buildNestedIndex()
+	})
+	if t.nestedErr != nil {
+		return nil, t.nestedErr
+	}
+	return t.nested[enclosing], nil
+}
+
+// InterfaceImpls returns indexes of InterfaceImpl rows of given TypeDef.
+//
+// Row index is needed to find attributes of implementation, like DefaultAttribute.
+func (t *Context) InterfaceImpls(class Index) ([]Index, error) {
+	return t.lookup(md.InterfaceImpl, 0, class+1)
+}
+
+// GenericParams returns generic parameters of given owner, ordered by number.
+func (t *Context) GenericParams(owner TypeOrMethodDef) ([]GenericParam, error) {
+	rows, err := t.lookup(md.GenericParam, 2, uint32(owner))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		table  = t.Table(md.GenericParam)
+		result = make([]GenericParam, len(rows))
+	)
+	for i, row := range rows {
+		if err := result[i].FromRow(table.Row(row)); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Number < result[j].Number
+	})
+	return result, nil
+}
+
+// PropertyMap returns property map of given TypeDef.
+// If TypeDef has no properties, ok is false.
+func (t *Context) PropertyMap(typeDef Index) (m PropertyMap, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.PropertyMap, 0, typeDef+1)
+	if err != nil || !ok {
+		return m, false, err
+	}
+
+	if err := m.FromRow(t.Table(md.PropertyMap).Row(row)); err != nil {
+		return m, false, err
+	}
+	return m, true, nil
+}
+
+// EventMap returns event map of given TypeDef.
+// If TypeDef has no events, ok is false.
+func (t *Context) EventMap(typeDef Index) (m EventMap, ok bool, _ error) {
+	row, ok, err := t.lookupOne(md.EventMap, 0, typeDef+1)
+	if err != nil || !ok {
+		return m, false, err
+	}
+
+	if err := m.FromRow(t.Table(md.EventMap).Row(row)); err != nil {
+		return m, false, err
+	}
+	return m, true, nil
+}
+
+func (t *Context) buildSemanticsIndex() (map[Index][]MethodSemantics, error) {
+	table := t.Table(md.MethodSemantics)
+
+	var (
+		s      MethodSemantics
+		result = map[Index][]MethodSemantics{}
+	)
+	for i := uint32(0); i < table.RowCount(); i++ {
+		if err := s.FromRow(table.Row(i)); err != nil {
+			return nil, err
+		}
+		result[s.Method-1] = append(result[s.Method-1], s)
+	}
+	return result, nil
+}
+
+// MethodSemantics returns semantics of given MethodDef.
+//
+// MethodSemantics table is sorted by association, so reverse index is built on first call.
+func (t *Context) MethodSemantics(method Index) ([]MethodSemantics, error) {
+	t.semanticsOnce.Do(func() {
+		t.semantics, t.semanticsErr = t.buildSemanticsIndex()
+	})
+	if t.semanticsErr != nil {
+		return nil, t.semanticsErr
+	}
+	return t.semantics[method], nil
+}
+
+// Semantics returns methods associated with given Property or Event.
+func (t *Context) Semantics(association HasSemantics) ([]MethodSemantics, error) {
+	rows, err := t.lookup(md.MethodSemantics, 2, uint32(association))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		table  = t.Table(md.MethodSemantics)
+		result = make([]MethodSemantics, len(rows))
+	)
+	for i, row := range rows {
+		if err := result[i].FromRow(table.Row(row)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,262 @@
+package types
+
+import "fmt"
+
+// MarshalSpec is a decoded II.23.4 MarshallingDescriptor.
+//
+// Optional values are unset when they are negative or NATIVE_TYPE_MAX, so zero
+// value denotes zero sizes and indexes. Use NewMarshalSpec to create MarshalSpec
+// with all optional values unset.
+type MarshalSpec struct {
+	Kind NativeTypeKind
+	// Elem is a type of array elements, NATIVE_TYPE_MAX if not specified.
+	Elem NativeTypeKind
+	// SizeParamIndex is an index of parameter which contains length of NATIVE_TYPE_ARRAY,
+	// -1 if not set.
+	SizeParamIndex int32
+	// SizeConst is a constant length of array or string, -1 if not set.
+	//
+	// For NATIVE_TYPE_ARRAY with SizeParamIndex, length is a sum of parameter value and SizeConst.
+	SizeConst int32
+	// IIDParamIndex is an index of parameter which contains IID of interface, -1 if not set.
+	IIDParamIndex int32
+	// SafeArrayType is a VARTYPE of NATIVE_TYPE_SAFEARRAY elements, -1 if not set.
+	SafeArrayType int32
+	// SafeArrayUserType is a name of user-defined SAFEARRAY element type.
+	SafeArrayUserType string
+	// CustomMarshaler contains strings of NATIVE_TYPE_CUSTOMMARSHALER.
+	CustomMarshaler CustomMarshaler
+}
+
+// NewMarshalSpec creates MarshalSpec of given kind with all optional values unset.
+func NewMarshalSpec(kind NativeTypeKind) MarshalSpec {
+	return MarshalSpec{
+		Kind:           kind,
+		Elem:           NATIVE_TYPE_MAX,
+		SizeParamIndex: -1,
+		SizeConst:      -1,
+		IIDParamIndex:  -1,
+		SafeArrayType:  -1,
+	}
+}
+
+// CustomMarshaler is a NATIVE_TYPE_CUSTOMMARSHALER description.
+type CustomMarshaler struct {
+	GUID           string
+	NativeTypeName string
+	// Marshaler is a name of marshaler type.
+	Marshaler string
+	Cookie    string
+}
+
+// arraySizeParamSpecified is a NATIVE_TYPE_ARRAY flag, which denotes that
+// SizeParamIndex is specified.
+const arraySizeParamSpecified = 0x1
+
+// MarshalSpec decodes NativeType blob.
+func (f *FieldMarshal) MarshalSpec() (MarshalSpec, error) {
+	return Signature(f.NativeType).Reader().MarshalSpec()
+}
+
+// readString reads length prefixed UTF-8 string.
+func (s *SignatureReader) readString() (string, bool) {
+	n, ok := s.Read()
+	if !ok || uint32(s.Len()) < n {
+		return "", false
+	}
+
+	str := string(s.sig[s.offset : s.offset+int(n)])
+	s.offset += int(n)
+	return str, true
+}
+
+// optional reads optional compressed integer, returns -1 if there is no data anymore.
+func (s *SignatureReader) optional() (int32, error) {
+	if s.Len() == 0 {
+		return -1, nil
+	}
+	v, ok := s.Read()
+	if !ok {
+		return 0, errSignatureTruncated
+	}
+	return int32(v), nil
+}
+
+// optionalKind reads optional array element native type, returns NATIVE_TYPE_MAX if there is no data anymore.
+func (s *SignatureReader) optionalKind() (NativeTypeKind, error) {
+	elem, err := s.optional()
+	switch {
+	case err != nil:
+		return NATIVE_TYPE_MAX, err
+	case elem < 0:
+		return NATIVE_TYPE_MAX, nil
+	case elem > 0xff:
+		return NATIVE_TYPE_MAX, fmt.Errorf("%w: element native type %#x is out of range", ErrBadSignature, elem)
+	}
+	return NativeTypeKind(elem), nil
+}
+
+// MarshalSpec reads II.23.4 MarshallingDescriptor.
+func (s *SignatureReader) MarshalSpec() (MarshalSpec, error) {
+	m := NewMarshalSpec(0)
+
+	kind, ok := s.Read()
+	if !ok {
+		return m, errSignatureTruncated
+	}
+	if kind > 0xff {
+		return m, fmt.Errorf("%w: native type %#x is out of range", ErrBadSignature, kind)
+	}
+	m.Kind = NativeTypeKind(kind)
+
+	var err error
+	switch m.Kind {
+	case NATIVE_TYPE_FIXEDSYSSTRING:
+		if m.SizeConst, err = s.optional(); err != nil {
+			return m, err
+		}
+	case NATIVE_TYPE_FIXEDARRAY:
+		if m.SizeConst, err = s.optional(); err != nil {
+			return m, err
+		}
+		if m.Elem, err = s.optionalKind(); err != nil {
+			return m, err
+		}
+	case NATIVE_TYPE_ARRAY:
+		if m.Elem, err = s.optionalKind(); err != nil {
+			return m, err
+		}
+		if m.SizeParamIndex, err = s.optional(); err != nil {
+			return m, err
+		}
+		if m.SizeConst, err = s.optional(); err != nil {
+			return m, err
+		}
+		flags, err := s.optional()
+		if err != nil {
+			return m, err
+		}
+		if flags >= 0 && flags&arraySizeParamSpecified == 0 {
+			m.SizeParamIndex = -1
+		}
+	case NATIVE_TYPE_SAFEARRAY:
+		if m.SafeArrayType, err = s.optional(); err != nil {
+			return m, err
+		}
+		if s.Len() > 0 {
+			if m.SafeArrayUserType, ok = s.readString(); !ok {
+				return m, errSignatureTruncated
+			}
+		}
+	case NATIVE_TYPE_INTF, NATIVE_TYPE_IUNKNOWN, NATIVE_TYPE_IDISPATCH, NATIVE_TYPE_IINSPECTABLE:
+		if m.IIDParamIndex, err = s.optional(); err != nil {
+			return m, err
+		}
+	case NATIVE_TYPE_CUSTOMMARSHALER:
+		c := &m.CustomMarshaler
+		for _, str := range []*string{&c.GUID, &c.NativeTypeName, &c.Marshaler, &c.Cookie} {
+			if *str, ok = s.readString(); !ok {
+				return m, errSignatureTruncated
+			}
+		}
+	}
+
+	if s.Len() > 0 {
+		return m, fmt.Errorf("%w: %d trailing bytes after %v", ErrBadSignature, s.Len(), m.Kind)
+	}
+	return m, nil
+}
+
+// writeString writes length prefixed UTF-8 string.
+func (s *SignatureWriter) writeString(str string) error {
+	if err := s.Write(uint32(len(str))); err != nil {
+		return err
+	}
+	s.sig = append(s.sig, str...)
+	return nil
+}
+
+// MarshalSpec writes II.23.4 MarshallingDescriptor.
+//
+// Optional values are positional, so value can't be set if previous one is
+// unset, e.g. NATIVE_TYPE_FIXEDARRAY Elem requires SizeConst.
+func (s *SignatureWriter) MarshalSpec(m MarshalSpec) error {
+	if err := s.Write(uint32(m.Kind)); err != nil {
+		return err
+	}
+
+	// writeOptional writes given values until first unset one.
+	writeOptional := func(values ...int32) error {
+		for i, v := range values {
+			if v < 0 {
+				for j, next := range values[i+1:] {
+					if next >= 0 {
+						return fmt.Errorf("%v: optional value %d is set, but value %d is not", m.Kind, i+1+j, i)
+					}
+				}
+				return nil
+			}
+			if err := s.Write(uint32(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch m.Kind {
+	case NATIVE_TYPE_FIXEDSYSSTRING:
+		return writeOptional(m.SizeConst)
+	case NATIVE_TYPE_FIXEDARRAY:
+		elem := int32(-1)
+		if m.Elem != NATIVE_TYPE_MAX {
+			elem = int32(m.Elem)
+		}
+		return writeOptional(m.SizeConst, elem)
+	case NATIVE_TYPE_ARRAY:
+		if m.SizeParamIndex < 0 && m.SizeConst < 0 {
+			if m.Elem == NATIVE_TYPE_MAX {
+				return nil
+			}
+			return s.Write(uint32(m.Elem))
+		}
+
+		values := []int32{int32(m.Elem), m.SizeParamIndex}
+		switch {
+		case m.SizeParamIndex < 0:
+			values = []int32{int32(m.Elem), 0, m.SizeConst, 0}
+		case m.SizeConst >= 0:
+			values = append(values, m.SizeConst, arraySizeParamSpecified)
+		}
+		return writeOptional(values...)
+	case NATIVE_TYPE_SAFEARRAY:
+		if err := writeOptional(m.SafeArrayType); err != nil {
+			return err
+		}
+		if m.SafeArrayUserType == "" {
+			return nil
+		}
+		if m.SafeArrayType < 0 {
+			return fmt.Errorf("SafeArrayUserType requires SafeArrayType")
+		}
+		return s.writeString(m.SafeArrayUserType)
+	case NATIVE_TYPE_INTF, NATIVE_TYPE_IUNKNOWN, NATIVE_TYPE_IDISPATCH, NATIVE_TYPE_IINSPECTABLE:
+		return writeOptional(m.IIDParamIndex)
+	case NATIVE_TYPE_CUSTOMMARSHALER:
+		c := m.CustomMarshaler
+		for _, str := range []string{c.GUID, c.NativeTypeName, c.Marshaler, c.Cookie} {
+			if err := s.writeString(str); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Encode encodes MarshalSpec to NativeType blob.
+func (m MarshalSpec) Encode() (Blob, error) {
+	var w SignatureWriter
+	if err := w.MarshalSpec(m); err != nil {
+		return nil, err
+	}
+	return Blob(w.Signature()), nil
+}
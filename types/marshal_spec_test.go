@@ -0,0 +1,141 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestSignatureReader_MarshalSpec(t *testing.T) {
+	lpArray := func(elem NativeTypeKind, param, size int32) MarshalSpec {
+		m := NewMarshalSpec(NATIVE_TYPE_ARRAY)
+		m.Elem, m.SizeParamIndex, m.SizeConst = elem, param, size
+		return m
+	}
+	tests := []struct {
+		name   string
+		blob   Blob
+		expect func(m *MarshalSpec)
+	}{
+		{"LPWStr", Blob{0x15}, func(m *MarshalSpec) { m.Kind = NATIVE_TYPE_LPWSTR }},
+		{"LPStr", Blob{0x14}, func(m *MarshalSpec) { m.Kind = NATIVE_TYPE_LPSTR }},
+		{"ByValTStr", Blob{0x17, 0x20}, func(m *MarshalSpec) {
+			m.Kind = NATIVE_TYPE_FIXEDSYSSTRING
+			m.SizeConst = 32
+		}},
+		{"ByValArray", Blob{0x1e, 0x80, 0x80, 0x04}, func(m *MarshalSpec) {
+			m.Kind = NATIVE_TYPE_FIXEDARRAY
+			m.SizeConst = 128
+			m.Elem = NATIVE_TYPE_U1
+		}},
+		{"LPArray", Blob{0x2a, 0x15}, func(m *MarshalSpec) { *m = lpArray(NATIVE_TYPE_LPWSTR, -1, -1) }},
+		{"LPArraySizeParam", Blob{0x2a, 0x07, 0x02}, func(m *MarshalSpec) { *m = lpArray(NATIVE_TYPE_I4, 2, -1) }},
+		{"LPArraySizeConst", Blob{0x2a, 0x50, 0x00, 0x10, 0x00}, func(m *MarshalSpec) {
+			*m = lpArray(NATIVE_TYPE_MAX, -1, 16)
+		}},
+		{"LPArrayBoth", Blob{0x2a, 0x07, 0x01, 0x04, 0x01}, func(m *MarshalSpec) { *m = lpArray(NATIVE_TYPE_I4, 1, 4) }},
+		{"Interface", Blob{0x1c, 0x03}, func(m *MarshalSpec) {
+			m.Kind = NATIVE_TYPE_INTF
+			m.IIDParamIndex = 3
+		}},
+		{"IUnknown", Blob{0x19}, func(m *MarshalSpec) { m.Kind = NATIVE_TYPE_IUNKNOWN }},
+		{"SafeArray", Blob{0x1d, 0x0d, 0x04, 'I', 'F', 'o', 'o'}, func(m *MarshalSpec) {
+			m.Kind = NATIVE_TYPE_SAFEARRAY
+			m.SafeArrayType = 13 // VT_UNKNOWN
+			m.SafeArrayUserType = "IFoo"
+		}},
+		{"CustomMarshaler", Blob{0x2c, 0x00, 0x00, 0x03, 'F', 'o', 'o', 0x01, 'c'}, func(m *MarshalSpec) {
+			m.Kind = NATIVE_TYPE_CUSTOMMARSHALER
+			m.CustomMarshaler = CustomMarshaler{Marshaler: "Foo", Cookie: "c"}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := require.New(t)
+
+			expect := NewMarshalSpec(0)
+			tt.expect(&expect)
+
+			m, err := Signature(tt.blob).Reader().MarshalSpec()
+			a.NoError(err)
+			a.Equal(expect, m)
+
+			encoded, err := m.Encode()
+			a.NoError(err)
+			a.Equal(tt.blob, encoded)
+		})
+	}
+}
+
+func TestSignatureReader_MarshalSpec_Error(t *testing.T) {
+	for _, blob := range []Blob{
+		nil,
+		{0x2a, 0x80},
+		{0x2c, 0x05, 'a'},
+		{0x15, 0x01},
+		// Native types do not fit into byte.
+		{0x81, 0x00},
+		{0x1e, 0x01, 0x81, 0x00},
+		{0x2a, 0x81, 0x00},
+	} {
+		_, err := Signature(blob).Reader().MarshalSpec()
+		require.True(t, errors.Is(err, ErrBadSignature), "%x: %v", []byte(blob), err)
+	}
+}
+
+func TestSignatureWriter_MarshalSpec(t *testing.T) {
+	a := require.New(t)
+
+	// Zero value encodes zero sizes.
+	encoded, err := MarshalSpec{Kind: NATIVE_TYPE_FIXEDARRAY}.Encode()
+	a.NoError(err)
+	a.Equal(Blob{0x1e, 0x00, 0x00}, encoded)
+
+	encoded, err = NewMarshalSpec(NATIVE_TYPE_FIXEDARRAY).Encode()
+	a.NoError(err)
+	a.Equal(Blob{0x1e}, encoded)
+
+	// Elem can't be written without SizeConst.
+	m := NewMarshalSpec(NATIVE_TYPE_FIXEDARRAY)
+	m.Elem = NATIVE_TYPE_U1
+	_, err = m.Encode()
+	a.Error(err)
+
+	m = NewMarshalSpec(NATIVE_TYPE_SAFEARRAY)
+	m.SafeArrayUserType = "IFoo"
+	_, err = m.Encode()
+	a.Error(err)
+}
+
+func TestContext_FieldMarshal(t *testing.T) {
+	a := require.New(t)
+
+	w := NewWriter()
+	(&Module{Name: "Test.winmd"}).AppendTo(w)
+	(&TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	(&TypeDef{Flags: 0x109, TypeName: "Foo", TypeNamespace: "Test"}).AppendTo(w)
+	name := (&Field{Flags: 0x6, Name: "name", Signature: Signature{0x06, 0x0e}}).AppendTo(w)
+	data := (&Field{Flags: 0x6, Name: "data", Signature: Signature{0x06, 0x1d, 0x05}}).AppendTo(w)
+	// Added out of order to check sorting by Parent.
+	(&FieldMarshal{Parent: CreateHasFieldMarshall(md.Field, data-1), NativeType: Blob{0x1e, 0x08, 0x04}}).AppendTo(w)
+	(&FieldMarshal{Parent: CreateHasFieldMarshall(md.Field, name-1), NativeType: Blob{0x15}}).AppendTo(w)
+
+	c := readPE(a, writePE(a, w))
+
+	m, ok, err := c.FieldMarshal(CreateHasFieldMarshall(md.Field, data-1))
+	a.NoError(err)
+	a.True(ok)
+	a.Equal(CreateHasFieldMarshall(md.Field, data-1), m.Parent)
+	spec, err := m.MarshalSpec()
+	a.NoError(err)
+	a.Equal(NATIVE_TYPE_FIXEDARRAY, spec.Kind)
+	a.Equal(NATIVE_TYPE_U1, spec.Elem)
+	a.Equal(int32(8), spec.SizeConst)
+
+	_, ok, err = c.FieldMarshal(CreateHasFieldMarshall(md.Param, 0))
+	a.NoError(err)
+	a.False(ok)
+}
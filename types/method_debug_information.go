@@ -0,0 +1,141 @@
+package types
+
+import "fmt"
+
+// MethodDebugInformation is a portable PDB MethodDebugInformation representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type MethodDebugInformation struct {
+	Document       Index `table:"Document"`
+	SequencePoints Blob
+}
+
+// SequencePoint is a decoded sequence point record.
+type SequencePoint struct {
+	// Document is a Document row index (1-based).
+	Document    Index
+	Offset      uint32
+	StartLine   uint32
+	StartColumn uint32
+	EndLine     uint32
+	EndColumn   uint32
+	// Hidden denotes that sequence point is hidden.
+	Hidden bool
+}
+
+// SequencePoints is a decoded sequence points blob.
+type SequencePoints struct {
+	// LocalSignature is a StandAloneSig row index (1-based).
+	LocalSignature Index
+	Points         []SequencePoint
+}
+
+// DecodeSequencePoints decodes SequencePoints blob.
+func (f *MethodDebugInformation) DecodeSequencePoints() (result SequencePoints, _ error) {
+	if len(f.SequencePoints) == 0 {
+		return result, nil
+	}
+	r := Signature(f.SequencePoints).Reader()
+
+	localSignature, ok := r.Read()
+	if !ok {
+		return result, fmt.Errorf("read LocalSignature")
+	}
+	result.LocalSignature = localSignature
+
+	document := f.Document
+	if document == 0 {
+		// Initial document is present only if Document column is null.
+		v, ok := r.Read()
+		if !ok {
+			return result, fmt.Errorf("read InitialDocument")
+		}
+		document = v
+	}
+
+	var (
+		offset      uint32
+		startLine   uint32
+		startColumn uint32
+		// First non-hidden sequence point start line and column are
+		// encoded as unsigned integers.
+		seenVisible bool
+	)
+	for i := 0; r.Len() > 0; i++ {
+		deltaOffset, ok := r.Read()
+		if !ok {
+			return result, fmt.Errorf("record #%d: read IL offset", i)
+		}
+		if i > 0 && deltaOffset == 0 {
+			// Document record.
+			v, ok := r.Read()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read Document", i)
+			}
+			document = v
+			continue
+		}
+		offset += deltaOffset
+
+		deltaLines, ok := r.Read()
+		if !ok {
+			return result, fmt.Errorf("record #%d: read ΔLines", i)
+		}
+		var deltaColumns int32
+		if deltaLines == 0 {
+			v, ok := r.Read()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read ΔColumns", i)
+			}
+			deltaColumns = int32(v)
+		} else {
+			v, ok := r.ReadSigned()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read ΔColumns", i)
+			}
+			deltaColumns = v
+		}
+
+		point := SequencePoint{
+			Document: document,
+			Offset:   offset,
+		}
+		if deltaLines == 0 && deltaColumns == 0 {
+			point.Hidden = true
+			result.Points = append(result.Points, point)
+			continue
+		}
+
+		if !seenVisible {
+			line, ok := r.Read()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read StartLine", i)
+			}
+			column, ok := r.Read()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read StartColumn", i)
+			}
+			startLine, startColumn = line, column
+			seenVisible = true
+		} else {
+			line, ok := r.ReadSigned()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read δStartLine", i)
+			}
+			column, ok := r.ReadSigned()
+			if !ok {
+				return result, fmt.Errorf("record #%d: read δStartColumn", i)
+			}
+			startLine = uint32(int32(startLine) + line)
+			startColumn = uint32(int32(startColumn) + column)
+		}
+
+		point.StartLine = startLine
+		point.StartColumn = startColumn
+		point.EndLine = startLine + deltaLines
+		point.EndColumn = uint32(int32(startColumn) + deltaColumns)
+		result.Points = append(result.Points, point)
+	}
+
+	return result, nil
+}
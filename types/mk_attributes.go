@@ -71,6 +71,7 @@ func run() error {
 			Values: []Value{
 				{Name: "PublicKey", Flag: 0x0001, Denotes: "The assembly reference holds the full (unhashed)public key."},
 				{Name: "Retargetable", Flag: 0x0100, Denotes: "The implementation of this assembly used at runtime isnot expected to match the version seen at compile time."},
+				{Name: "WindowsRuntime", Mask: 0x0e00, Flag: 0x0200, Denotes: "The assembly reference refers to Windows Runtime metadata."},
 				{Name: "DisableJITcompileOptimizer", Flag: 0x4000},
 				{Name: "EnableJITcompileTracking", Flag: 0x8000},
 			},
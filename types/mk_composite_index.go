@@ -110,6 +110,11 @@ func (t {{ .Name }}) TableIndex() uint32 {
 	return uint32((t >> {{ .Bits }}) - 1)
 }
 
+// IsNull denotes that {{ .Name }} is a null reference.
+func (t {{ .Name }}) IsNull() bool {
+	return t>>{{ .Bits }} == 0
+}
+
 // String implements fmt.Stringer method.
 func (t {{ .Name }}) String() string {
 	switch t.Tag() {
@@ -174,6 +179,40 @@ func run() error {
 				{"MethodSpec", 21},
 			},
 		},
+		{
+			// See Portable PDB v1.0: Format Specification.
+			Name: "HasCustomDebugInformation",
+			Bits: 5,
+			Tags: []tag{
+				{"MethodDef", 0},
+				{"Field", 1},
+				{"TypeRef", 2},
+				{"TypeDef", 3},
+				{"Param", 4},
+				{"InterfaceImpl", 5},
+				{"MemberRef", 6},
+				{"Module", 7},
+				{"Permission", 8},
+				{"Property", 9},
+				{"Event", 10},
+				{"StandAloneSig", 11},
+				{"ModuleRef", 12},
+				{"TypeSpec", 13},
+				{"Assembly", 14},
+				{"AssemblyRef", 15},
+				{"File", 16},
+				{"ExportedType", 17},
+				{"ManifestResource", 18},
+				{"GenericParam", 19},
+				{"GenericParamConstraint", 20},
+				{"MethodSpec", 21},
+				{"Document", 22},
+				{"LocalScope", 23},
+				{"LocalVariable", 24},
+				{"LocalConstant", 25},
+				{"ImportScope", 26},
+			},
+		},
 		{
 			Name: "HasFieldMarshall",
 			Bits: 1,
@@ -10,6 +10,7 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
+	gotypes "go/types"
 	"io"
 	"os"
 	"os/signal"
@@ -61,6 +62,15 @@ func generateList() map[string]struct{} {
 		"GenericParam":           {},
 		"MethodSpec":             {},
 		"GenericParamConstraint": {},
+		// Portable PDB tables.
+		"Document":               {},
+		"MethodDebugInformation": {},
+		"LocalScope":             {},
+		"LocalVariable":          {},
+		"LocalConstant":          {},
+		"ImportScope":            {},
+		"StateMachineMethod":     {},
+		"CustomDebugInformation": {},
 	}
 }
 
@@ -118,7 +128,8 @@ func collectTargets(pkg *packages.Package) ([]FromRowTarget, error) {
 				}
 
 				target := FromRowTarget{
-					Name: typSpec.Name.Name,
+					Name:  typSpec.Name.Name,
+					Table: tableName(typSpec.Name.Name),
 				}
 				for i, field := range st.Fields.List {
 					if len(field.Names) != 1 {
@@ -139,6 +150,8 @@ func collectTargets(pkg *packages.Package) ([]FromRowTarget, error) {
 						function = "String"
 					case "Blob", "Signature", "List":
 						function = typeName
+					case "md.GUID":
+						function = "GUID"
 					default:
 						function = "Uint64"
 					}
@@ -163,6 +176,7 @@ func collectTargets(pkg *packages.Package) ([]FromRowTarget, error) {
 						TypeName: typeName,
 						Function: function,
 						Index:    index,
+						Coded:    isCompositeIndex(pkg.TypesInfo.TypeOf(field.Type)),
 					})
 				}
 				targets = append(targets, target)
@@ -173,8 +187,33 @@ func collectTargets(pkg *packages.Package) ([]FromRowTarget, error) {
 	return targets, nil
 }
 
+// isCompositeIndex reports whether given type is a composite index type.
+func isCompositeIndex(t gotypes.Type) bool {
+	if t == nil {
+		return false
+	}
+	obj, _, _ := gotypes.LookupFieldOrMethod(t, true, nil, "TableIndex")
+	_, ok := obj.(*gotypes.Func)
+	return ok
+}
+
+// tableName returns md.TableType constant name of given row type.
+func tableName(name string) string {
+	switch name {
+	case "FieldRVA":
+		return "FieldRva"
+	case "AssemblyOS":
+		return "AssemblyOs"
+	case "AssemblyRefOS":
+		return "AssemblyRefOs"
+	default:
+		return name
+	}
+}
+
 type FromRowTarget struct {
 	Name    string
+	Table   string
 	Columns []Column
 }
 
@@ -183,6 +222,7 @@ type Column struct {
 	TypeName string
 	Function string
 	Index    string
+	Coded    bool
 }
 
 type Config struct {
@@ -197,11 +237,50 @@ import (
 	"github.com/tdakkota/win32metadata/md"
 )
 
+// heapColumns contains kinds of heaps referenced by table columns.
+var heapColumns = map[md.TableType][6]heapKind{
+{{- range $target := .Targets }}
+	md.{{ $target.Table }}: {
+	{{- range $column := $target.Columns -}}
+		{{- if eq $column.Function "String" }}stringHeap,
+		{{- else if or (eq $column.Function "Blob") (eq $column.Function "Signature") }}blobHeap,
+		{{- else if eq $column.Function "GUID" }}guidHeap,
+		{{- else }}noHeap,
+		{{- end }}
+	{{- end -}}
+	},
+{{- end }}
+}
+
+// indexColumns contains functions updating references to moved rows
+// stored in table columns.
+var indexColumns = map[md.TableType][6]remapFunc{
+{{- range $target := .Targets }}
+	md.{{ $target.Table }}: {
+	{{- range $column := $target.Columns -}}
+		{{- if $column.Coded }}remapCoded[{{ $column.TypeName }}],
+		{{- else if and $column.Index (ne $column.Function "List") }}remapIndex(md.{{ $column.Index }}),
+		{{- else }}nil,
+		{{- end }}
+	{{- end -}}
+	},
+{{- end }}
+}
+
 {{ range $target := .Targets -}}
+{{ template "table_type" $target }}
 {{ template "from_row" $target }}
+{{ template "append_to" $target }}
 {{ template "resolve" $target }}
 {{ end -}}
 
+{{ define "table_type" -}}
+// TableType returns type of {{ $.Name }} table.
+func (*{{ $.Name }}) TableType() md.TableType {
+	return md.{{ $.Table }}
+}
+{{ end }}
+
 {{ define "from_row" -}}  
 // FromRow creates {{ $.Name }} from given Row.
 func (f *{{ $.Name }}) FromRow(r Row) error {
@@ -222,6 +301,30 @@ func (f *{{ $.Name }}) FromRow(r Row) error {
 }
 {{ end }}
 
+{{ define "append_to" -}}
+// AppendTo encodes {{ $.Name }} and appends it to given Writer.
+// Returns 1-based row number of added row, see Writer.AddRow.
+func (f *{{ $.Name }}) AppendTo(w *Writer) Index {
+	return w.AddRow(md.{{ $.Table }}, md.RowValues{
+	{{- range $i, $column := $.Columns }}
+		{{- if eq $column.Function "String" }}
+		uint64(w.String(f.{{ $column.Name }})),
+		{{- else if eq $column.Function "Blob" }}
+		uint64(w.Blob(f.{{ $column.Name }})),
+		{{- else if eq $column.Function "Signature" }}
+		uint64(w.Blob(Blob(f.{{ $column.Name }}))),
+		{{- else if eq $column.Function "GUID" }}
+		uint64(w.GUID(f.{{ $column.Name }})),
+		{{- else if eq $column.Function "List" }}
+		uint64(f.{{ $column.Name }}.Start()) + 1,
+		{{- else }}
+		uint64(f.{{ $column.Name }}),
+		{{- end }}
+	{{- end }}
+	})
+}
+{{ end }}
+
 {{ define "resolve_result" -}}
 {{ if eq .Function "List" }}[]{{ end }}{{ .Index }}
 {{- end }}
@@ -243,7 +346,11 @@ func (f *{{ $.Name }}) Resolve{{ $column.Name }}(c *Context) ({{ template "resol
 		result = make([]{{ $column.Index }}, 0, f.{{ $column.Name }}.Size())
 	)
 	for i := f.{{ $column.Name }}.Start(); i < f.{{ $column.Name }}.End(); i++ {
-		if err := t.FromRow(table.Row(i)); err != nil {
+		row, err := c.ListIndex(md.{{ $column.Index }}, i)
+		if err != nil {
+			return result, err
+		}
+		if err := t.FromRow(table.Row(row)); err != nil {
 			return result, err
 		}
 		result = append(result, t)
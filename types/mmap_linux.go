@@ -0,0 +1,43 @@
+//go:build linux
+
+package types
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+type mmapCloser []byte
+
+func (m mmapCloser) Close() error {
+	return syscall.Munmap(m)
+}
+
+// mmapFile maps given file into memory.
+func mmapFile(path string) ([]byte, io.Closer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Mapping is valid after file is closed.
+	defer func() {
+		_ = f.Close()
+	}()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := stat.Size()
+	if size <= 0 || int64(int(size)) != size {
+		return nil, nil, fmt.Errorf("invalid file size %d", size)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mmap: %w", err)
+	}
+	return data, mmapCloser(data), nil
+}
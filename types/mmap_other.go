@@ -0,0 +1,17 @@
+//go:build !linux
+
+package types
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads whole file into memory.
+func mmapFile(path string) ([]byte, io.Closer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, io.NopCloser(nil), nil
+}
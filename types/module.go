@@ -1,10 +1,12 @@
 package types
 
+import "github.com/tdakkota/win32metadata/md"
+
 // Module is a II.22.30 Module representation.
 type Module struct {
 	Generation uint16
 	Name       string
-	Mvid       GUID
-	EncId      GUID
-	EncBaseId  GUID
+	Mvid       md.GUID
+	EncId      md.GUID
+	EncBaseId  md.GUID
 }
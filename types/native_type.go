@@ -0,0 +1,89 @@
+package types
+
+// NativeTypeKind is a II.23.4 Marshalling descriptor native type representation.
+//
+// Besides ECMA-335 intrinsics, it contains native types defined by CLR.
+type NativeTypeKind uint8
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=NativeTypeKind
+
+const (
+	// NATIVE_TYPE_BOOLEAN constant.
+	NATIVE_TYPE_BOOLEAN NativeTypeKind = 0x02
+	// NATIVE_TYPE_I1 constant.
+	NATIVE_TYPE_I1 NativeTypeKind = 0x03
+	// NATIVE_TYPE_U1 constant.
+	NATIVE_TYPE_U1 NativeTypeKind = 0x04
+	// NATIVE_TYPE_I2 constant.
+	NATIVE_TYPE_I2 NativeTypeKind = 0x05
+	// NATIVE_TYPE_U2 constant.
+	NATIVE_TYPE_U2 NativeTypeKind = 0x06
+	// NATIVE_TYPE_I4 constant.
+	NATIVE_TYPE_I4 NativeTypeKind = 0x07
+	// NATIVE_TYPE_U4 constant.
+	NATIVE_TYPE_U4 NativeTypeKind = 0x08
+	// NATIVE_TYPE_I8 constant.
+	NATIVE_TYPE_I8 NativeTypeKind = 0x09
+	// NATIVE_TYPE_U8 constant.
+	NATIVE_TYPE_U8 NativeTypeKind = 0x0a
+	// NATIVE_TYPE_R4 constant.
+	NATIVE_TYPE_R4 NativeTypeKind = 0x0b
+	// NATIVE_TYPE_R8 constant.
+	NATIVE_TYPE_R8 NativeTypeKind = 0x0c
+	// NATIVE_TYPE_CURRENCY constant.
+	NATIVE_TYPE_CURRENCY NativeTypeKind = 0x0f
+	// NATIVE_TYPE_BSTR constant.
+	NATIVE_TYPE_BSTR NativeTypeKind = 0x13
+	// NATIVE_TYPE_LPSTR constant.
+	NATIVE_TYPE_LPSTR NativeTypeKind = 0x14
+	// NATIVE_TYPE_LPWSTR constant.
+	NATIVE_TYPE_LPWSTR NativeTypeKind = 0x15
+	// NATIVE_TYPE_LPTSTR constant.
+	NATIVE_TYPE_LPTSTR NativeTypeKind = 0x16
+	// NATIVE_TYPE_FIXEDSYSSTRING constant, ByValTStr.
+	NATIVE_TYPE_FIXEDSYSSTRING NativeTypeKind = 0x17
+	// NATIVE_TYPE_IUNKNOWN constant.
+	NATIVE_TYPE_IUNKNOWN NativeTypeKind = 0x19
+	// NATIVE_TYPE_IDISPATCH constant.
+	NATIVE_TYPE_IDISPATCH NativeTypeKind = 0x1a
+	// NATIVE_TYPE_STRUCT constant.
+	NATIVE_TYPE_STRUCT NativeTypeKind = 0x1b
+	// NATIVE_TYPE_INTF constant.
+	NATIVE_TYPE_INTF NativeTypeKind = 0x1c
+	// NATIVE_TYPE_SAFEARRAY constant.
+	NATIVE_TYPE_SAFEARRAY NativeTypeKind = 0x1d
+	// NATIVE_TYPE_FIXEDARRAY constant, ByValArray.
+	NATIVE_TYPE_FIXEDARRAY NativeTypeKind = 0x1e
+	// NATIVE_TYPE_INT constant.
+	NATIVE_TYPE_INT NativeTypeKind = 0x1f
+	// NATIVE_TYPE_UINT constant.
+	NATIVE_TYPE_UINT NativeTypeKind = 0x20
+	// NATIVE_TYPE_BYVALSTR constant.
+	NATIVE_TYPE_BYVALSTR NativeTypeKind = 0x22
+	// NATIVE_TYPE_ANSIBSTR constant.
+	NATIVE_TYPE_ANSIBSTR NativeTypeKind = 0x23
+	// NATIVE_TYPE_TBSTR constant.
+	NATIVE_TYPE_TBSTR NativeTypeKind = 0x24
+	// NATIVE_TYPE_VARIANTBOOL constant.
+	NATIVE_TYPE_VARIANTBOOL NativeTypeKind = 0x25
+	// NATIVE_TYPE_FUNC constant.
+	NATIVE_TYPE_FUNC NativeTypeKind = 0x26
+	// NATIVE_TYPE_ASANY constant.
+	NATIVE_TYPE_ASANY NativeTypeKind = 0x28
+	// NATIVE_TYPE_ARRAY constant, LPArray.
+	NATIVE_TYPE_ARRAY NativeTypeKind = 0x2a
+	// NATIVE_TYPE_LPSTRUCT constant.
+	NATIVE_TYPE_LPSTRUCT NativeTypeKind = 0x2b
+	// NATIVE_TYPE_CUSTOMMARSHALER constant.
+	NATIVE_TYPE_CUSTOMMARSHALER NativeTypeKind = 0x2c
+	// NATIVE_TYPE_ERROR constant.
+	NATIVE_TYPE_ERROR NativeTypeKind = 0x2d
+	// NATIVE_TYPE_IINSPECTABLE constant.
+	NATIVE_TYPE_IINSPECTABLE NativeTypeKind = 0x2e
+	// NATIVE_TYPE_HSTRING constant.
+	NATIVE_TYPE_HSTRING NativeTypeKind = 0x2f
+	// NATIVE_TYPE_LPUTF8STR constant.
+	NATIVE_TYPE_LPUTF8STR NativeTypeKind = 0x30
+	// NATIVE_TYPE_MAX constant, denotes unspecified array element type.
+	NATIVE_TYPE_MAX NativeTypeKind = 0x50
+)
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// FromReaderAt creates new Context from raw metadata.
+//
+// Reader must be positioned at the metadata root, see md.ParseMetadataAt.
+func FromReaderAt(r io.ReaderAt, size int64) (*Context, error) {
+	metadata, err := md.ParseMetadataAt(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromMetadata(metadata)
+}
+
+// FromBytes creates new Context from in-memory metadata file.
+//
+// Data could be a PE file or a raw metadata blob, see Open.
+// Tables and heaps are read without copying, so data must not be modified
+// while Context is in use.
+func FromBytes(data []byte) (*Context, error) {
+	return fromReaderAt(md.Bytes(data), int64(len(data)))
+}
+
+// Load reads whole metadata file into memory and creates new Context from it.
+//
+// See FromBytes.
+func Load(path string) (*Context, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := FromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse metadata: %w", err)
+	}
+	return c, nil
+}
+
+// Mmap maps metadata file into memory and creates new Context from it.
+//
+// Mapping is read-only, see FromBytes. On systems without mmap support
+// whole file is read into memory.
+//
+// Context must be closed after use. Strings and blobs returned by
+// Context are not valid after Close.
+func Mmap(path string) (_ *Context, rErr error) {
+	data, closer, err := mmapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rErr != nil {
+			_ = closer.Close()
+		}
+	}()
+
+	c, err := FromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse metadata: %w", err)
+	}
+	c.closer = closer
+	return c, nil
+}
+
+// Open opens metadata file and creates new Context from it.
+//
+// File could be a PE file (like .winmd) or a raw metadata blob starting
+// with BSJB signature (like portable PDB).
+//
+// Context must be closed after use.
+func Open(path string) (_ *Context, rErr error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rErr != nil {
+			_ = f.Close()
+		}
+	}()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := fromReaderAt(f, stat.Size())
+	if err != nil {
+		return nil, fmt.Errorf("parse metadata: %w", err)
+	}
+	c.closer = f
+	return c, nil
+}
+
+// fromReaderAt detects file format and creates new Context.
+func fromReaderAt(r io.ReaderAt, size int64) (*Context, error) {
+	var magic [4]byte
+	if _, err := r.ReadAt(magic[:], 0); err != nil {
+		return nil, fmt.Errorf("read magic: %w", err)
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(magic[:]) == md.STORAGE_MAGIC_SIG:
+		return FromReaderAt(r, size)
+	case magic[0] == 'M' && magic[1] == 'Z':
+		f, err := pe.NewFile(r)
+		if err != nil {
+			return nil, err
+		}
+		return FromPE(f)
+	default:
+		return nil, fmt.Errorf("unknown file format (magic %#x)", magic)
+	}
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func testRawMetadata(a *require.Assertions) []byte {
+	var tables bytes.Buffer
+	for _, v := range []interface{}{
+		[4]byte{},              // Reserved
+		uint8(2),               // MajorVersion
+		uint8(0),               // MinorVersion
+		uint8(0),               // HeapSizes
+		uint8(1),               // Reserved
+		uint64(1 << md.Module), // Valid
+		uint64(0),              // Sorted
+		uint32(1),              // Module rows
+		// Module row.
+		uint16(0), // Generation
+		uint16(1), // Name
+		uint16(1), // Mvid
+		uint16(0), // EncId
+		uint16(0), // EncBaseId
+	} {
+		a.NoError(binary.Write(&tables, binary.LittleEndian, v))
+	}
+
+	return md.EncodeMetadata("v4.0.30319",
+		md.Stream{Name: "#~", Data: tables.Bytes()},
+		md.Stream{Name: "#Strings", Data: []byte("\x00Foo.dll\x00")},
+		md.Stream{Name: "#GUID", Data: bytes.Repeat([]byte{0xAB}, 16)},
+	)
+}
+
+func TestFromReaderAt(t *testing.T) {
+	a := require.New(t)
+
+	data := testRawMetadata(a)
+	c, err := FromReaderAt(bytes.NewReader(data), int64(len(data)))
+	a.NoError(err)
+
+	var m Module
+	a.NoError(m.FromRow(c.Table(md.Module).Row(0)))
+	a.Equal("Foo.dll", m.Name)
+
+	a.Equal(md.GUID{
+		0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB,
+		0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB, 0xAB,
+	}, m.Mvid)
+
+	_, err = FromReaderAt(bytes.NewReader(data[4:]), int64(len(data)-4))
+	a.Error(err)
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	write := func(a *require.Assertions, name string, data []byte) string {
+		p := filepath.Join(dir, name)
+		a.NoError(os.WriteFile(p, data, 0o600))
+		return p
+	}
+
+	t.Run("Raw", func(t *testing.T) {
+		a := require.New(t)
+
+		c, err := Open(write(a, "raw.md", testRawMetadata(a)))
+		a.NoError(err)
+		defer func() {
+			a.NoError(c.Close())
+		}()
+		a.Equal(uint32(1), c.RowCount(md.Module))
+	})
+	t.Run("BadPE", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := Open(write(a, "bad.dll", []byte("MZ not a PE file")))
+		a.Error(err)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := Open(write(a, "unknown", []byte("unknown")))
+		a.Error(err)
+	})
+	t.Run("NotExist", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := Open(filepath.Join(dir, "not-exist"))
+		a.Error(err)
+	})
+}
+
+func TestFromBytes(t *testing.T) {
+	a := require.New(t)
+
+	data := writePE(a, testWriter())
+	c, err := FromBytes(data)
+	a.NoError(err)
+	_, ok := c.section.(md.Bytes)
+	a.True(ok, "tables must be read from memory")
+
+	var def TypeDef
+	a.NoError(def.FromRow(c.Table(md.TypeDef).Row(1)))
+	a.Equal("IFoo", def.TypeName)
+
+	// String references file data.
+	var (
+		start = uintptr(unsafe.Pointer(unsafe.SliceData(data)))
+		ptr   = uintptr(unsafe.Pointer(unsafe.StringData(def.TypeName)))
+	)
+	a.True(ptr >= start && ptr < start+uintptr(len(data)))
+
+	var field Field
+	a.NoError(field.FromRow(c.Table(md.Field).Row(0)))
+	a.Equal(Signature{0x06, 0x08}, field.Signature)
+	a.Equal(len(field.Signature), cap(field.Signature))
+}
+
+func TestOpen_InMemory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]func(a *require.Assertions) []byte{
+		"Raw": testRawMetadata,
+		"PE": func(a *require.Assertions) []byte {
+			return writePE(a, testWriter())
+		},
+	}
+	openers := map[string]func(path string) (*Context, error){
+		"Open": Open,
+		"Load": Load,
+		"Mmap": Mmap,
+	}
+
+	for fileName, file := range files {
+		for openerName, open := range openers {
+			t.Run(fileName+"/"+openerName, func(t *testing.T) {
+				a := require.New(t)
+
+				p := filepath.Join(dir, fileName)
+				a.NoError(os.WriteFile(p, file(a), 0o600))
+
+				c, err := open(p)
+				a.NoError(err)
+				defer func() {
+					a.NoError(c.Close())
+				}()
+
+				if openerName != "Open" {
+					_, ok := c.section.(md.Bytes)
+					a.True(ok, "tables must be read from memory")
+				}
+
+				var m Module
+				a.NoError(m.FromRow(c.Table(md.Module).Row(0)))
+				a.NotEmpty(m.Name)
+			})
+		}
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		a := require.New(t)
+
+		p := filepath.Join(dir, "empty")
+		a.NoError(os.WriteFile(p, nil, 0o600))
+
+		_, err := Load(p)
+		a.Error(err)
+		_, err = Mmap(p)
+		a.Error(err)
+	})
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// RowType is a constraint of table row types, like TypeDef or MethodDef.
+type RowType[T any] interface {
+	*T
+	FromRow(r Row) error
+	TableType() md.TableType
+}
+
+// RowIterator iterates over decoded rows of T table.
+type RowIterator[T any, P RowType[T]] struct {
+	table Table
+	err   error
+}
+
+// Rows returns iterator over decoded rows of T table.
+//
+// If row cannot be decoded, iteration stops and error is returned by Err.
+//
+//	rows := types.Rows[types.TypeDef](c)
+//	for idx, def := range rows.All() {
+//		// ...
+//	}
+//	if err := rows.Err(); err != nil {
+//		return err
+//	}
+func Rows[T any, P RowType[T]](c *Context) *RowIterator[T, P] {
+	return &RowIterator[T, P]{
+		table: c.Table(P(nil).TableType()),
+	}
+}
+
+// All returns iterator over table indexes and decoded rows.
+func (r *RowIterator[T, P]) All() iter.Seq2[Index, T] {
+	return func(yield func(Index, T) bool) {
+		for i := Index(0); i < r.table.RowCount(); i++ {
+			var v T
+			if err := P(&v).FromRow(r.table.Row(i)); err != nil {
+				r.err = fmt.Errorf("decode %v(%d): %w", r.table.Type, i, err)
+				return
+			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns decoding error which stopped iteration, if any.
+func (r *RowIterator[T, P]) Err() error {
+	return r.err
+}
+
+// Get decodes row of T table with given index.
+func Get[T any, P RowType[T]](c *Context, idx Index) (v T, _ error) {
+	tt := P(nil).TableType()
+	if count := c.RowCount(tt); idx >= count {
+		return v, fmt.Errorf("%v row index %d is out of bounds (%d): %w", tt, idx, count, md.ErrOutOfBounds)
+	}
+
+	if err := P(&v).FromRow(c.Table(tt).Row(idx)); err != nil {
+		return v, fmt.Errorf("decode %v(%d): %w", tt, idx, err)
+	}
+	return v, nil
+}
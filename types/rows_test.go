@@ -0,0 +1,93 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestRows(t *testing.T) {
+	a := require.New(t)
+	c := readPE(a, writePE(a, testWriter()))
+
+	var (
+		indexes []Index
+		names   []string
+	)
+	rows := Rows[TypeDef](c)
+	for idx, def := range rows.All() {
+		indexes = append(indexes, idx)
+		names = append(names, def.TypeName)
+	}
+	a.NoError(rows.Err())
+	a.Equal([]Index{0, 1, 2}, indexes)
+	a.Equal([]string{"<Module>", "IFoo", "Bar"}, names)
+
+	// Stop early.
+	rows = Rows[TypeDef](c)
+	for _, def := range rows.All() {
+		a.Equal("<Module>", def.TypeName)
+		break
+	}
+	a.NoError(rows.Err())
+
+	// Empty table.
+	for range Rows[ModuleRef](c).All() {
+		a.Fail("unexpected row")
+	}
+}
+
+func TestRows_Error(t *testing.T) {
+	a := require.New(t)
+
+	var b md.TablesBuilder
+	b.Rows[md.Module] = []md.RowValues{{0, 1}}
+	b.Rows[md.TypeDef] = []md.RowValues{
+		{0, 1, 0, 0, 1, 1},
+		{0, 100, 0, 0, 1, 1},
+		{0, 1, 0, 0, 1, 1},
+	}
+	tables, err := b.Bytes()
+	a.NoError(err)
+
+	c, err := FromBytes(md.EncodeMetadata("v4.0.30319",
+		md.Stream{Name: "#~", Data: tables},
+		md.Stream{Name: "#Strings", Data: []byte("\x00A\x00")},
+	))
+	a.NoError(err)
+
+	var count int
+	rows := Rows[TypeDef](c)
+	for range rows.All() {
+		count++
+	}
+	// Iteration stops at the second row.
+	a.Equal(1, count)
+	a.ErrorIs(rows.Err(), md.ErrOutOfBounds)
+	a.ErrorContains(rows.Err(), "decode TypeDef(1)")
+
+	_, err = Get[TypeDef](c, 1)
+	a.ErrorIs(err, md.ErrOutOfBounds)
+
+	def, err := Get[TypeDef](c, 2)
+	a.NoError(err)
+	a.Equal("A", def.TypeName)
+}
+
+func TestGet(t *testing.T) {
+	a := require.New(t)
+	c := readPE(a, writePE(a, testWriter()))
+
+	def, err := Get[TypeDef](c, 2)
+	a.NoError(err)
+	a.Equal("Bar", def.TypeName)
+
+	param, err := Get[Param](c, 0)
+	a.NoError(err)
+	a.Equal("x", param.Name)
+
+	_, err = Get[TypeDef](c, 3)
+	a.ErrorIs(err, md.ErrOutOfBounds)
+}
@@ -2,10 +2,18 @@ package types
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
-	"io"
+
+	"github.com/tdakkota/win32metadata/md"
 )
 
+// ErrBadSignature is returned when signature blob is malformed.
+var ErrBadSignature = errors.New("bad signature")
+
+// errSignatureTruncated is returned when signature blob ends unexpectedly.
+var errSignatureTruncated = fmt.Errorf("%w: %w", ErrBadSignature, md.ErrTruncated)
+
 // Reader creates SignatureReader.
 func (s Signature) Reader() *SignatureReader {
 	return &SignatureReader{
@@ -13,10 +21,26 @@ func (s Signature) Reader() *SignatureReader {
 	}
 }
 
+// maxSignatureDepth is a maximum nesting depth of signature types.
+//
+// Real signatures are much shallower, limit protects from stack exhaustion
+// on malformed input like SZARRAY SZARRAY ... SZARRAY.
+const maxSignatureDepth = 64
+
 // SignatureReader is a helper to read Signature.
 type SignatureReader struct {
 	sig    Signature
 	offset int
+	// depth is a nesting depth of currently decoded type.
+	depth int
+}
+
+// Len returns number of unread bytes.
+func (s *SignatureReader) Len() int {
+	if s.offset >= len(s.sig) {
+		return 0
+	}
+	return len(s.sig) - s.offset
 }
 
 // Peek peeks unsigned integer from Signature blob, returns it and its size in bytes.
@@ -70,46 +94,132 @@ func (s *SignatureReader) Read() (value uint32, ok bool) {
 	return
 }
 
-func (s *SignatureReader) modifiers() (result []TypeDefOrRef) {
+// ReadSigned reads signed compressed integer from Signature blob and returns it.
+// If there is no data anymore, ok is false.
+//
+// See II.23.2 Blobs and signatures.
+func (s *SignatureReader) ReadSigned() (value int32, ok bool) {
+	raw, size, ok := s.Peek()
+	if !ok {
+		return 0, false
+	}
+	s.offset += size
+
+	value = int32(raw >> 1)
+	if raw&1 == 0 {
+		return value, true
+	}
+	switch size {
+	case 1:
+		value -= 0x40
+	case 2:
+		value -= 0x2000
+	default:
+		value -= 0x10000000
+	}
+	return value, true
+}
+
+// CustomModifier is a II.23.2.7 CustomMod representation.
+type CustomModifier struct {
+	// Required denotes that modifier is CMOD_REQD, otherwise it is CMOD_OPT.
+	Required bool
+	Type     TypeDefOrRef
+}
+
+func (s *SignatureReader) modifiers() (result []CustomModifier, _ error) {
 	for {
 		value, size, ok := s.Peek()
-		if !ok || (value != 32 && value != 31) {
+		if !ok || (value != uint32(ELEMENT_TYPE_CMOD_OPT) && value != uint32(ELEMENT_TYPE_CMOD_REQD)) {
 			break
 		}
 		s.offset += size
-		result = append(result, TypeDefOrRef(value))
+
+		token, ok := s.Read()
+		if !ok {
+			return nil, errSignatureTruncated
+		}
+		result = append(result, CustomModifier{
+			Required: value == uint32(ELEMENT_TYPE_CMOD_REQD),
+			Type:     TypeDefOrRef(token),
+		})
 	}
 
-	return
+	return result, nil
 }
 
 // Element represents one parameter or result in Signature.
 type Element struct {
-	Type     ElementType
-	Pointers int
-	ByRef    bool
-	IsConst  bool
-	IsArray  bool
+	Type ElementType
+	// Modifiers contains custom modifiers of every pointer level.
+	//
+	// Modifiers[0] are placed before PINNED, BYREF and PTR prefixes, Modifiers[i] are
+	// placed after i-th PTR prefix. Nil if Element has no modifiers at all.
+	Modifiers [][]CustomModifier
+	Pointers  int
+	ByRef     bool
+	Pinned    bool
+	IsConst   bool
+	IsArray   bool
+}
+
+func (s *SignatureReader) arrayShape() (shape ArrayShape, _ error) {
+	rank, ok := s.Read()
+	if !ok {
+		return shape, errSignatureTruncated
+	}
+	shape.Rank = rank
+
+	numSizes, ok := s.Read()
+	if !ok {
+		return shape, errSignatureTruncated
+	}
+	for i := uint32(0); i < numSizes; i++ {
+		size, ok := s.Read()
+		if !ok {
+			return shape, errSignatureTruncated
+		}
+		shape.Sizes = append(shape.Sizes, size)
+	}
+
+	numLoBounds, ok := s.Read()
+	if !ok {
+		return shape, errSignatureTruncated
+	}
+	for i := uint32(0); i < numLoBounds; i++ {
+		bound, ok := s.ReadSigned()
+		if !ok {
+			return shape, errSignatureTruncated
+		}
+		shape.LoBounds = append(shape.LoBounds, bound)
+	}
+
+	return shape, nil
 }
 
 func (s *SignatureReader) elementType(c *Context) (ElementType, error) {
 	var t ElementType
 
+	if s.depth >= maxSignatureDepth {
+		return t, fmt.Errorf("%w: type nesting is deeper than %d", ErrBadSignature, maxSignatureDepth)
+	}
+	s.depth++
+	defer func() { s.depth-- }()
+
 	value, ok := s.Read()
 	if !ok {
-		return t, io.ErrUnexpectedEOF
+		return t, errSignatureTruncated
 	}
 
 	if t.FromCode(value) {
 		return t, nil
 	}
 
-	// TODO(tdakkota): complete implementation
 	switch ElementTypeKind(value) {
 	case ELEMENT_TYPE_VALUETYPE, ELEMENT_TYPE_CLASS:
 		r, ok := s.Read()
 		if !ok {
-			return t, io.ErrUnexpectedEOF
+			return t, errSignatureTruncated
 		}
 		t.TypeDef.Index = TypeDefOrRef(r)
 
@@ -117,7 +227,7 @@ func (s *SignatureReader) elementType(c *Context) (ElementType, error) {
 	case ELEMENT_TYPE_VAR:
 		r, ok := s.Read()
 		if !ok {
-			return t, io.ErrUnexpectedEOF
+			return t, errSignatureTruncated
 		}
 		t.GenericTypeVar.Index = r
 
@@ -125,40 +235,69 @@ func (s *SignatureReader) elementType(c *Context) (ElementType, error) {
 	case ELEMENT_TYPE_MVAR:
 		r, ok := s.Read()
 		if !ok {
-			return t, io.ErrUnexpectedEOF
+			return t, errSignatureTruncated
 		}
 		t.GenericMethodVar.Index = r
 
+		return t, nil
+	case ELEMENT_TYPE_PTR:
+		elem, err := s.NextElement(c)
+		if err != nil {
+			return ElementType{}, err
+		}
+
+		t.Ptr = ElementTypePtr{
+			Elem: &elem,
+		}
+		return t, nil
+	case ELEMENT_TYPE_FNPTR:
+		sig, err := s.Method(c)
+		if err != nil {
+			return ElementType{}, err
+		}
+
+		t.FnPtr = ElementTypeFnPtr{
+			Signature: &sig,
+		}
 		return t, nil
 	case ELEMENT_TYPE_ARRAY:
 		elem, err := s.NextElement(c)
 		if err != nil {
 			return ElementType{}, err
 		}
-		// TODO(tdakkota): complete decoding according to II.23.2.13 ArrayShape.
-		s.Read() // rank
-		s.Read() // bounds count
 
-		size, ok := s.Read()
-		if !ok {
-			return ElementType{}, io.ErrUnexpectedEOF
+		shape, err := s.arrayShape()
+		if err != nil {
+			return ElementType{}, err
 		}
+
 		t.Array = ElementTypeArray{
-			Elem: &elem,
-			Size: size,
+			Elem:  &elem,
+			Shape: shape,
 		}
 		return t, nil
 	case ELEMENT_TYPE_GENERICINST:
-		s.Read() // (CLASS | VALUETYPE)
+		kind, ok := s.Read() // (CLASS | VALUETYPE)
+		if !ok {
+			return t, errSignatureTruncated
+		}
+		switch ElementTypeKind(kind) {
+		case ELEMENT_TYPE_CLASS:
+		case ELEMENT_TYPE_VALUETYPE:
+			t.TypeDef.IsValueType = true
+		default:
+			return t, fmt.Errorf("%w: unexpected generic instantiation type %#x", ErrBadSignature, kind)
+		}
+
 		r, ok := s.Read()
 		if !ok {
-			return t, io.ErrUnexpectedEOF
+			return t, errSignatureTruncated
 		}
 		t.TypeDef.Index = TypeDefOrRef(r)
 
 		args, ok := s.Read() // GenArgCount
 		if !ok {
-			return t, io.ErrUnexpectedEOF
+			return t, errSignatureTruncated
 		}
 		for i := uint32(0); i < args; i++ {
 			arg, err := s.elementType(c)
@@ -180,19 +319,27 @@ func (s *SignatureReader) elementType(c *Context) (ElementType, error) {
 		}
 		return t, nil
 	default:
-		return t, fmt.Errorf("unexpected element type %#x", value)
+		return t, fmt.Errorf("%w: unexpected element type %#x", ErrBadSignature, value)
 	}
 }
 
-func (s *SignatureReader) isConst(c *Context) (bool, error) {
-	for _, mod := range s.modifiers() {
-		namespace, name, err := c.ResolveTypeDefOrRefName(mod)
-		if err != nil {
-			return false, err
-		}
+// isConst reports whether any pointer level has IsConst modifier.
+func isConst(c *Context, levels [][]CustomModifier) (bool, error) {
+	// Context is needed only to resolve modifier names.
+	if c == nil {
+		return false, nil
+	}
 
-		if namespace == "System.Runtime.CompilerServices" && name == "IsConst" {
-			return true, nil
+	for _, mods := range levels {
+		for _, mod := range mods {
+			namespace, name, err := c.ResolveTypeDefOrRefName(mod.Type)
+			if err != nil {
+				return false, err
+			}
+
+			if namespace == "System.Runtime.CompilerServices" && name == "IsConst" {
+				return true, nil
+			}
 		}
 	}
 
@@ -201,22 +348,37 @@ func (s *SignatureReader) isConst(c *Context) (bool, error) {
 
 // NextElement returns next Element in signature.
 func (s *SignatureReader) NextElement(c *Context) (e Element, _ error) {
-	isConst, err := s.isConst(c)
+	mods, err := s.modifiers()
 	if err != nil {
 		return e, err
 	}
-	e.IsConst = isConst
+	levels := [][]CustomModifier{mods}
+	hasModifiers := len(mods) > 0
 
+	e.Pinned = s.NextIs(uint32(ELEMENT_TYPE_PINNED))
 	e.ByRef = s.NextIs(uint32(ELEMENT_TYPE_BYREF))
 	if s.NextIs(uint32(ELEMENT_TYPE_VOID)) {
 		e.Type = ElementType{
 			Kind: ELEMENT_TYPE_VOID,
 		}
+		if hasModifiers {
+			e.Modifiers = levels
+		}
 		return e, nil
 	}
-	e.IsArray = s.NextIs(uint32(ELEMENT_TYPE_ARRAY))
 	for s.NextIs(uint32(ELEMENT_TYPE_PTR)) {
 		e.Pointers++
+
+		// II.23.2.12 Type: PTR CustomMod* Type.
+		mods, err := s.modifiers()
+		if err != nil {
+			return e, err
+		}
+		levels = append(levels, mods)
+		hasModifiers = hasModifiers || len(mods) > 0
+	}
+	if hasModifiers {
+		e.Modifiers = levels
 	}
 
 	elementType, err := s.elementType(c)
@@ -224,8 +386,14 @@ func (s *SignatureReader) NextElement(c *Context) (e Element, _ error) {
 		return e, err
 	}
 	e.Type = elementType
+	e.IsArray = elementType.Kind == ELEMENT_TYPE_ARRAY
 
-	return
+	e.IsConst, err = isConst(c, e.Modifiers)
+	if err != nil {
+		return e, err
+	}
+
+	return e, nil
 }
 
 // MethodSignature is a II.23.2.1 MethodDefSig or II.23.2.2 MethodRefSig representation.
@@ -234,13 +402,15 @@ type MethodSignature struct {
 	GenericArgCount uint32
 	Return          Element
 	Params          []Element
+	// VarArgs contains parameters after SENTINEL in vararg MethodRefSig.
+	VarArgs []Element
 }
 
 // Method reads MethodSignature from Signature blob.
 func (s *SignatureReader) Method(file *Context) (MethodSignature, error) {
 	flags, ok := s.Read()
 	if !ok {
-		return MethodSignature{}, io.ErrUnexpectedEOF
+		return MethodSignature{}, errSignatureTruncated
 	}
 
 	const METHOD_DEF_SIG_FLAGS_GENERIC = 0x10
@@ -248,13 +418,13 @@ func (s *SignatureReader) Method(file *Context) (MethodSignature, error) {
 	if flags&METHOD_DEF_SIG_FLAGS_GENERIC == METHOD_DEF_SIG_FLAGS_GENERIC {
 		genericArgCount, ok = s.Read()
 		if !ok {
-			return MethodSignature{}, io.ErrUnexpectedEOF
+			return MethodSignature{}, errSignatureTruncated
 		}
 	}
 
 	count, ok := s.Read()
 	if !ok {
-		return MethodSignature{}, io.ErrUnexpectedEOF
+		return MethodSignature{}, errSignatureTruncated
 	}
 
 	returnType, err := s.NextElement(file)
@@ -262,14 +432,33 @@ func (s *SignatureReader) Method(file *Context) (MethodSignature, error) {
 		return MethodSignature{}, err
 	}
 
-	var params []Element
+	var (
+		params   []Element
+		varArgs  []Element
+		sentinel bool
+	)
+	// Every parameter takes at least one byte.
+	if int64(count) > int64(s.Len()) {
+		return MethodSignature{}, fmt.Errorf("%w: param count %d is bigger than signature", errSignatureTruncated, count)
+	}
 	if count > 0 {
 		params = make([]Element, 0, count)
 		for i := 0; i < int(count); i++ {
+			if s.NextIs(uint32(ELEMENT_TYPE_SENTINEL)) {
+				if sentinel {
+					return MethodSignature{}, fmt.Errorf("%w: unexpected second sentinel at param %d", ErrBadSignature, i)
+				}
+				sentinel = true
+			}
+
 			t, err := s.NextElement(file)
 			if err != nil {
 				return MethodSignature{}, err
 			}
+			if sentinel {
+				varArgs = append(varArgs, t)
+				continue
+			}
 			params = append(params, t)
 		}
 	}
@@ -279,6 +468,7 @@ func (s *SignatureReader) Method(file *Context) (MethodSignature, error) {
 		GenericArgCount: genericArgCount,
 		Return:          returnType,
 		Params:          params,
+		VarArgs:         varArgs,
 	}, nil
 }
 
@@ -291,10 +481,10 @@ type FieldSignature struct {
 func (s *SignatureReader) Field(file *Context) (FieldSignature, error) {
 	typ, ok := s.Read()
 	if !ok {
-		return FieldSignature{}, io.ErrUnexpectedEOF
+		return FieldSignature{}, errSignatureTruncated
 	}
 	if typ != 0x6 {
-		return FieldSignature{}, fmt.Errorf("unexpected field type %d", typ)
+		return FieldSignature{}, fmt.Errorf("%w: unexpected field type %d", ErrBadSignature, typ)
 	}
 
 	e, err := s.NextElement(file)
@@ -306,3 +496,165 @@ func (s *SignatureReader) Field(file *Context) (FieldSignature, error) {
 		Field: e,
 	}, nil
 }
+
+// PropertySignature is a II.23.2.5 PropertySig representation.
+type PropertySignature struct {
+	// HasThis denotes that property is an instance property.
+	HasThis bool
+	Type    Element
+	// Params contains parameters of indexed property.
+	Params []Element
+}
+
+// Property reads PropertySignature from Signature blob.
+func (s *SignatureReader) Property(file *Context) (PropertySignature, error) {
+	const (
+		PROPERTY = 0x8
+		HASTHIS  = 0x20
+	)
+	flags, ok := s.Read()
+	if !ok {
+		return PropertySignature{}, errSignatureTruncated
+	}
+	if flags&^HASTHIS != PROPERTY {
+		return PropertySignature{}, fmt.Errorf("%w: unexpected property type %#x", ErrBadSignature, flags)
+	}
+
+	count, ok := s.Read()
+	if !ok {
+		return PropertySignature{}, errSignatureTruncated
+	}
+
+	typ, err := s.NextElement(file)
+	if err != nil {
+		return PropertySignature{}, err
+	}
+
+	// Every parameter takes at least one byte.
+	if int64(count) > int64(s.Len()) {
+		return PropertySignature{}, fmt.Errorf("%w: param count %d is bigger than signature", errSignatureTruncated, count)
+	}
+	var params []Element
+	for i := uint32(0); i < count; i++ {
+		p, err := s.NextElement(file)
+		if err != nil {
+			return PropertySignature{}, err
+		}
+		params = append(params, p)
+	}
+
+	return PropertySignature{
+		HasThis: flags&HASTHIS != 0,
+		Type:    typ,
+		Params:  params,
+	}, nil
+}
+
+// LocalVarSignature is a II.23.2.6 LocalVarSig representation.
+type LocalVarSignature struct {
+	Locals []Element
+}
+
+// LocalVar reads LocalVarSignature from Signature blob.
+func (s *SignatureReader) LocalVar(file *Context) (LocalVarSignature, error) {
+	const LOCAL_SIG = 0x7
+	typ, ok := s.Read()
+	if !ok {
+		return LocalVarSignature{}, errSignatureTruncated
+	}
+	if typ != LOCAL_SIG {
+		return LocalVarSignature{}, fmt.Errorf("%w: unexpected local variables type %#x", ErrBadSignature, typ)
+	}
+
+	count, ok := s.Read()
+	if !ok {
+		return LocalVarSignature{}, errSignatureTruncated
+	}
+	// Every local takes at least one byte.
+	if int64(count) > int64(s.Len()) {
+		return LocalVarSignature{}, fmt.Errorf("%w: local count %d is bigger than signature", errSignatureTruncated, count)
+	}
+
+	locals := make([]Element, 0, count)
+	for i := uint32(0); i < count; i++ {
+		e, err := s.NextElement(file)
+		if err != nil {
+			return LocalVarSignature{}, fmt.Errorf("local %d: %w", i, err)
+		}
+		locals = append(locals, e)
+	}
+
+	return LocalVarSignature{
+		Locals: locals,
+	}, nil
+}
+
+// MethodSpecSignature is a II.23.2.15 MethodSpec instantiation representation.
+type MethodSpecSignature struct {
+	Args []ElementType
+}
+
+// MethodSpec reads MethodSpecSignature from Signature blob.
+func (s *SignatureReader) MethodSpec(file *Context) (MethodSpecSignature, error) {
+	const GENERICINST = 0xa
+	typ, ok := s.Read()
+	if !ok {
+		return MethodSpecSignature{}, errSignatureTruncated
+	}
+	if typ != GENERICINST {
+		return MethodSpecSignature{}, fmt.Errorf("%w: unexpected method instantiation type %#x", ErrBadSignature, typ)
+	}
+
+	count, ok := s.Read()
+	if !ok {
+		return MethodSpecSignature{}, errSignatureTruncated
+	}
+	// Every argument takes at least one byte.
+	if int64(count) > int64(s.Len()) {
+		return MethodSpecSignature{}, fmt.Errorf("%w: argument count %d is bigger than signature", errSignatureTruncated, count)
+	}
+
+	args := make([]ElementType, 0, count)
+	for i := uint32(0); i < count; i++ {
+		arg, err := s.elementType(file)
+		if err != nil {
+			return MethodSpecSignature{}, fmt.Errorf("argument %d: %w", i, err)
+		}
+		args = append(args, arg)
+	}
+
+	return MethodSpecSignature{
+		Args: args,
+	}, nil
+}
+
+// TypeSpec reads II.23.2.14 TypeSpec from Signature blob.
+//
+// Only PTR, FNPTR, ARRAY, SZARRAY, GENERICINST, VAR and MVAR types are allowed,
+// signature must not contain trailing bytes.
+func (s *SignatureReader) TypeSpec(file *Context) (ElementType, error) {
+	kind, _, ok := s.Peek()
+	if !ok {
+		return ElementType{}, errSignatureTruncated
+	}
+	switch ElementTypeKind(kind) {
+	case ELEMENT_TYPE_PTR,
+		ELEMENT_TYPE_FNPTR,
+		ELEMENT_TYPE_ARRAY,
+		ELEMENT_TYPE_SZARRAY,
+		ELEMENT_TYPE_GENERICINST,
+		ELEMENT_TYPE_VAR,
+		ELEMENT_TYPE_MVAR:
+	default:
+		return ElementType{}, fmt.Errorf("%w: unexpected TypeSpec type %#x", ErrBadSignature, kind)
+	}
+
+	t, err := s.elementType(file)
+	if err != nil {
+		return t, err
+	}
+	if s.Len() > 0 {
+		return t, fmt.Errorf("%w: %d trailing bytes after %v", ErrBadSignature, s.Len(), t.Kind)
+	}
+	return t, nil
+}
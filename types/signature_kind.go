@@ -0,0 +1,100 @@
+package types
+
+import "fmt"
+
+// SignatureKind is a kind of signature blob, defined by II.23.2.3 calling convention byte.
+type SignatureKind uint8
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SignatureKind -trimprefix=SignatureKind
+
+const (
+	// SignatureKindMethod is a II.23.2.1 MethodDefSig or II.23.2.2 MethodRefSig.
+	SignatureKindMethod SignatureKind = iota + 1
+	// SignatureKindField is a II.23.2.4 FieldSig.
+	SignatureKindField
+	// SignatureKindProperty is a II.23.2.5 PropertySig.
+	SignatureKindProperty
+	// SignatureKindLocalVar is a II.23.2.6 LocalVarSig.
+	SignatureKindLocalVar
+	// SignatureKindMethodSpec is a II.23.2.15 MethodSpec instantiation.
+	SignatureKindMethodSpec
+)
+
+// Kind returns kind of signature using its first byte.
+//
+// Calling convention byte must have only flags allowed for its kind, as
+// readers of particular kinds, like Field, require.
+//
+// TypeSpec signatures have no calling convention byte, so they can't be detected.
+func (s *SignatureReader) Kind() (SignatureKind, error) {
+	const (
+		CALLING_CONVENTION_MASK = 0x0f
+
+		DEFAULT     = 0x0
+		VARARG      = 0x5
+		FIELD       = 0x6
+		LOCAL_SIG   = 0x7
+		PROPERTY    = 0x8
+		UNMANAGED   = 0x9
+		GENERICINST = 0xa
+
+		GENERIC      = 0x10
+		HASTHIS      = 0x20
+		EXPLICITTHIS = 0x40
+	)
+
+	value, _, ok := s.Peek()
+	if !ok {
+		return 0, errSignatureTruncated
+	}
+
+	cc, flags := value&CALLING_CONVENTION_MASK, value&^CALLING_CONVENTION_MASK
+	switch {
+	case (cc <= VARARG || cc == UNMANAGED) && flags&^(GENERIC|HASTHIS|EXPLICITTHIS) == 0:
+		return SignatureKindMethod, nil
+	case cc == FIELD && flags == 0:
+		return SignatureKindField, nil
+	case cc == LOCAL_SIG && flags == 0:
+		return SignatureKindLocalVar, nil
+	case cc == PROPERTY && flags&^HASTHIS == 0:
+		return SignatureKindProperty, nil
+	case cc == GENERICINST && flags == 0:
+		return SignatureKindMethodSpec, nil
+	default:
+		return 0, fmt.Errorf("%w: unexpected calling convention %#x", ErrBadSignature, value)
+	}
+}
+
+// DecodedSignature is a decoded signature blob.
+//
+// Only one of Method, Field, Property, LocalVar, MethodSpec fields is present, according to Kind.
+type DecodedSignature struct {
+	Kind       SignatureKind
+	Method     MethodSignature
+	Field      FieldSignature
+	Property   PropertySignature
+	LocalVar   LocalVarSignature
+	MethodSpec MethodSpecSignature
+}
+
+// Decode reads signature of any kind, using calling convention byte.
+func (s *SignatureReader) Decode(file *Context) (d DecodedSignature, err error) {
+	d.Kind, err = s.Kind()
+	if err != nil {
+		return d, err
+	}
+
+	switch d.Kind {
+	case SignatureKindMethod:
+		d.Method, err = s.Method(file)
+	case SignatureKindField:
+		d.Field, err = s.Field(file)
+	case SignatureKindProperty:
+		d.Property, err = s.Property(file)
+	case SignatureKindLocalVar:
+		d.LocalVar, err = s.LocalVar(file)
+	case SignatureKindMethodSpec:
+		d.MethodSpec, err = s.MethodSpec(file)
+	}
+	return d, err
+}
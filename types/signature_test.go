@@ -1,9 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
 )
 
 func TestSignatureReader_Method(t *testing.T) {
@@ -228,6 +231,95 @@ func TestSignatureReader_Method(t *testing.T) {
 				},
 			},
 		},
+		{
+			"VarArgs",
+			Signature{
+				0x05, // VARARG
+				3,
+				1,    // void
+				8,    // int32
+				0x41, // SENTINEL
+				8,    // int32
+				0x16, // TYPEDBYREF
+			},
+			MethodSignature{
+				Flags: 0x05,
+				Return: Element{
+					Type: ElementType{Kind: ELEMENT_TYPE_VOID},
+				},
+				Params: []Element{
+					{Type: ElementType{Kind: ELEMENT_TYPE_I4}},
+				},
+				VarArgs: []Element{
+					{Type: ElementType{Kind: ELEMENT_TYPE_I4}},
+					{Type: ElementType{Kind: ELEMENT_TYPE_TYPEDBYREF}},
+				},
+			},
+		},
+		{
+			"FunctionPointer",
+			Signature{
+				0,
+				1,
+				1,     // void
+				0x1b,  // FNPTR
+				0,     // Calling convention
+				1,     // Parameter count
+				8,     // int32
+				15, 1, // *void
+			},
+			MethodSignature{
+				Return: Element{
+					Type: ElementType{Kind: ELEMENT_TYPE_VOID},
+				},
+				Params: []Element{
+					{Type: ElementType{
+						Kind: ELEMENT_TYPE_FNPTR,
+						FnPtr: ElementTypeFnPtr{
+							Signature: &MethodSignature{
+								Return: Element{
+									Type: ElementType{Kind: ELEMENT_TYPE_I4},
+								},
+								Params: []Element{
+									{Type: ElementType{Kind: ELEMENT_TYPE_VOID}, Pointers: 1},
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		{
+			"CustomModifiers",
+			Signature{
+				0,
+				1,
+				0x1f, 0x09, // modreq(TypeRef(1))
+				1,          // void
+				0x20, 0x05, // modopt(TypeDef(1))
+				15,         // *
+				0x20, 0x0d, // modopt(TypeRef(3))
+				5, // uint8
+			},
+			MethodSignature{
+				Return: Element{
+					Type: ElementType{Kind: ELEMENT_TYPE_VOID},
+					Modifiers: [][]CustomModifier{
+						{{Required: true, Type: TypeDefOrRef(0x09)}},
+					},
+				},
+				Params: []Element{
+					{
+						Type: ElementType{Kind: ELEMENT_TYPE_U1},
+						Modifiers: [][]CustomModifier{
+							{{Type: TypeDefOrRef(0x05)}},
+							{{Type: TypeDefOrRef(0x0d)}},
+						},
+						Pointers: 1,
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -238,6 +330,10 @@ func TestSignatureReader_Method(t *testing.T) {
 			a.NoError(err)
 
 			a.Equal(test.expect, method)
+
+			sig, err := method.Encode()
+			a.NoError(err)
+			a.Equal(test.sig, sig)
 		})
 	}
 }
@@ -303,6 +399,95 @@ func TestSignatureReader_Field(t *testing.T) {
 				},
 			}},
 		},
+		{
+			// WCHAR szExeFile[260] from PROCESSENTRY32W.
+			"ELEMENT_TYPE_ARRAY",
+			Signature{
+				0x06,       // FIELD
+				0x14,       // ELEMENT_TYPE_ARRAY
+				0x07,       // uint16
+				0x01,       // Rank
+				0x01,       // NumSizes
+				0x81, 0x04, // Size
+				0x00, // NumLoBounds
+			},
+			FieldSignature{Field: Element{
+				Type: ElementType{
+					Kind: ELEMENT_TYPE_ARRAY,
+					Array: ElementTypeArray{
+						Elem: &Element{
+							Type: ElementType{Kind: ELEMENT_TYPE_U2},
+						},
+						Shape: ArrayShape{
+							Rank:  1,
+							Sizes: []uint32{260},
+						},
+					},
+				},
+				IsArray: true,
+			}},
+		},
+		{
+			// II.23.2.13 ArrayShape example: [-3...2, 1...4] (lo bounds are signed).
+			"ArrayShape",
+			Signature{
+				0x06,       // FIELD
+				0x14,       // ELEMENT_TYPE_ARRAY
+				0x08,       // int32
+				0x02,       // Rank
+				0x02,       // NumSizes
+				0x06, 0x04, // Sizes
+				0x02,       // NumLoBounds
+				0x7b, 0x02, // LoBounds
+			},
+			FieldSignature{Field: Element{
+				Type: ElementType{
+					Kind: ELEMENT_TYPE_ARRAY,
+					Array: ElementTypeArray{
+						Elem: &Element{
+							Type: ElementType{Kind: ELEMENT_TYPE_I4},
+						},
+						Shape: ArrayShape{
+							Rank:     2,
+							Sizes:    []uint32{6, 4},
+							LoBounds: []int32{-3, 1},
+						},
+					},
+				},
+				IsArray: true,
+			}},
+		},
+		{
+			"GENERICINST with pointer argument",
+			Signature{
+				0x06, // FIELD
+				0x15, // ELEMENT_TYPE_GENERICINST
+				0x11, // ELEMENT_TYPE_VALUETYPE
+				0x08, // TypeDefOrRef index
+				0x01, // GenArgCount
+				0x0f, // ELEMENT_TYPE_PTR
+				0x08, // int32
+			},
+			FieldSignature{Field: Element{
+				Type: ElementType{
+					Kind: ELEMENT_TYPE_GENERICINST,
+					TypeDef: ElementTypeTypeDef{
+						Index:       8,
+						IsValueType: true,
+						Generics: []ElementType{
+							{
+								Kind: ELEMENT_TYPE_PTR,
+								Ptr: ElementTypePtr{
+									Elem: &Element{
+										Type: ElementType{Kind: ELEMENT_TYPE_I4},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -313,6 +498,346 @@ func TestSignatureReader_Field(t *testing.T) {
 			a.NoError(err)
 
 			a.Equal(test.expect, method)
+
+			sig, err := method.Encode()
+			a.NoError(err)
+			a.Equal(test.sig, sig)
+		})
+	}
+}
+
+func TestSignatureReader_IsConst(t *testing.T) {
+	a := require.New(t)
+
+	w := NewWriter()
+	(&Module{Name: "Test.winmd"}).AppendTo(w)
+	mscorlib := (&AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	isConst := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.AssemblyRef, mscorlib-1),
+		TypeName:        "IsConst",
+		TypeNamespace:   "System.Runtime.CompilerServices",
+	}).AppendTo(w)
+	c := readPE(a, writePE(a, w))
+
+	modreq := CustomModifier{Required: true, Type: CreateTypeDefOrRef(md.TypeRef, isConst-1)}
+	field, err := Signature{
+		0x06,                    // FIELD
+		0x0f,                    // *
+		0x1f, byte(modreq.Type), // modreq(IsConst)
+		0x04, // int8
+	}.Reader().Field(c)
+	a.NoError(err)
+	a.Equal(FieldSignature{Field: Element{
+		Type:      ElementType{Kind: ELEMENT_TYPE_I1},
+		Modifiers: [][]CustomModifier{nil, {modreq}},
+		Pointers:  1,
+		IsConst:   true,
+	}}, field)
+}
+
+func TestSignatureReader_Property(t *testing.T) {
+	tests := []struct {
+		name   string
+		sig    Signature
+		expect PropertySignature
+	}{
+		{
+			"Static",
+			Signature{
+				0x08, // PROPERTY
+				0x00, // ParamCount
+				0x0e, // ELEMENT_TYPE_STRING
+			},
+			PropertySignature{Type: Element{
+				Type: ElementType{Kind: ELEMENT_TYPE_STRING},
+			}},
+		},
+		{
+			// WinRT IVector<T>.Size like property.
+			"Instance",
+			Signature{
+				0x28, // HASTHIS | PROPERTY
+				0x00, // ParamCount
+				0x09, // ELEMENT_TYPE_U4
+			},
+			PropertySignature{HasThis: true, Type: Element{
+				Type: ElementType{Kind: ELEMENT_TYPE_U4},
+			}},
+		},
+		{
+			"Indexed",
+			Signature{
+				0x28, // HASTHIS | PROPERTY
+				0x01, // ParamCount
+				0x13, // ELEMENT_TYPE_VAR
+				0x00, // 0
+				0x08, // ELEMENT_TYPE_I4
+			},
+			PropertySignature{
+				HasThis: true,
+				Type: Element{
+					Type: ElementType{
+						Kind:           ELEMENT_TYPE_VAR,
+						GenericTypeVar: ElementTypeGenericTypeVar{Index: 0},
+					},
+				},
+				Params: []Element{
+					{Type: ElementType{Kind: ELEMENT_TYPE_I4}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := require.New(t)
+			r := test.sig.Reader()
+
+			prop, err := r.Property(nil)
+			a.NoError(err)
+			a.Zero(r.Len())
+
+			a.Equal(test.expect, prop)
+
+			sig, err := prop.Encode()
+			a.NoError(err)
+			a.Equal(test.sig, sig)
 		})
 	}
+
+	for _, sig := range []Signature{
+		{},
+		{0x06, 0x00, 0x08},
+		{0x28},
+		{0x28, 0x02, 0x08},
+	} {
+		_, err := sig.Reader().Property(nil)
+		require.ErrorIs(t, err, ErrBadSignature, "%x", []byte(sig))
+	}
+}
+
+func TestSignatureReader_LocalVar(t *testing.T) {
+	a := require.New(t)
+
+	sig := Signature{
+		0x07, // LOCAL_SIG
+		0x03, // Count
+		0x08, // ELEMENT_TYPE_I4
+		0x45, // ELEMENT_TYPE_PINNED
+		0x10, // ELEMENT_TYPE_BYREF
+		0x05, // ELEMENT_TYPE_U1
+		0x16, // ELEMENT_TYPE_TYPEDBYREF
+	}
+	r := sig.Reader()
+	locals, err := r.LocalVar(nil)
+	a.NoError(err)
+	a.Zero(r.Len())
+	a.Equal(LocalVarSignature{Locals: []Element{
+		{Type: ElementType{Kind: ELEMENT_TYPE_I4}},
+		{Type: ElementType{Kind: ELEMENT_TYPE_U1}, Pinned: true, ByRef: true},
+		{Type: ElementType{Kind: ELEMENT_TYPE_TYPEDBYREF}},
+	}}, locals)
+
+	encoded, err := locals.Encode()
+	a.NoError(err)
+	a.Equal(sig, encoded)
+
+	for _, sig := range []Signature{
+		{},
+		{0x06, 0x00},
+		{0x07},
+		{0x07, 0x02, 0x08},
+	} {
+		_, err := sig.Reader().LocalVar(nil)
+		a.ErrorIs(err, ErrBadSignature, "%x", []byte(sig))
+	}
+}
+
+func TestSignatureReader_MethodSpec(t *testing.T) {
+	a := require.New(t)
+
+	sig := Signature{
+		0x0a, // GENERICINST
+		0x02, // GenArgCount
+		0x0e, // ELEMENT_TYPE_STRING
+		0x15, // ELEMENT_TYPE_GENERICINST
+		0x12, // ELEMENT_TYPE_CLASS
+		0x09, // TypeDefOrRef: TypeRef(1)
+		0x01, // GenArgCount
+		0x1e, // ELEMENT_TYPE_MVAR
+		0x00, // 0
+	}
+	r := sig.Reader()
+	spec, err := r.MethodSpec(nil)
+	a.NoError(err)
+	a.Zero(r.Len())
+	a.Equal(MethodSpecSignature{Args: []ElementType{
+		{Kind: ELEMENT_TYPE_STRING},
+		{
+			Kind: ELEMENT_TYPE_GENERICINST,
+			TypeDef: ElementTypeTypeDef{
+				Index: CreateTypeDefOrRef(md.TypeRef, 1),
+				Generics: []ElementType{
+					{Kind: ELEMENT_TYPE_MVAR},
+				},
+			},
+		},
+	}}, spec)
+
+	encoded, err := spec.Encode()
+	a.NoError(err)
+	a.Equal(sig, encoded)
+
+	for _, sig := range []Signature{
+		{},
+		{0x0a},
+		{0x0a, 0x01},
+		{0x15, 0x01, 0x08},
+	} {
+		_, err := sig.Reader().MethodSpec(nil)
+		a.ErrorIs(err, ErrBadSignature, "%x", []byte(sig))
+	}
+}
+
+func TestSignatureReader_TypeSpec(t *testing.T) {
+	a := require.New(t)
+
+	sig := Signature{
+		0x1d, // ELEMENT_TYPE_SZARRAY
+		0x0f, // ELEMENT_TYPE_PTR
+		0x01, // ELEMENT_TYPE_VOID
+	}
+	r := sig.Reader()
+	spec, err := r.TypeSpec(nil)
+	a.NoError(err)
+	a.Zero(r.Len())
+	a.Equal(ElementType{
+		Kind: ELEMENT_TYPE_SZARRAY,
+		SZArray: ElementTypeSZArray{Elem: &Element{
+			Type:     ElementType{Kind: ELEMENT_TYPE_VOID},
+			Pointers: 1,
+		}},
+	}, spec)
+
+	encoded, err := spec.Encode()
+	a.NoError(err)
+	a.Equal(sig, encoded)
+
+	for _, sig := range []Signature{
+		{},
+		{0x08},             // ELEMENT_TYPE_I4
+		{0x11, 0x09},       // ELEMENT_TYPE_VALUETYPE
+		{0x13, 0x00, 0x08}, // ELEMENT_TYPE_VAR with trailing byte
+	} {
+		_, err := sig.Reader().TypeSpec(nil)
+		a.Error(err, "%x", []byte(sig))
+	}
+}
+
+func TestSignatureReader_Depth(t *testing.T) {
+	a := require.New(t)
+
+	nested := func(depth int) Signature {
+		sig := bytes.Repeat([]byte{0x1d}, depth-1) // ELEMENT_TYPE_SZARRAY
+		return append(sig, 0x08)                   // ELEMENT_TYPE_I4
+	}
+
+	_, err := nested(maxSignatureDepth).Reader().TypeSpec(nil)
+	a.NoError(err)
+
+	for _, sig := range []Signature{
+		nested(maxSignatureDepth + 1),
+		// FNPTR nesting goes through method signature.
+		append(bytes.Repeat([]byte{0x1b, 0x00, 0x00}, maxSignatureDepth+1), 0x01),
+	} {
+		_, err = sig.Reader().TypeSpec(nil)
+		a.ErrorIs(err, ErrBadSignature)
+	}
+}
+
+func TestSignatureReader_Decode(t *testing.T) {
+	tests := []struct {
+		sig    Signature
+		expect SignatureKind
+	}{
+		{Signature{0x00, 0x00, 0x01}, SignatureKindMethod},
+		{Signature{0x20, 0x00, 0x01}, SignatureKindMethod},
+		{Signature{0x05, 0x01, 0x01, 0x41, 0x08}, SignatureKindMethod},
+		{Signature{0x06, 0x08}, SignatureKindField},
+		{Signature{0x28, 0x00, 0x08}, SignatureKindProperty},
+		{Signature{0x07, 0x01, 0x08}, SignatureKindLocalVar},
+		{Signature{0x0a, 0x01, 0x08}, SignatureKindMethodSpec},
+	}
+	for _, test := range tests {
+		t.Run(test.expect.String(), func(t *testing.T) {
+			a := require.New(t)
+
+			r := test.sig.Reader()
+			d, err := r.Decode(nil)
+			a.NoError(err)
+			a.Zero(r.Len())
+			a.Equal(test.expect, d.Kind)
+
+			var encoded Signature
+			switch d.Kind {
+			case SignatureKindMethod:
+				encoded, err = d.Method.Encode()
+			case SignatureKindField:
+				encoded, err = d.Field.Encode()
+			case SignatureKindProperty:
+				encoded, err = d.Property.Encode()
+			case SignatureKindLocalVar:
+				encoded, err = d.LocalVar.Encode()
+			case SignatureKindMethodSpec:
+				encoded, err = d.MethodSpec.Encode()
+			}
+			a.NoError(err)
+			a.Equal(test.sig, encoded)
+		})
+	}
+
+	for _, sig := range []Signature{{}, {0x0b}, {0x0f, 0x00}} {
+		_, err := sig.Reader().Decode(nil)
+		require.ErrorIs(t, err, ErrBadSignature, "%x", []byte(sig))
+	}
+
+	// Kind and Field agree on FIELD with flag bits set.
+	for _, sig := range []Signature{
+		{0x26, 0x08}, // FIELD | HASTHIS
+		{0x86, 0x08}, // FIELD | 0x80
+	} {
+		a := require.New(t)
+
+		_, err := sig.Reader().Kind()
+		a.ErrorIs(err, ErrBadSignature, "%x", []byte(sig))
+		_, err = sig.Reader().Field(nil)
+		a.ErrorIs(err, ErrBadSignature, "%x", []byte(sig))
+	}
+}
+
+func TestSignatureReader_ReadSigned(t *testing.T) {
+	// Examples from II.23.2 Blobs and signatures.
+	tests := []struct {
+		sig    Signature
+		expect int32
+	}{
+		{Signature{0x06}, 3},
+		{Signature{0x7B}, -3},
+		{Signature{0x80, 0x80}, 64},
+		{Signature{0x01}, -64},
+		{Signature{0xC0, 0x00, 0x40, 0x00}, 8192},
+		{Signature{0x80, 0x01}, -8192},
+		{Signature{0xDF, 0xFF, 0xFF, 0xFE}, 268435455},
+		{Signature{0xC0, 0x00, 0x00, 0x01}, -268435456},
+	}
+	for _, test := range tests {
+		a := require.New(t)
+
+		v, ok := test.sig.Reader().ReadSigned()
+		a.True(ok)
+		a.Equal(test.expect, v, "%x", []byte(test.sig))
+
+		var w SignatureWriter
+		a.NoError(w.WriteSigned(test.expect))
+		a.Equal(test.sig, w.Signature())
+	}
 }
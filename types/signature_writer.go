@@ -0,0 +1,381 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SignatureWriter is a helper to encode Signature.
+//
+// Zero value is ready to use.
+type SignatureWriter struct {
+	sig Signature
+}
+
+// Signature returns encoded Signature.
+func (s *SignatureWriter) Signature() Signature {
+	return s.sig
+}
+
+// Write writes unsigned compressed integer to Signature blob.
+//
+// See II.23.2 Blobs and signatures.
+func (s *SignatureWriter) Write(value uint32) error {
+	switch {
+	case value < 0x80:
+		s.sig = append(s.sig, byte(value))
+	case value < 0x4000:
+		s.sig = binary.BigEndian.AppendUint16(s.sig, uint16(value)|0x8000)
+	case value < 0x2000_0000:
+		s.sig = binary.BigEndian.AppendUint32(s.sig, value|0xC000_0000)
+	default:
+		return fmt.Errorf("value %#x is too big to compress", value)
+	}
+	return nil
+}
+
+// WriteSigned writes signed compressed integer to Signature blob.
+//
+// See II.23.2 Blobs and signatures.
+func (s *SignatureWriter) WriteSigned(value int32) error {
+	var sign uint32
+	if value < 0 {
+		sign = 1
+	}
+
+	switch {
+	case value >= -0x40 && value < 0x40:
+		s.sig = append(s.sig, byte((uint32(value)&0x3F)<<1|sign))
+	case value >= -0x2000 && value < 0x2000:
+		s.sig = binary.BigEndian.AppendUint16(s.sig, uint16((uint32(value)&0x1FFF)<<1|sign)|0x8000)
+	case value >= -0x1000_0000 && value < 0x1000_0000:
+		s.sig = binary.BigEndian.AppendUint32(s.sig, (uint32(value)&0x0FFF_FFFF)<<1|sign|0xC000_0000)
+	default:
+		return fmt.Errorf("value %d is too big to compress", value)
+	}
+	return nil
+}
+
+func (s *SignatureWriter) kind(kind ElementTypeKind) {
+	s.sig = append(s.sig, byte(kind))
+}
+
+func (s *SignatureWriter) modifiers(mods []CustomModifier) error {
+	for _, mod := range mods {
+		if mod.Required {
+			s.kind(ELEMENT_TYPE_CMOD_REQD)
+		} else {
+			s.kind(ELEMENT_TYPE_CMOD_OPT)
+		}
+		if err := s.Write(uint32(mod.Type)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SignatureWriter) arrayShape(shape ArrayShape) error {
+	if err := s.Write(shape.Rank); err != nil {
+		return err
+	}
+
+	if err := s.Write(uint32(len(shape.Sizes))); err != nil {
+		return err
+	}
+	for _, size := range shape.Sizes {
+		if err := s.Write(size); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Write(uint32(len(shape.LoBounds))); err != nil {
+		return err
+	}
+	for _, bound := range shape.LoBounds {
+		if err := s.WriteSigned(bound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ElementType writes ElementType to Signature blob.
+func (s *SignatureWriter) ElementType(t ElementType) error {
+	switch t.Kind {
+	case ELEMENT_TYPE_VOID,
+		ELEMENT_TYPE_BOOLEAN,
+		ELEMENT_TYPE_CHAR,
+		ELEMENT_TYPE_I1,
+		ELEMENT_TYPE_U1,
+		ELEMENT_TYPE_I2,
+		ELEMENT_TYPE_U2,
+		ELEMENT_TYPE_I4,
+		ELEMENT_TYPE_U4,
+		ELEMENT_TYPE_I8,
+		ELEMENT_TYPE_U8,
+		ELEMENT_TYPE_R4,
+		ELEMENT_TYPE_R8,
+		ELEMENT_TYPE_I,
+		ELEMENT_TYPE_U,
+		ELEMENT_TYPE_STRING,
+		ELEMENT_TYPE_OBJECT,
+		ELEMENT_TYPE_TYPEDBYREF:
+		s.kind(t.Kind)
+		return nil
+	case ELEMENT_TYPE_VALUETYPE, ELEMENT_TYPE_CLASS:
+		s.kind(t.Kind)
+		return s.Write(uint32(t.TypeDef.Index))
+	case ELEMENT_TYPE_VAR:
+		s.kind(t.Kind)
+		return s.Write(t.GenericTypeVar.Index)
+	case ELEMENT_TYPE_MVAR:
+		s.kind(t.Kind)
+		return s.Write(t.GenericMethodVar.Index)
+	case ELEMENT_TYPE_PTR:
+		if t.Ptr.Elem == nil {
+			return fmt.Errorf("%v: element is nil", t.Kind)
+		}
+		s.kind(t.Kind)
+		return s.Element(*t.Ptr.Elem)
+	case ELEMENT_TYPE_FNPTR:
+		if t.FnPtr.Signature == nil {
+			return fmt.Errorf("%v: signature is nil", t.Kind)
+		}
+		s.kind(t.Kind)
+		return s.Method(*t.FnPtr.Signature)
+	case ELEMENT_TYPE_ARRAY:
+		if t.Array.Elem == nil {
+			return fmt.Errorf("%v: element is nil", t.Kind)
+		}
+		s.kind(t.Kind)
+		if err := s.Element(*t.Array.Elem); err != nil {
+			return err
+		}
+		return s.arrayShape(t.Array.Shape)
+	case ELEMENT_TYPE_GENERICINST:
+		s.kind(t.Kind)
+		if t.TypeDef.IsValueType {
+			s.kind(ELEMENT_TYPE_VALUETYPE)
+		} else {
+			s.kind(ELEMENT_TYPE_CLASS)
+		}
+		if err := s.Write(uint32(t.TypeDef.Index)); err != nil {
+			return err
+		}
+
+		if err := s.Write(uint32(len(t.TypeDef.Generics))); err != nil {
+			return err
+		}
+		for _, arg := range t.TypeDef.Generics {
+			if err := s.ElementType(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ELEMENT_TYPE_SZARRAY:
+		if t.SZArray.Elem == nil {
+			return fmt.Errorf("%v: element is nil", t.Kind)
+		}
+		s.kind(t.Kind)
+		return s.Element(*t.SZArray.Elem)
+	default:
+		return fmt.Errorf("unexpected element type %v", t.Kind)
+	}
+}
+
+// Element writes Element to Signature blob.
+//
+// Modifiers are written at their pointer level, see Element.Modifiers.
+// IsConst and IsArray fields are ignored, since they are derived from
+// Modifiers and Type.
+func (s *SignatureWriter) Element(e Element) error {
+	if len(e.Modifiers) > e.Pointers+1 {
+		return fmt.Errorf("%d modifier levels for %d pointers", len(e.Modifiers), e.Pointers)
+	}
+	// level returns modifiers of given pointer level.
+	level := func(i int) []CustomModifier {
+		if i < len(e.Modifiers) {
+			return e.Modifiers[i]
+		}
+		return nil
+	}
+	if err := s.modifiers(level(0)); err != nil {
+		return err
+	}
+
+	if e.Pinned {
+		s.kind(ELEMENT_TYPE_PINNED)
+	}
+	if e.ByRef {
+		s.kind(ELEMENT_TYPE_BYREF)
+	}
+	for i := 1; i <= e.Pointers; i++ {
+		s.kind(ELEMENT_TYPE_PTR)
+		if err := s.modifiers(level(i)); err != nil {
+			return err
+		}
+	}
+
+	return s.ElementType(e.Type)
+}
+
+// Method writes MethodSignature to Signature blob.
+func (s *SignatureWriter) Method(m MethodSignature) error {
+	const METHOD_DEF_SIG_FLAGS_GENERIC = 0x10
+
+	if err := s.Write(m.Flags); err != nil {
+		return err
+	}
+	if m.Flags&METHOD_DEF_SIG_FLAGS_GENERIC == METHOD_DEF_SIG_FLAGS_GENERIC {
+		if err := s.Write(m.GenericArgCount); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Write(uint32(len(m.Params) + len(m.VarArgs))); err != nil {
+		return err
+	}
+	if err := s.Element(m.Return); err != nil {
+		return fmt.Errorf("return: %w", err)
+	}
+
+	for i, p := range m.Params {
+		if err := s.Element(p); err != nil {
+			return fmt.Errorf("param %d: %w", i, err)
+		}
+	}
+	if len(m.VarArgs) > 0 {
+		s.kind(ELEMENT_TYPE_SENTINEL)
+	}
+	for i, p := range m.VarArgs {
+		if err := s.Element(p); err != nil {
+			return fmt.Errorf("vararg %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Field writes FieldSignature to Signature blob.
+func (s *SignatureWriter) Field(f FieldSignature) error {
+	const FIELD = 0x6
+
+	s.sig = append(s.sig, FIELD)
+	return s.Element(f.Field)
+}
+
+// Property writes PropertySignature to Signature blob.
+func (s *SignatureWriter) Property(p PropertySignature) error {
+	const (
+		PROPERTY = 0x8
+		HASTHIS  = 0x20
+	)
+
+	flags := uint32(PROPERTY)
+	if p.HasThis {
+		flags |= HASTHIS
+	}
+	if err := s.Write(flags); err != nil {
+		return err
+	}
+	if err := s.Write(uint32(len(p.Params))); err != nil {
+		return err
+	}
+	if err := s.Element(p.Type); err != nil {
+		return fmt.Errorf("type: %w", err)
+	}
+
+	for i, param := range p.Params {
+		if err := s.Element(param); err != nil {
+			return fmt.Errorf("param %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// LocalVar writes LocalVarSignature to Signature blob.
+func (s *SignatureWriter) LocalVar(l LocalVarSignature) error {
+	const LOCAL_SIG = 0x7
+
+	s.sig = append(s.sig, LOCAL_SIG)
+	if err := s.Write(uint32(len(l.Locals))); err != nil {
+		return err
+	}
+	for i, local := range l.Locals {
+		if err := s.Element(local); err != nil {
+			return fmt.Errorf("local %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// MethodSpec writes MethodSpecSignature to Signature blob.
+func (s *SignatureWriter) MethodSpec(m MethodSpecSignature) error {
+	const GENERICINST = 0xa
+
+	s.sig = append(s.sig, GENERICINST)
+	if err := s.Write(uint32(len(m.Args))); err != nil {
+		return err
+	}
+	for i, arg := range m.Args {
+		if err := s.ElementType(arg); err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Encode encodes MethodSignature to MethodDefSig or MethodRefSig blob.
+func (m MethodSignature) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.Method(m); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
+
+// Encode encodes FieldSignature to FieldSig blob.
+func (f FieldSignature) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.Field(f); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
+
+// Encode encodes PropertySignature to PropertySig blob.
+func (p PropertySignature) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.Property(p); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
+
+// Encode encodes LocalVarSignature to LocalVarSig blob.
+func (l LocalVarSignature) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.LocalVar(l); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
+
+// Encode encodes MethodSpecSignature to MethodSpec instantiation blob.
+func (m MethodSpecSignature) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.MethodSpec(m); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
+
+// Encode encodes ElementType to Signature blob.
+//
+// Result can be used as II.23.2.14 TypeSpec signature.
+func (t ElementType) Encode() (Signature, error) {
+	var s SignatureWriter
+	if err := s.ElementType(t); err != nil {
+		return nil, err
+	}
+	return s.Signature(), nil
+}
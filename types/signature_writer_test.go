@@ -0,0 +1,187 @@
+package types
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestSignatureWriter_Write(t *testing.T) {
+	// Examples from II.23.2 Blobs and signatures.
+	tests := []struct {
+		value  uint32
+		expect Signature
+	}{
+		{0x03, Signature{0x03}},
+		{0x7F, Signature{0x7F}},
+		{0x80, Signature{0x80, 0x80}},
+		{0x2E57, Signature{0xAE, 0x57}},
+		{0x3FFF, Signature{0xBF, 0xFF}},
+		{0x4000, Signature{0xC0, 0x00, 0x40, 0x00}},
+		{0x1FFFFFFF, Signature{0xDF, 0xFF, 0xFF, 0xFF}},
+	}
+	for _, test := range tests {
+		a := require.New(t)
+
+		var w SignatureWriter
+		a.NoError(w.Write(test.value))
+		a.Equal(test.expect, w.Signature())
+	}
+
+	t.Run("TooBig", func(t *testing.T) {
+		a := require.New(t)
+
+		var w SignatureWriter
+		a.Error(w.Write(0x20000000))
+		a.Error(w.WriteSigned(0x10000000))
+		a.Error(w.WriteSigned(-0x10000001))
+	})
+}
+
+func TestSignatureWriter_RoundTrip(t *testing.T) {
+	t.Run("Unsigned", func(t *testing.T) {
+		a := require.New(t)
+
+		a.NoError(quick.Check(func(v uint32) bool {
+			v &= 0x1FFFFFFF
+
+			var w SignatureWriter
+			if err := w.Write(v); err != nil {
+				return false
+			}
+			r := w.Signature().Reader()
+			got, ok := r.Read()
+			return ok && got == v && r.Len() == 0
+		}, nil))
+	})
+	t.Run("Signed", func(t *testing.T) {
+		a := require.New(t)
+
+		a.NoError(quick.Check(func(v int32) bool {
+			v %= 0x10000000
+
+			var w SignatureWriter
+			if err := w.WriteSigned(v); err != nil {
+				return false
+			}
+			r := w.Signature().Reader()
+			got, ok := r.ReadSigned()
+			return ok && got == v && r.Len() == 0
+		}, nil))
+	})
+}
+
+func TestSignature_RoundTrip(t *testing.T) {
+	type encoder interface {
+		Encode() (Signature, error)
+	}
+	var (
+		method = func(r *SignatureReader) (encoder, error) { return r.Method(nil) }
+		field  = func(r *SignatureReader) (encoder, error) { return r.Field(nil) }
+		spec   = func(r *SignatureReader) (encoder, error) { return r.TypeSpec(nil) }
+	)
+	for _, tt := range []struct {
+		name   string
+		sig    Signature
+		decode func(r *SignatureReader) (encoder, error)
+	}{
+		// int32 (string, uint8*)
+		{"Method", Signature{0x00, 0x02, 0x08, 0x0e, 0x0f, 0x05}, method},
+		// instance !!0 Convert<T>(!!0)
+		{"GenericMethod", Signature{0x30, 0x01, 0x01, 0x1e, 0x00, 0x1e, 0x00}, method},
+		// valuetype TypeDef(1)[]
+		{"Field", Signature{0x06, 0x1d, 0x11, 0x04}, field},
+		// uint16 modreq(TypeRef(1))*
+		{"FieldModifiers", Signature{0x06, 0x0f, 0x1f, 0x05, 0x07}, field},
+		{"Property", Signature{0x28, 0x01, 0x08, 0x0e}, func(r *SignatureReader) (encoder, error) { return r.Property(nil) }},
+		{"LocalVar", Signature{0x07, 0x02, 0x08, 0x0e}, func(r *SignatureReader) (encoder, error) { return r.LocalVar(nil) }},
+		{"MethodSpec", Signature{0x0a, 0x02, 0x0e, 0x13, 0x00}, func(r *SignatureReader) (encoder, error) { return r.MethodSpec(nil) }},
+		// class TypeRef(1)<class TypeRef(2)<!0>>
+		{"GenericInst", Signature{0x15, 0x12, 0x05, 0x01, 0x15, 0x12, 0x09, 0x01, 0x13, 0x00}, spec},
+		// int32[4...,]
+		{"Array", Signature{0x14, 0x08, 0x02, 0x01, 0x04, 0x01, 0x00}, spec},
+		// method void *()
+		{"FnPtr", Signature{0x1b, 0x00, 0x00, 0x01}, spec},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := require.New(t)
+
+			decoded, err := tt.decode(tt.sig.Reader())
+			a.NoError(err)
+			encoded, err := decoded.Encode()
+			a.NoError(err)
+			a.Equal(tt.sig, encoded)
+		})
+	}
+}
+
+func TestElementType_Encode(t *testing.T) {
+	a := require.New(t)
+
+	// IVector<IKeyValuePair<String, T>> TypeSpec.
+	spec := ElementType{
+		Kind: ELEMENT_TYPE_GENERICINST,
+		TypeDef: ElementTypeTypeDef{
+			Index: CreateTypeDefOrRef(md.TypeRef, 4),
+			Generics: []ElementType{
+				{
+					Kind: ELEMENT_TYPE_GENERICINST,
+					TypeDef: ElementTypeTypeDef{
+						Index: CreateTypeDefOrRef(md.TypeRef, 5),
+						Generics: []ElementType{
+							{Kind: ELEMENT_TYPE_STRING},
+							{Kind: ELEMENT_TYPE_VAR},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sig, err := spec.Encode()
+	a.NoError(err)
+	a.Equal(Signature{
+		0x15, 0x12, 0x15, 0x01,
+		0x15, 0x12, 0x19, 0x02, 0x0e, 0x13, 0x00,
+	}, sig)
+
+	r := sig.Reader()
+	decoded, err := r.elementType(nil)
+	a.NoError(err)
+	a.Equal(spec, decoded)
+
+	_, err = ElementType{Kind: ELEMENT_TYPE_SZARRAY}.Encode()
+	a.Error(err)
+	_, err = ElementType{Kind: ELEMENT_TYPE_CMOD_OPT}.Encode()
+	a.Error(err)
+}
+
+func TestFieldSignature_Encode(t *testing.T) {
+	a := require.New(t)
+
+	sig, err := FieldSignature{Field: Element{
+		Type:      ElementType{Kind: ELEMENT_TYPE_U2},
+		Modifiers: [][]CustomModifier{{{Required: true, Type: 0x09}}},
+		Pointers:  2,
+	}}.Encode()
+	a.NoError(err)
+	a.Equal(Signature{0x06, 0x1f, 0x09, 0x0f, 0x0f, 0x07}, sig)
+
+	// Modifiers are written after PTR prefix of their level.
+	sig, err = FieldSignature{Field: Element{
+		Type:      ElementType{Kind: ELEMENT_TYPE_U2},
+		Modifiers: [][]CustomModifier{nil, nil, {{Type: 0x09}}},
+		Pointers:  2,
+	}}.Encode()
+	a.NoError(err)
+	a.Equal(Signature{0x06, 0x0f, 0x0f, 0x20, 0x09, 0x07}, sig)
+
+	_, err = FieldSignature{Field: Element{
+		Type:      ElementType{Kind: ELEMENT_TYPE_U2},
+		Modifiers: [][]CustomModifier{nil, {{Type: 0x09}}},
+	}}.Encode()
+	a.Error(err)
+}
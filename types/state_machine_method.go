@@ -0,0 +1,9 @@
+package types
+
+// StateMachineMethod is a portable PDB StateMachineMethod representation.
+//
+// See Portable PDB v1.0: Format Specification.
+type StateMachineMethod struct {
+	MoveNextMethod Index `table:"MethodDef"`
+	KickoffMethod  Index `table:"MethodDef"`
+}
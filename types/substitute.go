@@ -0,0 +1,164 @@
+package types
+
+import "fmt"
+
+// Substitute returns copy of ElementType with every VAR replaced by typeArgs element and
+// every MVAR replaced by methodArgs element.
+//
+// If typeArgs or methodArgs is nil, corresponding variables are kept, so instantiation
+// can be done in steps.
+func (t ElementType) Substitute(typeArgs, methodArgs []ElementType) (ElementType, error) {
+	switch t.Kind {
+	case ELEMENT_TYPE_VAR:
+		if typeArgs == nil {
+			return t, nil
+		}
+		idx := t.GenericTypeVar.Index
+		if idx >= uint32(len(typeArgs)) {
+			return t, fmt.Errorf("type argument !%d is out of range [0, %d)", idx, len(typeArgs))
+		}
+		return typeArgs[idx], nil
+	case ELEMENT_TYPE_MVAR:
+		if methodArgs == nil {
+			return t, nil
+		}
+		idx := t.GenericMethodVar.Index
+		if idx >= uint32(len(methodArgs)) {
+			return t, fmt.Errorf("method argument !!%d is out of range [0, %d)", idx, len(methodArgs))
+		}
+		return methodArgs[idx], nil
+	case ELEMENT_TYPE_PTR:
+		elem, err := t.Ptr.Elem.Substitute(typeArgs, methodArgs)
+		if err != nil {
+			return t, err
+		}
+		t.Ptr.Elem = &elem
+	case ELEMENT_TYPE_SZARRAY:
+		elem, err := t.SZArray.Elem.Substitute(typeArgs, methodArgs)
+		if err != nil {
+			return t, err
+		}
+		t.SZArray.Elem = &elem
+	case ELEMENT_TYPE_ARRAY:
+		elem, err := t.Array.Elem.Substitute(typeArgs, methodArgs)
+		if err != nil {
+			return t, err
+		}
+		t.Array.Elem = &elem
+	case ELEMENT_TYPE_FNPTR:
+		sig, err := t.FnPtr.Signature.Substitute(typeArgs, methodArgs)
+		if err != nil {
+			return t, err
+		}
+		t.FnPtr.Signature = &sig
+	case ELEMENT_TYPE_GENERICINST:
+		generics := make([]ElementType, len(t.TypeDef.Generics))
+		for i, arg := range t.TypeDef.Generics {
+			s, err := arg.Substitute(typeArgs, methodArgs)
+			if err != nil {
+				return t, err
+			}
+			generics[i] = s
+		}
+		t.TypeDef.Generics = generics
+	}
+	return t, nil
+}
+
+// IsGeneric denotes that ElementType contains VAR or MVAR.
+func (t ElementType) IsGeneric() bool {
+	switch t.Kind {
+	case ELEMENT_TYPE_VAR, ELEMENT_TYPE_MVAR:
+		return true
+	case ELEMENT_TYPE_PTR:
+		return t.Ptr.Elem.Type.IsGeneric()
+	case ELEMENT_TYPE_SZARRAY:
+		return t.SZArray.Elem.Type.IsGeneric()
+	case ELEMENT_TYPE_ARRAY:
+		return t.Array.Elem.Type.IsGeneric()
+	case ELEMENT_TYPE_FNPTR:
+		sig := t.FnPtr.Signature
+		if sig.Return.Type.IsGeneric() {
+			return true
+		}
+		for _, p := range sig.Params {
+			if p.Type.IsGeneric() {
+				return true
+			}
+		}
+		return false
+	case ELEMENT_TYPE_GENERICINST:
+		for _, arg := range t.TypeDef.Generics {
+			if arg.IsGeneric() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Substitute returns copy of Element with substituted type.
+//
+// See ElementType.Substitute.
+func (e Element) Substitute(typeArgs, methodArgs []ElementType) (Element, error) {
+	t, err := e.Type.Substitute(typeArgs, methodArgs)
+	if err != nil {
+		return e, err
+	}
+	e.Type = t
+	e.IsArray = t.Kind == ELEMENT_TYPE_ARRAY
+	return e, nil
+}
+
+func substituteElements(elems []Element, typeArgs, methodArgs []ElementType) ([]Element, error) {
+	if elems == nil {
+		return nil, nil
+	}
+
+	result := make([]Element, len(elems))
+	for i, e := range elems {
+		s, err := e.Substitute(typeArgs, methodArgs)
+		if err != nil {
+			return nil, fmt.Errorf("param %d: %w", i, err)
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// Substitute returns copy of MethodSignature with substituted return and parameter types.
+//
+// See ElementType.Substitute.
+func (m MethodSignature) Substitute(typeArgs, methodArgs []ElementType) (MethodSignature, error) {
+	ret, err := m.Return.Substitute(typeArgs, methodArgs)
+	if err != nil {
+		return m, fmt.Errorf("return: %w", err)
+	}
+	m.Return = ret
+
+	if m.Params, err = substituteElements(m.Params, typeArgs, methodArgs); err != nil {
+		return m, err
+	}
+	if m.VarArgs, err = substituteElements(m.VarArgs, typeArgs, methodArgs); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// Substitute returns copy of PropertySignature with substituted type and parameter types.
+//
+// See ElementType.Substitute.
+func (p PropertySignature) Substitute(typeArgs []ElementType) (PropertySignature, error) {
+	typ, err := p.Type.Substitute(typeArgs, nil)
+	if err != nil {
+		return p, err
+	}
+	p.Type = typ
+
+	if p.Params, err = substituteElements(p.Params, typeArgs, nil); err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestElementType_Substitute(t *testing.T) {
+	var (
+		str     = ElementType{Kind: ELEMENT_TYPE_STRING}
+		i4      = ElementType{Kind: ELEMENT_TYPE_I4}
+		typeVar = func(idx uint32) ElementType {
+			return ElementType{Kind: ELEMENT_TYPE_VAR, GenericTypeVar: ElementTypeGenericTypeVar{Index: idx}}
+		}
+		methodVar = func(idx uint32) ElementType {
+			return ElementType{Kind: ELEMENT_TYPE_MVAR, GenericMethodVar: ElementTypeGenericMethodVar{Index: idx}}
+		}
+		genericInst = func(args ...ElementType) ElementType {
+			return ElementType{Kind: ELEMENT_TYPE_GENERICINST, TypeDef: ElementTypeTypeDef{
+				Index:    CreateTypeDefOrRef(md.TypeRef, 1),
+				Generics: args,
+			}}
+		}
+		szArray = func(elem ElementType) ElementType {
+			return ElementType{Kind: ELEMENT_TYPE_SZARRAY, SZArray: ElementTypeSZArray{Elem: &Element{Type: elem}}}
+		}
+	)
+
+	tests := []struct {
+		name       string
+		input      ElementType
+		typeArgs   []ElementType
+		methodArgs []ElementType
+		expect     ElementType
+	}{
+		{"Primitive", i4, []ElementType{str}, nil, i4},
+		{"Var", typeVar(1), []ElementType{str, i4}, nil, i4},
+		{"KeepVar", typeVar(0), nil, []ElementType{str}, typeVar(0)},
+		{"MethodVar", methodVar(0), []ElementType{i4}, []ElementType{str}, str},
+		{"KeepMethodVar", methodVar(0), []ElementType{i4}, nil, methodVar(0)},
+		{"Array", szArray(typeVar(0)), []ElementType{str}, nil, szArray(str)},
+		{
+			"Nested",
+			genericInst(genericInst(typeVar(0), methodVar(0))),
+			[]ElementType{str},
+			[]ElementType{szArray(i4)},
+			genericInst(genericInst(str, szArray(i4))),
+		},
+		{
+			"VarToGeneric",
+			genericInst(typeVar(0)),
+			[]ElementType{genericInst(typeVar(0))},
+			nil,
+			genericInst(genericInst(typeVar(0))),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := require.New(t)
+
+			input, err := test.input.Encode()
+			a.NoError(err)
+
+			result, err := test.input.Substitute(test.typeArgs, test.methodArgs)
+			a.NoError(err)
+			a.Equal(test.expect, result)
+
+			// Input must not be modified.
+			again, err := test.input.Encode()
+			a.NoError(err)
+			a.Equal(input, again)
+		})
+	}
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		a := require.New(t)
+
+		_, err := typeVar(1).Substitute([]ElementType{str}, nil)
+		a.Error(err)
+		_, err = szArray(methodVar(0)).Substitute(nil, []ElementType{})
+		a.Error(err)
+	})
+	t.Run("IsGeneric", func(t *testing.T) {
+		a := require.New(t)
+
+		a.False(i4.IsGeneric())
+		a.True(typeVar(0).IsGeneric())
+		a.True(genericInst(str, szArray(methodVar(0))).IsGeneric())
+		a.False(genericInst(str, szArray(i4)).IsGeneric())
+	})
+}
+
+func TestMethodSignature_Substitute(t *testing.T) {
+	a := require.New(t)
+
+	// !!0 Convert<U>(!0, !0*)
+	sig := Signature{0x30, 0x01, 0x02, 0x1e, 0x00, 0x13, 0x00, 0x0f, 0x13, 0x00}
+	m, err := sig.Reader().Method(nil)
+	a.NoError(err)
+
+	result, err := m.Substitute(
+		[]ElementType{{Kind: ELEMENT_TYPE_I4}},
+		[]ElementType{{Kind: ELEMENT_TYPE_STRING}},
+	)
+	a.NoError(err)
+
+	encoded, err := result.Encode()
+	a.NoError(err)
+	a.Equal(Signature{0x30, 0x01, 0x02, 0x0e, 0x08, 0x0f, 0x08}, encoded)
+
+	// Original signature is not modified.
+	encoded, err = m.Encode()
+	a.NoError(err)
+	a.Equal(sig, encoded)
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// typeKey is a key of type name index.
+type typeKey struct {
+	Namespace string
+	Name      string
+	// Enclosing is an enclosing TypeDef index plus one, zero for top-level types.
+	Enclosing uint32
+}
+
+// typeIndex is a TypeDef name index.
+type typeIndex struct {
+	types      map[typeKey][]Index
+	namespaces map[string][]Index
+}
+
+func (t *Context) buildIndex() (typeIndex, error) {
+	typeDefs := t.Table(md.TypeDef)
+	count := typeDefs.RowCount()
+
+	// Collect enclosing classes first.
+	enclosing := make(map[Index]uint32)
+	nestedClasses := t.Table(md.NestedClass)
+	var class NestedClass
+	for i := uint32(0); i < nestedClasses.RowCount(); i++ {
+		if err := class.FromRow(nestedClasses.Row(i)); err != nil {
+			return typeIndex{}, err
+		}
+		enclosing[class.NestedClass-1] = class.EnclosingClass
+	}
+
+	idx := typeIndex{
+		types:      make(map[typeKey][]Index, count),
+		namespaces: map[string][]Index{},
+	}
+	for i := uint32(0); i < count; i++ {
+		row := typeDefs.Row(i)
+
+		namespace, name, err := resolveTypeName(row)
+		if err != nil {
+			return typeIndex{}, err
+		}
+
+		key := typeKey{Namespace: namespace, Name: name}
+		if e, ok := enclosing[i]; ok {
+			// Namespace of nested type does not matter.
+			key = typeKey{Name: name, Enclosing: e}
+		} else {
+			idx.namespaces[namespace] = append(idx.namespaces[namespace], i)
+		}
+		idx.types[key] = append(idx.types[key], i)
+	}
+
+	return idx, nil
+}
+
+func (t *Context) typeIndex() (typeIndex, error) {
+	t.indexOnce.Do(func() {
+		t.index, t.indexErr = t.buildIndex()
+	})
+	return t.index, t.indexErr
+}
+
+// FindTypeDefs returns indexes of all top-level TypeDefs with given namespace and name.
+func (t *Context) FindTypeDefs(namespace, name string) ([]Index, error) {
+	idx, err := t.typeIndex()
+	if err != nil {
+		return nil, err
+	}
+	return idx.types[typeKey{Namespace: namespace, Name: name}], nil
+}
+
+// FindTypeDef returns index of top-level TypeDef with given namespace and name.
+// If there is no such TypeDef, ok is false.
+//
+// If there are several such TypeDefs, e.g. defined for different architectures, the first one
+// is returned. Use FindTypeDefArch to select TypeDef by architecture.
+func (t *Context) FindTypeDef(namespace, name string) (_ Index, ok bool, _ error) {
+	return t.FindTypeDefArch(namespace, name, ArchitectureAny)
+}
+
+// FindNestedTypeDef returns index of TypeDef with given name nested into given enclosing TypeDef.
+// If there is no such TypeDef, ok is false.
+func (t *Context) FindNestedTypeDef(enclosing Index, name string) (_ Index, ok bool, _ error) {
+	return t.FindNestedTypeDefArch(enclosing, name, ArchitectureAny)
+}
+
+// Namespaces returns sorted list of namespaces, which contain top-level TypeDefs.
+func (t *Context) Namespaces() ([]string, error) {
+	idx, err := t.typeIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(idx.namespaces))
+	for ns := range idx.namespaces {
+		result = append(result, ns)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// NamespaceTypeDefs returns indexes of all top-level TypeDefs in given namespace.
+func (t *Context) NamespaceTypeDefs(namespace string) ([]Index, error) {
+	idx, err := t.typeIndex()
+	if err != nil {
+		return nil, err
+	}
+	return idx.namespaces[namespace], nil
+}
+
+// ResolveTypeRef finds TypeDef index referenced by given TypeRef index.
+//
+// Nested TypeRefs are resolved through ResolutionScope. Other resolution scopes are ignored, so
+// TypeRef is resolved by name in this Context.
+// If TypeDef is not defined in this Context, ok is false.
+func (t *Context) ResolveTypeRef(ref Index) (_ Index, ok bool, _ error) {
+	return t.ResolveTypeRefArch(ref, ArchitectureAny)
+}
+
+// ResolveTypeRefArch finds TypeDef index referenced by given TypeRef index, which is available
+// on target architecture.
+//
+// See ResolveTypeRef and FindTypeDefArch.
+func (t *Context) ResolveTypeRefArch(ref Index, target Architecture) (_ Index, ok bool, _ error) {
+	var r TypeRef
+	if err := r.FromRow(t.Table(md.TypeRef).Row(ref)); err != nil {
+		return 0, false, err
+	}
+
+	if tt, ok := r.ResolutionScope.Table(); ok && tt == md.TypeRef {
+		enclosing, ok, err := t.ResolveTypeRefArch(r.ResolutionScope.TableIndex(), target)
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		return t.FindNestedTypeDefArch(enclosing, r.TypeName, target)
+	}
+
+	return t.FindTypeDefArch(r.TypeNamespace, r.TypeName, target)
+}
+
+// ResolveTypeDefOrRef finds TypeDef index referenced by given TypeDefOrRef.
+// If TypeDef is not defined in this Context, ok is false.
+func (t *Context) ResolveTypeDefOrRef(ref TypeDefOrRef) (_ Index, ok bool, _ error) {
+	return t.ResolveTypeDefOrRefArch(ref, ArchitectureAny)
+}
+
+// ResolveTypeDefOrRefArch finds TypeDef index referenced by given TypeDefOrRef, which is available
+// on target architecture.
+//
+// TypeDef references are returned as is. See ResolveTypeRefArch.
+func (t *Context) ResolveTypeDefOrRefArch(ref TypeDefOrRef, target Architecture) (_ Index, ok bool, _ error) {
+	tt, ok := ref.Table()
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected tag %v", ref)
+	}
+
+	switch tt {
+	case md.TypeDef:
+		return ref.TableIndex(), true, nil
+	case md.TypeRef:
+		return t.ResolveTypeRefArch(ref.TableIndex(), target)
+	default:
+		return 0, false, fmt.Errorf("can't resolve %v to TypeDef", ref)
+	}
+}
@@ -0,0 +1,516 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// AssemblyVersion is a decoded Assembly or AssemblyRef version.
+type AssemblyVersion struct {
+	Major    uint16
+	Minor    uint16
+	Build    uint16
+	Revision uint16
+}
+
+// ParseAssemblyVersion decodes version column of Assembly or AssemblyRef table.
+func ParseAssemblyVersion(v uint64) AssemblyVersion {
+	return AssemblyVersion{
+		Major:    uint16(v),
+		Minor:    uint16(v >> 16),
+		Build:    uint16(v >> 32),
+		Revision: uint16(v >> 48),
+	}
+}
+
+// Less reports whether v is lower than b.
+func (v AssemblyVersion) Less(b AssemblyVersion) bool {
+	if v.Major != b.Major {
+		return v.Major < b.Major
+	}
+	if v.Minor != b.Minor {
+		return v.Minor < b.Minor
+	}
+	if v.Build != b.Build {
+		return v.Build < b.Build
+	}
+	return v.Revision < b.Revision
+}
+
+// String implements fmt.Stringer.
+func (v AssemblyVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Build, v.Revision)
+}
+
+// UnresolvedAssemblyError is returned when referenced assembly is not loaded into Universe.
+type UnresolvedAssemblyError struct {
+	Name    string
+	Version AssemblyVersion
+}
+
+// Error implements error.
+func (e *UnresolvedAssemblyError) Error() string {
+	return fmt.Sprintf("assembly %s %s is not loaded", e.Name, e.Version)
+}
+
+// UnresolvedModuleError is returned when referenced module is not loaded into Universe.
+type UnresolvedModuleError struct {
+	Name string
+}
+
+// Error implements error.
+func (e *UnresolvedModuleError) Error() string {
+	return fmt.Sprintf("module %s is not loaded", e.Name)
+}
+
+// UnresolvedTypeError is returned when referenced type is not defined in file which should define it.
+type UnresolvedTypeError struct {
+	// Namespace and Name of referenced type.
+	//
+	// Name of nested type is prefixed by enclosing type name and '/'.
+	Namespace string
+	Name      string
+}
+
+// Error implements error.
+func (e *UnresolvedTypeError) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("type %s not found", e.Name)
+	}
+	return fmt.Sprintf("type %s.%s not found", e.Namespace, e.Name)
+}
+
+// windowsRuntimeVersion is a version of placeholder "Windows" assembly, which is referenced
+// by Windows Runtime metadata files instead of assembly which actually defines the type.
+var windowsRuntimeVersion = AssemblyVersion{Major: 255, Minor: 255, Build: 255, Revision: 255}
+
+// IsWindowsRuntime reports whether AssemblyRef refers to Windows Runtime metadata.
+//
+// Such references do not name the file which defines the type, so the type
+// must be found by its namespace and name in all files.
+func (f *AssemblyRef) IsWindowsRuntime() bool {
+	return f.Flags.WindowsRuntime() ||
+		(f.Name == "Windows" && ParseAssemblyVersion(f.Version) == windowsRuntimeVersion)
+}
+
+// ResolvedType is a TypeDef found in one of Universe files.
+type ResolvedType struct {
+	Context *Context
+	Index   Index
+}
+
+// TypeDef decodes resolved TypeDef row.
+func (r ResolvedType) TypeDef() (TypeDef, error) {
+	var def TypeDef
+	if err := def.FromRow(r.Context.Table(md.TypeDef).Row(r.Index)); err != nil {
+		return def, err
+	}
+	return def, nil
+}
+
+type universeAssembly struct {
+	Version AssemblyVersion
+	Context *Context
+}
+
+// Universe is a set of metadata files, which allows resolving types across files.
+//
+// Universe is safe for concurrent use by multiple goroutines, once all files are added.
+type Universe struct {
+	files      []*Context
+	assemblies map[string][]universeAssembly
+	modules    map[string]*Context
+	closers    []io.Closer
+}
+
+// NewUniverse creates new Universe from given files.
+func NewUniverse(files ...*Context) (*Universe, error) {
+	u := &Universe{
+		assemblies: map[string][]universeAssembly{},
+		modules:    map[string]*Context{},
+	}
+	for _, c := range files {
+		if err := u.Add(c); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// OpenUniverse opens given metadata files and creates new Universe from them.
+//
+// Universe must be closed after use.
+func OpenUniverse(paths ...string) (_ *Universe, rErr error) {
+	u, err := NewUniverse()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rErr != nil {
+			_ = u.Close()
+		}
+	}()
+
+	for _, p := range paths {
+		c, err := Open(p)
+		if err != nil {
+			return nil, fmt.Errorf("open %q: %w", p, err)
+		}
+		u.closers = append(u.closers, c)
+
+		if err := u.Add(c); err != nil {
+			return nil, fmt.Errorf("add %q: %w", p, err)
+		}
+	}
+
+	return u, nil
+}
+
+// Close closes files opened by OpenUniverse.
+func (u *Universe) Close() error {
+	var errs []error
+	for _, c := range u.closers {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	u.closers = nil
+	return errors.Join(errs...)
+}
+
+// Add adds file to Universe.
+func (u *Universe) Add(c *Context) error {
+	if c.RowCount(md.Assembly) > 0 {
+		var a Assembly
+		if err := a.FromRow(c.Table(md.Assembly).Row(0)); err != nil {
+			return fmt.Errorf("decode assembly: %w", err)
+		}
+
+		key := strings.ToLower(a.Name)
+		u.assemblies[key] = append(u.assemblies[key], universeAssembly{
+			Version: ParseAssemblyVersion(a.Version),
+			Context: c,
+		})
+	}
+
+	if c.RowCount(md.Module) > 0 {
+		var m Module
+		if err := m.FromRow(c.Table(md.Module).Row(0)); err != nil {
+			return fmt.Errorf("decode module: %w", err)
+		}
+		u.modules[strings.ToLower(m.Name)] = c
+	}
+
+	u.files = append(u.files, c)
+	return nil
+}
+
+// Files returns all files in Universe.
+func (u *Universe) Files() []*Context {
+	return u.files
+}
+
+// Assembly finds file of assembly with given name and version.
+//
+// If there is no exact version match, the newest loaded assembly with higher version is used.
+func (u *Universe) Assembly(name string, version AssemblyVersion) (*Context, error) {
+	var best *universeAssembly
+	for i, candidate := range u.assemblies[strings.ToLower(name)] {
+		if candidate.Version == version {
+			return candidate.Context, nil
+		}
+		if candidate.Version.Less(version) {
+			continue
+		}
+		if best == nil || best.Version.Less(candidate.Version) {
+			best = &u.assemblies[strings.ToLower(name)][i]
+		}
+	}
+
+	if best == nil {
+		return nil, &UnresolvedAssemblyError{Name: name, Version: version}
+	}
+	return best.Context, nil
+}
+
+// ResolveScope finds file which defines types with given ResolutionScope.
+//
+// Nested types (TypeRef scope) are resolved to file of enclosing type. Windows Runtime
+// AssemblyRef does not denote a single file, use ResolveTypeRef to resolve such types.
+func (u *Universe) ResolveScope(c *Context, scope ResolutionScope) (*Context, error) {
+	if scope == 0 {
+		// Null scope, type is defined in the same file.
+		return c, nil
+	}
+
+	row, ok := scope.Row(c)
+	if !ok {
+		return nil, fmt.Errorf("unexpected tag %v", scope)
+	}
+
+	switch row.Table.Type {
+	case md.Module:
+		return c, nil
+	case md.ModuleRef:
+		var ref ModuleRef
+		if err := ref.FromRow(row); err != nil {
+			return nil, err
+		}
+		m, ok := u.modules[strings.ToLower(ref.Name)]
+		if !ok {
+			return nil, &UnresolvedModuleError{Name: ref.Name}
+		}
+		return m, nil
+	case md.AssemblyRef:
+		var ref AssemblyRef
+		if err := ref.FromRow(row); err != nil {
+			return nil, err
+		}
+		if ref.IsWindowsRuntime() {
+			return nil, fmt.Errorf("assembly reference %s to Windows Runtime metadata can't be resolved to a single file", ref.Name)
+		}
+		return u.Assembly(ref.Name, ParseAssemblyVersion(ref.Version))
+	case md.TypeRef:
+		enclosing, err := u.ResolveTypeRef(c, row.Row)
+		if err != nil {
+			return nil, err
+		}
+		return enclosing.Context, nil
+	default:
+		return nil, fmt.Errorf("unexpected resolution scope %v", scope)
+	}
+}
+
+// ResolveTypeRef finds TypeDef referenced by given TypeRef index of given file.
+func (u *Universe) ResolveTypeRef(c *Context, ref Index) (ResolvedType, error) {
+	return u.ResolveTypeRefArch(c, ref, ArchitectureAny)
+}
+
+// ResolveTypeRefArch finds TypeDef referenced by given TypeRef index of given file, which is
+// available on target architecture.
+//
+// Type forwarders (ExportedType rows) of resolved file are followed to other assemblies
+// and files. If TypeDef is not found, UnresolvedTypeError is returned. If TypeDef is
+// defined only for other architectures, ArchitectureError is returned.
+func (u *Universe) ResolveTypeRefArch(c *Context, ref Index, target Architecture) (ResolvedType, error) {
+	var r TypeRef
+	if err := r.FromRow(c.Table(md.TypeRef).Row(ref)); err != nil {
+		return ResolvedType{}, err
+	}
+
+	if tt, ok := r.ResolutionScope.Table(); ok && tt == md.TypeRef && r.ResolutionScope != 0 {
+		enclosing, err := u.ResolveTypeRefArch(c, r.ResolutionScope.TableIndex(), target)
+		if err != nil {
+			return ResolvedType{}, err
+		}
+
+		idx, ok, err := enclosing.Context.FindNestedTypeDefArch(enclosing.Index, r.TypeName, target)
+		if err != nil {
+			return ResolvedType{}, err
+		}
+		if !ok {
+			def, err := enclosing.TypeDef()
+			if err != nil {
+				return ResolvedType{}, err
+			}
+			return ResolvedType{}, &UnresolvedTypeError{
+				Namespace: def.TypeNamespace,
+				Name:      def.TypeName + "/" + r.TypeName,
+			}
+		}
+		return ResolvedType{Context: enclosing.Context, Index: idx}, nil
+	}
+
+	winrt, err := u.isWindowsRuntimeScope(c, r.ResolutionScope)
+	if err != nil {
+		return ResolvedType{}, fmt.Errorf("resolve %s.%s: %w", r.TypeNamespace, r.TypeName, err)
+	}
+	if winrt {
+		// Type may be defined in any Windows Runtime file.
+		resolved, ok, err := u.FindTypeDefArch(r.TypeNamespace, r.TypeName, target)
+		if err != nil {
+			return ResolvedType{}, err
+		}
+		if !ok {
+			return ResolvedType{}, &UnresolvedTypeError{Namespace: r.TypeNamespace, Name: r.TypeName}
+		}
+		return resolved, nil
+	}
+
+	file, err := u.ResolveScope(c, r.ResolutionScope)
+	if err != nil {
+		return ResolvedType{}, fmt.Errorf("resolve %s.%s: %w", r.TypeNamespace, r.TypeName, err)
+	}
+
+	return u.findForwardedTypeDef(file, r.TypeNamespace, r.TypeName, target)
+}
+
+// maxForwards limits length of ExportedType forwarder chain, which may be cyclic in malformed files.
+const maxForwards = 8
+
+// findForwardedTypeDef finds TypeDef in given file, following ExportedType forwarders
+// to other assemblies and files of the same assembly.
+func (u *Universe) findForwardedTypeDef(c *Context, namespace, name string, target Architecture) (ResolvedType, error) {
+	for range maxForwards {
+		idx, ok, err := c.FindTypeDefArch(namespace, name, target)
+		if err != nil {
+			return ResolvedType{}, err
+		}
+		if ok {
+			return ResolvedType{Context: c, Index: idx}, nil
+		}
+
+		exported, ok, err := c.findExportedType(namespace, name)
+		if err != nil {
+			return ResolvedType{}, err
+		}
+		if !ok {
+			return ResolvedType{}, &UnresolvedTypeError{Namespace: namespace, Name: name}
+		}
+
+		c, err = u.resolveImplementation(c, exported.Implementation)
+		if err != nil {
+			return ResolvedType{}, fmt.Errorf("forward %s.%s: %w", namespace, name, err)
+		}
+	}
+	return ResolvedType{}, fmt.Errorf("forward %s.%s: too many forwarders", namespace, name)
+}
+
+// findExportedType finds top-level ExportedType with given namespace and name.
+func (t *Context) findExportedType(namespace, name string) (e ExportedType, ok bool, _ error) {
+	table := t.Table(md.ExportedType)
+	for i := uint32(0); i < table.RowCount(); i++ {
+		if err := e.FromRow(table.Row(i)); err != nil {
+			return e, false, err
+		}
+		if tt, ok := e.Implementation.Table(); ok && tt == md.ExportedType {
+			continue
+		}
+		if e.TypeNamespace == namespace && e.TypeName == name {
+			return e, true, nil
+		}
+	}
+	return ExportedType{}, false, nil
+}
+
+// resolveImplementation finds file referenced by ExportedType Implementation.
+func (u *Universe) resolveImplementation(c *Context, impl Implementation) (*Context, error) {
+	row, ok := impl.Row(c)
+	if !ok {
+		return nil, fmt.Errorf("unexpected tag %v", impl)
+	}
+
+	switch row.Table.Type {
+	case md.File:
+		var f File
+		if err := f.FromRow(row); err != nil {
+			return nil, err
+		}
+		m, ok := u.modules[strings.ToLower(f.Name)]
+		if !ok {
+			return nil, &UnresolvedModuleError{Name: f.Name}
+		}
+		return m, nil
+	case md.AssemblyRef:
+		var ref AssemblyRef
+		if err := ref.FromRow(row); err != nil {
+			return nil, err
+		}
+		return u.Assembly(ref.Name, ParseAssemblyVersion(ref.Version))
+	default:
+		return nil, fmt.Errorf("unexpected implementation %v", impl)
+	}
+}
+
+// isWindowsRuntimeScope reports whether given ResolutionScope is a Windows Runtime AssemblyRef.
+func (u *Universe) isWindowsRuntimeScope(c *Context, scope ResolutionScope) (bool, error) {
+	if tt, ok := scope.Table(); !ok || tt != md.AssemblyRef || scope.IsNull() {
+		return false, nil
+	}
+
+	var ref AssemblyRef
+	if err := ref.FromRow(c.Table(md.AssemblyRef).Row(scope.TableIndex())); err != nil {
+		return false, err
+	}
+	return ref.IsWindowsRuntime(), nil
+}
+
+// ResolveTypeDefOrRef finds TypeDef referenced by given TypeDefOrRef of given file.
+func (u *Universe) ResolveTypeDefOrRef(c *Context, ref TypeDefOrRef) (ResolvedType, error) {
+	return u.ResolveTypeDefOrRefArch(c, ref, ArchitectureAny)
+}
+
+// ResolveTypeDefOrRefArch finds TypeDef referenced by given TypeDefOrRef of given file, which is
+// available on target architecture.
+//
+// TypeDef references are returned as is. See ResolveTypeRefArch.
+func (u *Universe) ResolveTypeDefOrRefArch(c *Context, ref TypeDefOrRef, target Architecture) (ResolvedType, error) {
+	tt, ok := ref.Table()
+	if !ok {
+		return ResolvedType{}, fmt.Errorf("unexpected tag %v", ref)
+	}
+
+	switch tt {
+	case md.TypeDef:
+		return ResolvedType{Context: c, Index: ref.TableIndex()}, nil
+	case md.TypeRef:
+		return u.ResolveTypeRefArch(c, ref.TableIndex(), target)
+	default:
+		return ResolvedType{}, fmt.Errorf("can't resolve %v to TypeDef", ref)
+	}
+}
+
+// FindTypeDef finds top-level TypeDef with given namespace and name in all files.
+// If there is no such TypeDef, ok is false.
+func (u *Universe) FindTypeDef(namespace, name string) (_ ResolvedType, ok bool, _ error) {
+	return u.FindTypeDefArch(namespace, name, ArchitectureAny)
+}
+
+// FindTypeDefArch finds top-level TypeDef with given namespace and name in all files, which is
+// available on target architecture.
+//
+// If there is no such TypeDef, ok is false. If TypeDef is defined only for other architectures,
+// ArchitectureError is returned.
+func (u *Universe) FindTypeDefArch(namespace, name string, target Architecture) (_ ResolvedType, ok bool, _ error) {
+	var archErr *ArchitectureError
+	for _, c := range u.files {
+		idx, ok, err := c.FindTypeDefArch(namespace, name, target)
+		if e := (*ArchitectureError)(nil); errors.As(err, &e) {
+			if archErr != nil {
+				e.Supported |= archErr.Supported
+			}
+			archErr = e
+			continue
+		}
+		if err != nil {
+			return ResolvedType{}, false, err
+		}
+		if ok {
+			return ResolvedType{Context: c, Index: idx}, true, nil
+		}
+	}
+	if archErr != nil {
+		return ResolvedType{}, false, archErr
+	}
+	return ResolvedType{}, false, nil
+}
+
+// ResolveEnum finds underlying type of enum with given name in all files.
+//
+// Can be used as CustomAttributeDecoder.ResolveEnum.
+func (u *Universe) ResolveEnum(namespace, name string) (ElementTypeKind, error) {
+	for _, c := range u.files {
+		kind, ok, err := c.enumUnderlyingType(namespace, name)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("enum %s.%s not found", namespace, name)
+}
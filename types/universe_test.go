@@ -0,0 +1,192 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+func TestParseAssemblyVersion(t *testing.T) {
+	a := require.New(t)
+
+	v := ParseAssemblyVersion(0x0004_0003_0002_0001)
+	a.Equal(AssemblyVersion{Major: 1, Minor: 2, Build: 3, Revision: 4}, v)
+	a.Equal("1.2.3.4", v.String())
+
+	a.True(v.Less(AssemblyVersion{Major: 1, Minor: 2, Build: 3, Revision: 5}))
+	a.True(v.Less(AssemblyVersion{Major: 2}))
+	a.False(v.Less(v))
+	a.False(v.Less(AssemblyVersion{Major: 1, Minor: 1, Build: 9, Revision: 9}))
+}
+
+const (
+	foundationContract = "Windows.Foundation.FoundationContract"
+	universalContract  = "Windows.Foundation.UniversalApiContract"
+)
+
+// winmdWriter creates Windows Runtime metadata file of given assembly, which defines given interface.
+//
+// Returns index of AssemblyRef to other Windows Runtime files.
+func winmdWriter(assembly, namespace, name string) (*Writer, Index) {
+	w := NewWriter()
+	(&Module{Name: assembly + ".winmd"}).AppendTo(w)
+	(&Assembly{Name: assembly, Version: 1}).AppendTo(w)
+	windows := (&AssemblyRef{
+		Name:    "Windows",
+		Version: 0x00ff_00ff_00ff_00ff,
+		Flags:   0x200, // ContentType = WindowsRuntime
+	}).AppendTo(w)
+
+	(&TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	(&TypeDef{
+		Flags:         0x40a1, // Public | Interface | Abstract | WindowsRuntime
+		TypeName:      name,
+		TypeNamespace: namespace,
+	}).AppendTo(w)
+	return w, windows
+}
+
+func TestUniverse_ResolveTypeRef(t *testing.T) {
+	a := require.New(t)
+
+	// Both files reference each other through placeholder "Windows" assembly.
+	foundation, windows := winmdWriter(foundationContract, "Windows.Foundation", "IStringable")
+	vectorRef := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.AssemblyRef, windows-1),
+		TypeName:        "IVector`1",
+		TypeNamespace:   "Windows.Foundation.Collections",
+	}).AppendTo(foundation)
+	missing := (&ModuleRef{Name: "Missing.winmd"}).AppendTo(foundation)
+	missingRef := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.ModuleRef, missing-1),
+		TypeName:        "IMissing",
+		TypeNamespace:   "Windows.Foundation",
+	}).AppendTo(foundation)
+
+	universal, windows := winmdWriter(universalContract, "Windows.Foundation.Collections", "IVector`1")
+	stringableRef := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.AssemblyRef, windows-1),
+		TypeName:        "IStringable",
+		TypeNamespace:   "Windows.Foundation",
+	}).AppendTo(universal)
+	module := (&ModuleRef{Name: foundationContract + ".winmd"}).AppendTo(universal)
+	moduleRef := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.ModuleRef, module-1),
+		TypeName:        "IStringable",
+		TypeNamespace:   "Windows.Foundation",
+	}).AppendTo(universal)
+
+	var (
+		foundationFile = readPE(a, writePE(a, foundation))
+		universalFile  = readPE(a, writePE(a, universal))
+	)
+	u, err := NewUniverse(foundationFile, universalFile)
+	a.NoError(err)
+
+	for _, tt := range []struct {
+		file   *Context
+		ref    Index
+		expect *Context
+		name   string
+	}{
+		{foundationFile, vectorRef - 1, universalFile, "IVector`1"},
+		{universalFile, stringableRef - 1, foundationFile, "IStringable"},
+		{universalFile, moduleRef - 1, foundationFile, "IStringable"},
+	} {
+		r, err := u.ResolveTypeRef(tt.file, tt.ref)
+		a.NoError(err)
+		a.Same(tt.expect, r.Context)
+
+		def, err := r.TypeDef()
+		a.NoError(err)
+		a.Equal(tt.name, def.TypeName)
+	}
+
+	_, err = u.ResolveTypeRef(foundationFile, missingRef-1)
+	var unresolved *UnresolvedModuleError
+	a.True(errors.As(err, &unresolved))
+	a.Equal("Missing.winmd", unresolved.Name)
+}
+
+func TestUniverse_ResolveTypeRef_Forwarded(t *testing.T) {
+	a := require.New(t)
+
+	foundation, _ := winmdWriter(foundationContract, "Windows.Foundation", "IStringable")
+
+	// Forwarder assembly forwards IStringable to the defining assembly.
+	forwarder := NewWriter()
+	(&Module{Name: "Forwarder.dll"}).AppendTo(forwarder)
+	(&Assembly{Name: "Forwarder", Version: 1}).AppendTo(forwarder)
+	target := (&AssemblyRef{Name: foundationContract, Version: 1}).AppendTo(forwarder)
+	(&ExportedType{
+		Flags:          0x200000, // Forwarder
+		TypeName:       "IStringable",
+		TypeNamespace:  "Windows.Foundation",
+		Implementation: CreateImplementation(md.AssemblyRef, target-1),
+	}).AppendTo(forwarder)
+
+	user := NewWriter()
+	(&Module{Name: "User.dll"}).AppendTo(user)
+	scope := CreateResolutionScope(md.AssemblyRef, (&AssemblyRef{Name: "Forwarder", Version: 1}).AppendTo(user)-1)
+	stringableRef := (&TypeRef{
+		ResolutionScope: scope,
+		TypeName:        "IStringable",
+		TypeNamespace:   "Windows.Foundation",
+	}).AppendTo(user)
+	missingRef := (&TypeRef{
+		ResolutionScope: scope,
+		TypeName:        "IMissing",
+		TypeNamespace:   "Windows.Foundation",
+	}).AppendTo(user)
+
+	var (
+		foundationFile = readPE(a, writePE(a, foundation))
+		userFile       = readPE(a, writePE(a, user))
+	)
+	u, err := NewUniverse(foundationFile, readPE(a, writePE(a, forwarder)), userFile)
+	a.NoError(err)
+
+	r, err := u.ResolveTypeRef(userFile, stringableRef-1)
+	a.NoError(err)
+	a.Same(foundationFile, r.Context)
+
+	_, err = u.ResolveTypeRef(userFile, missingRef-1)
+	var unresolved *UnresolvedTypeError
+	a.True(errors.As(err, &unresolved))
+	a.Equal(UnresolvedTypeError{Namespace: "Windows.Foundation", Name: "IMissing"}, *unresolved)
+	a.EqualError(err, "type Windows.Foundation.IMissing not found")
+}
+
+func TestUniverse_Assembly(t *testing.T) {
+	a := require.New(t)
+
+	var (
+		v1 = &Context{}
+		v2 = &Context{}
+	)
+	u, err := NewUniverse()
+	a.NoError(err)
+	u.assemblies["windows.foundation"] = []universeAssembly{
+		{Version: AssemblyVersion{Major: 1}, Context: v1},
+		{Version: AssemblyVersion{Major: 2}, Context: v2},
+	}
+
+	c, err := u.Assembly("Windows.Foundation", AssemblyVersion{Major: 1})
+	a.NoError(err)
+	a.Same(v1, c)
+
+	c, err = u.Assembly("Windows.Foundation", AssemblyVersion{Major: 1, Minor: 5})
+	a.NoError(err)
+	a.Same(v2, c)
+
+	_, err = u.Assembly("Windows.Foundation", AssemblyVersion{Major: 3})
+	var unresolved *UnresolvedAssemblyError
+	a.True(errors.As(err, &unresolved))
+	a.Equal("Windows.Foundation", unresolved.Name)
+
+	_, err = u.Assembly("netstandard", AssemblyVersion{Major: 2, Minor: 1})
+	a.EqualError(err, "assembly netstandard 2.1.0.0 is not loaded")
+}
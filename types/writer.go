@@ -0,0 +1,346 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// Writer builds metadata file from row structs.
+//
+// Rows are added using AppendTo methods of row types, e.g. TypeDef.AppendTo.
+// Index, List and composite index fields are written as is, so they must
+// reference rows of this Writer.
+//
+// Output does not depend on order of heap additions: heaps are built
+// during encoding, in order of table rows. So, reading written file and
+// writing it again produces the same bytes.
+type Writer struct {
+	// Version is a metadata version string.
+	Version string
+
+	strings     heapValues[string]
+	blobs       heapValues[string]
+	guids       heapValues[md.GUID]
+	userStrings md.UserStringHeapBuilder
+	rows        [md.CustomDebugInformation + 1][]md.RowValues
+}
+
+// heapValues deduplicates heap values and assigns them identifiers.
+//
+// Identifiers are replaced with heap indexes during encoding.
+type heapValues[T comparable] struct {
+	values []T
+	ids    map[T]uint32
+}
+
+func (h *heapValues[T]) add(v T) uint32 {
+	if id, ok := h.ids[v]; ok {
+		return id
+	}
+	if h.ids == nil {
+		h.ids = map[T]uint32{}
+	}
+
+	h.values = append(h.values, v)
+	id := uint32(len(h.values))
+	h.ids[v] = id
+	return id
+}
+
+func (h *heapValues[T]) get(id uint64) (v T, _ error) {
+	if id < 1 || id > uint64(len(h.values)) {
+		return v, fmt.Errorf("unknown heap value %d", id)
+	}
+	return h.values[id-1], nil
+}
+
+// NewWriter creates new Writer.
+func NewWriter() *Writer {
+	return &Writer{
+		Version: "v4.0.30319",
+	}
+}
+
+// String adds string to #Strings heap and returns its identifier.
+func (w *Writer) String(s string) uint32 {
+	if s == "" {
+		return 0
+	}
+	return w.strings.add(s)
+}
+
+// Blob adds blob to #Blob heap and returns its identifier.
+func (w *Writer) Blob(b Blob) uint32 {
+	if len(b) == 0 {
+		return 0
+	}
+	return w.blobs.add(string(b))
+}
+
+// GUID adds GUID to #GUID heap and returns its identifier.
+func (w *Writer) GUID(g md.GUID) uint32 {
+	if g.Zero() {
+		return 0
+	}
+	return w.guids.add(g)
+}
+
+// UserString adds string to #US heap and returns its index.
+//
+// Unlike other heaps, #US heap index is final, since it is referenced
+// by method bodies, not by tables.
+func (w *Writer) UserString(s string) uint32 {
+	return w.userStrings.Add(s)
+}
+
+// AddRow adds raw row to given table and returns its 1-based row number.
+//
+// Row number is a value of simple index columns referencing the row, e.g.
+// NestedClass.EnclosingClass. Composite index constructors and List take
+// 0-based table index, which is row number minus one.
+func (w *Writer) AddRow(tt md.TableType, values md.RowValues) Index {
+	w.rows[tt] = append(w.rows[tt], values)
+	return uint32(len(w.rows[tt]))
+}
+
+// RowCount returns row count of given table type.
+func (w *Writer) RowCount(tt md.TableType) uint32 {
+	return uint32(len(w.rows[tt]))
+}
+
+type sortKey struct {
+	Column int
+	Desc   bool
+}
+
+// sortedTables is a list of tables which must be sorted, in order of sorting.
+//
+// Tables referenced by other sorted tables are sorted first.
+var sortedTables = []struct {
+	Table md.TableType
+	Keys  []sortKey
+}{
+	{md.InterfaceImpl, []sortKey{{Column: 0}, {Column: 1}}},
+	{md.GenericParam, []sortKey{{Column: 2}, {Column: 0}}},
+	{md.GenericParamConstraint, []sortKey{{Column: 0}}},
+	{md.LocalScope, []sortKey{{Column: 0}, {Column: 4}, {Column: 5, Desc: true}}},
+	{md.Constant, []sortKey{{Column: 1}}},
+	{md.FieldMarshal, []sortKey{{Column: 0}}},
+	{md.DeclSecurity, []sortKey{{Column: 1}}},
+	{md.ClassLayout, []sortKey{{Column: 2}}},
+	{md.FieldLayout, []sortKey{{Column: 1}}},
+	{md.MethodSemantics, []sortKey{{Column: 2}}},
+	{md.MethodImpl, []sortKey{{Column: 0}}},
+	{md.ImplMap, []sortKey{{Column: 1}}},
+	{md.FieldRva, []sortKey{{Column: 1}}},
+	{md.NestedClass, []sortKey{{Column: 0}}},
+	{md.StateMachineMethod, []sortKey{{Column: 0}}},
+	{md.CustomAttribute, []sortKey{{Column: 0}}},
+	{md.CustomDebugInformation, []sortKey{{Column: 0}}},
+}
+
+// sortRows sorts tables and updates references to moved rows.
+func sortRows(rows *[md.CustomDebugInformation + 1][]md.RowValues) (mask uint64) {
+	for _, s := range sortedTables {
+		mask |= 1 << uint(s.Table)
+
+		table := rows[s.Table]
+		order := make([]int, len(table))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := table[order[i]], table[order[j]]
+			for _, key := range s.Keys {
+				if a[key.Column] == b[key.Column] {
+					continue
+				}
+				return (a[key.Column] < b[key.Column]) != key.Desc
+			}
+			return false
+		})
+
+		var (
+			sorted = make([]md.RowValues, len(table))
+			moved  = map[uint32]uint32{}
+		)
+		for to, from := range order {
+			sorted[to] = table[from]
+			if to != from {
+				moved[uint32(from)] = uint32(to)
+			}
+		}
+		rows[s.Table] = sorted
+
+		if len(moved) > 0 {
+			remapRows(rows, s.Table, moved)
+		}
+	}
+	return mask
+}
+
+// remapFunc updates column value if it references moved row of given table.
+//
+// Keys and values of moved are 0-based table indexes.
+type remapFunc func(v uint64, tt md.TableType, moved map[uint32]uint32) uint64
+
+// remapIndex returns remapFunc of simple index column referencing given table.
+func remapIndex(table md.TableType) remapFunc {
+	return func(v uint64, tt md.TableType, moved map[uint32]uint32) uint64 {
+		if tt != table || v == 0 {
+			return v
+		}
+		if to, ok := moved[uint32(v)-1]; ok {
+			return uint64(to) + 1
+		}
+		return v
+	}
+}
+
+// compositeIndex is a constraint for pointer to composite index type.
+type compositeIndex[T any] interface {
+	*T
+	Table() (md.TableType, bool)
+	TableIndex() uint32
+	Set(tt md.TableType, idx uint32)
+}
+
+// remapCoded is a remapFunc of composite index column.
+func remapCoded[T ~uint32, P compositeIndex[T]](v uint64, tt md.TableType, moved map[uint32]uint32) uint64 {
+	idx := T(v)
+	p := P(&idx)
+	if t, ok := p.Table(); !ok || t != tt {
+		return v
+	}
+	to, ok := moved[p.TableIndex()]
+	if !ok {
+		return v
+	}
+	p.Set(tt, to)
+	return uint64(idx)
+}
+
+// remapRows updates references to moved rows of given table.
+func remapRows(rows *[md.CustomDebugInformation + 1][]md.RowValues, tt md.TableType, moved map[uint32]uint32) {
+	for table, columns := range indexColumns {
+		for column, remap := range columns {
+			if remap == nil {
+				continue
+			}
+			for i := range rows[table] {
+				row := &rows[table][i]
+				row[column] = remap(row[column], tt, moved)
+			}
+		}
+	}
+}
+
+// heapKind is a kind of heap referenced by column.
+type heapKind uint8
+
+const (
+	noHeap heapKind = iota
+	stringHeap
+	blobHeap
+	guidHeap
+)
+
+// buildHeaps builds heaps in order of table rows and replaces heap
+// value identifiers with heap indexes.
+func (w *Writer) buildHeaps(rows *[md.CustomDebugInformation + 1][]md.RowValues) (
+	strs md.StringHeapBuilder,
+	blobs md.BlobHeapBuilder,
+	guids md.GUIDHeapBuilder,
+	_ error,
+) {
+	for tt := range rows {
+		kinds, ok := heapColumns[md.TableType(tt)]
+		if !ok {
+			continue
+		}
+
+		for i := range rows[tt] {
+			row := &rows[tt][i]
+			for column, kind := range kinds {
+				id := row[column]
+				if kind == noHeap || id == 0 {
+					continue
+				}
+
+				switch kind {
+				case stringHeap:
+					v, err := w.strings.get(id)
+					if err != nil {
+						return strs, blobs, guids, fmt.Errorf("%v(%d): column %d: %w", md.TableType(tt), i, column, err)
+					}
+					row[column] = uint64(strs.Add(v))
+				case blobHeap:
+					v, err := w.blobs.get(id)
+					if err != nil {
+						return strs, blobs, guids, fmt.Errorf("%v(%d): column %d: %w", md.TableType(tt), i, column, err)
+					}
+					row[column] = uint64(blobs.Add([]byte(v)))
+				case guidHeap:
+					v, err := w.guids.get(id)
+					if err != nil {
+						return strs, blobs, guids, fmt.Errorf("%v(%d): column %d: %w", md.TableType(tt), i, column, err)
+					}
+					row[column] = uint64(guids.Add(v))
+				}
+			}
+		}
+	}
+	return strs, blobs, guids, nil
+}
+
+// Metadata encodes metadata, starting with BSJB signature.
+//
+// Tables which must be sorted are sorted, references to moved rows are
+// updated accordingly.
+func (w *Writer) Metadata() ([]byte, error) {
+	rows := w.rows
+	for i := range rows {
+		rows[i] = append([]md.RowValues(nil), rows[i]...)
+	}
+	sorted := sortRows(&rows)
+
+	stringsBuilder, blobBuilder, guidBuilder, err := w.buildHeaps(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		stringsHeap = stringsBuilder.Bytes()
+		guidHeap    = guidBuilder.Bytes()
+		blobHeap    = blobBuilder.Bytes()
+	)
+	b := md.TablesBuilder{
+		HeapSizes: md.HeapSizes(len(stringsHeap), len(guidHeap), len(blobHeap)),
+		Sorted:    sorted,
+		Rows:      rows,
+	}
+	tables, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return md.EncodeMetadata(w.Version,
+		md.Stream{Name: "#~", Data: tables},
+		md.Stream{Name: "#Strings", Data: stringsHeap},
+		md.Stream{Name: "#US", Data: w.userStrings.Bytes()},
+		md.Stream{Name: "#GUID", Data: guidHeap},
+		md.Stream{Name: "#Blob", Data: blobHeap},
+	), nil
+}
+
+// WritePE encodes metadata and writes it as PE file, readable by FromPE.
+func (w *Writer) WritePE(out io.Writer) error {
+	data, err := w.Metadata()
+	if err != nil {
+		return err
+	}
+	return md.WritePE(out, data)
+}
@@ -0,0 +1,295 @@
+package types
+
+import (
+	"bytes"
+	"debug/pe"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+var testMvid = md.GUID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+
+func testWriter() *Writer {
+	w := NewWriter()
+	w.Version = "WindowsRuntime 1.4"
+
+	// Rows are added out of order to check sorting.
+	(&Module{Name: "Test.winmd", Mvid: testMvid}).AppendTo(w)
+	mscorlib := (&AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	object := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.AssemblyRef, mscorlib-1),
+		TypeName:        "Object",
+		TypeNamespace:   "System",
+	}).AppendTo(w)
+	attr := (&MemberRef{
+		Class:     CreateMemberRefParent(md.TypeRef, object-1),
+		Name:      ".ctor",
+		Signature: Signature{0x20, 0x00, 0x01},
+	}).AppendTo(w)
+
+	(&TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	iface := (&TypeDef{
+		Flags:         0xa1, // Public | Interface | Abstract
+		TypeName:      "IFoo",
+		TypeNamespace: "Test",
+	}).AppendTo(w)
+	bar := (&TypeDef{
+		Flags:         0x01, // Public
+		TypeName:      "Bar",
+		TypeNamespace: "Test",
+		Extends:       CreateTypeDefOrRef(md.TypeRef, object-1),
+	}).AppendTo(w)
+
+	field := (&Field{Flags: 0x16, Name: "Value", Signature: Signature{0x06, 0x08}}).AppendTo(w)
+	method := (&MethodDef{Flags: 0x86, Name: "Do", Signature: Signature{0x00, 0x01, 0x01, 0x08}}).AppendTo(w)
+	(&Param{Sequence: 1, Name: "x"}).AppendTo(w)
+
+	(&InterfaceImpl{Class: bar, Interface: CreateTypeDefOrRef(md.TypeDef, iface-1)}).AppendTo(w)
+	(&Constant{Type: ELEMENT_TYPE_I4, Parent: CreateHasConstant(md.Field, field-1), Value: Blob{5, 0, 0, 0}}).AppendTo(w)
+
+	t := (&GenericParam{Number: 0, Owner: CreateTypeOrMethodDef(md.TypeDef, bar-1), Name: "T"}).AppendTo(w)
+	(&GenericParam{Number: 0, Owner: CreateTypeOrMethodDef(md.TypeDef, iface-1), Name: "U"}).AppendTo(w)
+	(&GenericParamConstraint{Owner: t, Constraint: CreateTypeDefOrRef(md.TypeDef, iface-1)}).AppendTo(w)
+
+	ctor := CreateCustomAttributeType(md.MemberRef, attr-1)
+	value := Blob{0x01, 0x00, 0x00, 0x00}
+	(&CustomAttribute{Parent: CreateHasCustomAttribute(md.GenericParam, t-1), Type: ctor, Value: value}).AppendTo(w)
+	(&CustomAttribute{Parent: CreateHasCustomAttribute(md.TypeDef, bar-1), Type: ctor, Value: value}).AppendTo(w)
+	(&CustomAttribute{Parent: CreateHasCustomAttribute(md.MethodDef, method-1), Type: ctor, Value: value}).AppendTo(w)
+
+	w.UserString("Hello")
+	return w
+}
+
+func writePE(a *require.Assertions, w *Writer) []byte {
+	var buf bytes.Buffer
+	a.NoError(w.WritePE(&buf))
+	return buf.Bytes()
+}
+
+func readPE(a *require.Assertions, data []byte) *Context {
+	f, err := pe.NewFile(bytes.NewReader(data))
+	a.NoError(err)
+
+	c, err := FromPE(f)
+	a.NoError(err)
+	return c
+}
+
+type appendableRow[T any] interface {
+	RowType[T]
+	AppendTo(w *Writer) Index
+}
+
+func copyRows[T any, P appendableRow[T]](a *require.Assertions, c *Context, w *Writer) {
+	rows := Rows[T, P](c)
+	for _, v := range rows.All() {
+		P(&v).AppendTo(w)
+	}
+	a.NoError(rows.Err())
+}
+
+// rewrite copies all tables of given Context and given user strings to new Writer.
+func rewrite(a *require.Assertions, c *Context, userStrings ...string) *Writer {
+	w := NewWriter()
+	w.Version = c.Metadata.Version
+
+	copyRows[Module](a, c, w)
+	copyRows[TypeRef](a, c, w)
+	copyRows[TypeDef](a, c, w)
+	copyRows[Field](a, c, w)
+	copyRows[MethodDef](a, c, w)
+	copyRows[Param](a, c, w)
+	copyRows[InterfaceImpl](a, c, w)
+	copyRows[MemberRef](a, c, w)
+	copyRows[Constant](a, c, w)
+	copyRows[CustomAttribute](a, c, w)
+	copyRows[AssemblyRef](a, c, w)
+	copyRows[GenericParam](a, c, w)
+	copyRows[GenericParamConstraint](a, c, w)
+
+	for _, s := range userStrings {
+		w.UserString(s)
+	}
+	return w
+}
+
+// fixtureMetadata returns hand-built metadata in canonical Writer layout.
+func fixtureMetadata() []byte {
+	tables := []byte{
+		0x00, 0x00, 0x00, 0x00, // Reserved
+		0x02, 0x00, // MajorVersion, MinorVersion
+		0x00, 0x01, // HeapSizes, Reserved
+		0x17, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, // Valid: Module, TypeRef, TypeDef, Field, AssemblyRef
+		0x00, 0xfa, 0x01, 0x33, 0x00, 0x16, 0xc4, 0x00, // Sorted: all tables sorted by Writer
+		0x01, 0x00, 0x00, 0x00, // Module rows
+		0x01, 0x00, 0x00, 0x00, // TypeRef rows
+		0x02, 0x00, 0x00, 0x00, // TypeDef rows
+		0x01, 0x00, 0x00, 0x00, // Field rows
+		0x01, 0x00, 0x00, 0x00, // AssemblyRef rows
+		// Module: Generation, Name, Mvid, EncId, EncBaseId.
+		0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// TypeRef: AssemblyRef(0), "Object", "System".
+		0x06, 0x00, 0x0c, 0x00, 0x13, 0x00,
+		// TypeDef: "<Module>".
+		0x00, 0x00, 0x00, 0x00, 0x1a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00,
+		// TypeDef: Public | BeforeFieldInit, "Foo", "Test", extends TypeRef(0).
+		0x01, 0x00, 0x10, 0x00, 0x23, 0x00, 0x27, 0x00, 0x05, 0x00, 0x01, 0x00, 0x01, 0x00,
+		// Field: Public, "Value", int32.
+		0x06, 0x00, 0x2c, 0x00, 0x01, 0x00,
+		// AssemblyRef: 4.0.0.0, "mscorlib".
+		0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	strings := []byte("\x00Test.winmd\x00Object\x00System\x00<Module>\x00Foo\x00Test\x00Value\x00mscorlib\x00")
+	blobs := []byte{0x00, 0x02, 0x06, 0x08}
+
+	return md.EncodeMetadata("v4.0.30319",
+		md.Stream{Name: "#~", Data: tables},
+		md.Stream{Name: "#Strings", Data: strings},
+		md.Stream{Name: "#US", Data: []byte{0x00}},
+		md.Stream{Name: "#GUID", Data: testMvid[:]},
+		md.Stream{Name: "#Blob", Data: blobs},
+	)
+}
+
+func TestWriter(t *testing.T) {
+	a := require.New(t)
+
+	data := writePE(a, testWriter())
+	c := readPE(a, data)
+
+	bar, ok, err := c.FindTypeDef("Test", "Bar")
+	a.NoError(err)
+	a.True(ok)
+	iface, ok, err := c.FindTypeDef("Test", "IFoo")
+	a.NoError(err)
+	a.True(ok)
+
+	var m Module
+	a.NoError(m.FromRow(c.Table(md.Module).Row(0)))
+	a.Equal("Test.winmd", m.Name)
+	a.Equal(testMvid, m.Mvid)
+
+	var def TypeDef
+	a.NoError(def.FromRow(c.Table(md.TypeDef).Row(bar)))
+	fields, err := def.ResolveFieldList(c)
+	a.NoError(err)
+	a.Len(fields, 1)
+	a.Equal("Value", fields[0].Name)
+	methods, err := def.ResolveMethodList(c)
+	a.NoError(err)
+	a.Len(methods, 1)
+	a.Equal("Do", methods[0].Name)
+
+	v, ok, err := c.FieldConstant(0)
+	a.NoError(err)
+	a.True(ok)
+	a.Equal(int32(5), v)
+
+	impls, err := c.InterfaceImpls(bar)
+	a.NoError(err)
+	a.Len(impls, 1)
+	impl, err := Get[InterfaceImpl](c, impls[0])
+	a.NoError(err)
+	a.Equal(CreateTypeDefOrRef(md.TypeDef, iface), impl.Interface)
+
+	// GenericParam table is sorted by owner, so "T" is moved after "U".
+	params, err := c.GenericParams(CreateTypeOrMethodDef(md.TypeDef, bar))
+	a.NoError(err)
+	a.Len(params, 1)
+	a.Equal("T", params[0].Name)
+
+	var constraint GenericParamConstraint
+	a.NoError(constraint.FromRow(c.Table(md.GenericParamConstraint).Row(0)))
+	owner, err := constraint.ResolveOwner(c)
+	a.NoError(err)
+	a.Equal("T", owner.Name)
+
+	attrs, err := c.CustomAttributes(CreateHasCustomAttribute(md.GenericParam, constraint.Owner-1))
+	a.NoError(err)
+	a.Len(attrs, 1)
+
+	a.True(c.IsSorted(md.CustomAttribute))
+	for _, parent := range []HasCustomAttribute{
+		CreateHasCustomAttribute(md.MethodDef, 0),
+		CreateHasCustomAttribute(md.TypeDef, bar),
+	} {
+		attrs, err := c.CustomAttributes(parent)
+		a.NoError(err)
+		a.Len(attrs, 1)
+	}
+
+	s, err := c.UserString(1)
+	a.NoError(err)
+	a.Equal("Hello", s)
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	a := require.New(t)
+
+	data := writePE(a, testWriter())
+	again := writePE(a, rewrite(a, readPE(a, data), "Hello"))
+	a.Equal(data, again)
+
+	// Metadata which was not produced by Writer.
+	fixture := fixtureMetadata()
+	c, err := FromBytes(fixture)
+	a.NoError(err)
+	def, err := Get[TypeDef](c, 1)
+	a.NoError(err)
+	a.Equal("Foo", def.TypeName)
+	encoded, err := rewrite(a, c).Metadata()
+	a.NoError(err)
+	a.Equal(fixture, encoded)
+
+	// Output must not depend on Writer state.
+	w := testWriter()
+	a.Equal(writePE(a, w), writePE(a, w))
+}
+
+func TestWriter_RemapRows(t *testing.T) {
+	a := require.New(t)
+
+	w := NewWriter()
+	(&Module{Name: "Test.winmd"}).AppendTo(w)
+	mscorlib := (&AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	object := (&TypeRef{
+		ResolutionScope: CreateResolutionScope(md.AssemblyRef, mscorlib-1),
+		TypeName:        "Object",
+		TypeNamespace:   "System",
+	}).AppendTo(w)
+	attr := (&MemberRef{
+		Class:     CreateMemberRefParent(md.TypeRef, object-1),
+		Name:      ".ctor",
+		Signature: Signature{0x20, 0x00, 0x01},
+	}).AppendTo(w)
+
+	(&TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	iface := (&TypeDef{Flags: 0xa1, TypeName: "IFoo", TypeNamespace: "Test"}).AppendTo(w)
+	foo := (&TypeDef{Flags: 0x01, TypeName: "Foo", TypeNamespace: "Test"}).AppendTo(w)
+	bar := (&TypeDef{Flags: 0x01, TypeName: "Bar", TypeNamespace: "Test"}).AppendTo(w)
+
+	// InterfaceImpl of Bar is moved after InterfaceImpl of Foo.
+	impl := (&InterfaceImpl{Class: bar, Interface: CreateTypeDefOrRef(md.TypeDef, iface-1)}).AppendTo(w)
+	(&InterfaceImpl{Class: foo, Interface: CreateTypeDefOrRef(md.TypeDef, iface-1)}).AppendTo(w)
+	(&CustomAttribute{
+		Parent: CreateHasCustomAttribute(md.InterfaceImpl, impl-1),
+		Type:   CreateCustomAttributeType(md.MemberRef, attr-1),
+		Value:  Blob{0x01, 0x00, 0x00, 0x00},
+	}).AppendTo(w)
+
+	c := readPE(a, writePE(a, w))
+	var moved InterfaceImpl
+	a.NoError(moved.FromRow(c.Table(md.InterfaceImpl).Row(1)))
+	a.Equal(bar, moved.Class)
+
+	attrs, err := c.CustomAttributes(CreateHasCustomAttribute(md.InterfaceImpl, 1))
+	a.NoError(err)
+	a.Len(attrs, 1)
+	attrs, err = c.CustomAttributes(CreateHasCustomAttribute(md.InterfaceImpl, 0))
+	a.NoError(err)
+	a.Empty(attrs)
+}
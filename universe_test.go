@@ -0,0 +1,44 @@
+package win32metadata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+func TestUniverse_ResolveTypeRef(t *testing.T) {
+	a := require.New(t)
+	c := openWin32(a)
+
+	u, err := types.NewUniverse(c)
+	a.NoError(err)
+
+	var (
+		typeRefs = c.Table(md.TypeRef)
+		ref      types.TypeRef
+		resolved int
+	)
+	for i := uint32(0); i < typeRefs.RowCount(); i++ {
+		a.NoError(ref.FromRow(typeRefs.Row(i)))
+
+		r, err := u.ResolveTypeRef(c, i)
+		var unresolved *types.UnresolvedAssemblyError
+		if errors.As(err, &unresolved) {
+			// Types from other assemblies, like System.Guid.
+			a.NotEmpty(unresolved.Name)
+			continue
+		}
+		a.NoError(err, "%s.%s", ref.TypeNamespace, ref.TypeName)
+		a.Same(c, r.Context)
+
+		def, err := r.TypeDef()
+		a.NoError(err)
+		a.Equal(ref.TypeName, def.TypeName)
+		resolved++
+	}
+	a.NotZero(resolved)
+}
@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+)
+
+// Severity is a diagnostic severity.
+type Severity string
+
+const (
+	// Error denotes that metadata is invalid.
+	Error Severity = "error"
+	// Warning denotes that metadata is valid, but likely incorrect.
+	Warning Severity = "warning"
+)
+
+// NoColumn is a Diagnostic.Column value for diagnostics about the whole row or table.
+const NoColumn = -1
+
+// Diagnostic is a single validation problem.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	// Rule is a ECMA-335 section (e.g. "II.24.2.4"), numbered validation rule
+	// of table section (e.g. "II.22.37/19") or "Win32" for Win32 metadata conventions.
+	Rule string `json:"rule"`
+	// Check is a short name of failed check.
+	Check string `json:"check"`
+
+	// Stream is a name of metadata stream, if diagnostic is about stream
+	// contents rather than table row.
+	Stream string `json:"stream,omitempty"`
+
+	// Table, Row and Column are not set if diagnostic is about stream.
+	Table md.TableType `json:"table"`
+	// Row is a 0-based row index.
+	Row uint32 `json:"row"`
+	// Column is a column index or NoColumn.
+	Column int `json:"column"`
+
+	Message string `json:"message"`
+}
+
+// String implements fmt.Stringer.
+func (d Diagnostic) String() string {
+	loc := fmt.Sprintf("%v(%d)", d.Table, d.Row)
+	if d.Stream != "" {
+		loc = d.Stream
+	} else if d.Column != NoColumn {
+		loc += fmt.Sprintf(".%d", d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s %s]", loc, d.Severity, d.Message, d.Rule, d.Check)
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Table, Row and Column are omitted for stream diagnostics.
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	type diagnostic Diagnostic
+	if d.Stream == "" {
+		return json.Marshal(diagnostic(d))
+	}
+	return json.Marshal(struct {
+		diagnostic
+		// Shadow location fields of embedded struct.
+		Table  *md.TableType `json:"table,omitempty"`
+		Row    *uint32       `json:"row,omitempty"`
+		Column *int          `json:"column,omitempty"`
+	}{diagnostic: diagnostic(d)})
+}
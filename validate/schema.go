@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+type columnKind int
+
+const (
+	valueColumn columnKind = iota
+	stringColumn
+	blobColumn
+	guidColumn
+	indexColumn
+	listColumn
+	codedColumn
+)
+
+// codedIndex decodes composite index.
+type codedIndex func(v uint32) (tt md.TableType, row uint32, ok bool)
+
+func coded[T interface {
+	~uint32
+	Table() (md.TableType, bool)
+	TableIndex() uint32
+}]() codedIndex {
+	return func(v uint32) (md.TableType, uint32, bool) {
+		t := T(v)
+		tt, ok := t.Table()
+		return tt, t.TableIndex(), ok
+	}
+}
+
+var (
+	typeDefOrRef        = coded[types.TypeDefOrRef]()
+	hasConstant         = coded[types.HasConstant]()
+	hasCustomAttribute  = coded[types.HasCustomAttribute]()
+	hasFieldMarshal     = coded[types.HasFieldMarshall]()
+	hasDeclSecurity     = coded[types.HasDeclSecurity]()
+	memberRefParent     = coded[types.MemberRefParent]()
+	hasSemantics        = coded[types.HasSemantics]()
+	methodDefOrRef      = coded[types.MethodDefOrRef]()
+	memberForwarded     = coded[types.MemberForwarded]()
+	implementation      = coded[types.Implementation]()
+	customAttributeType = coded[types.CustomAttributeType]()
+	resolutionScope     = coded[types.ResolutionScope]()
+	typeOrMethodDef     = coded[types.TypeOrMethodDef]()
+)
+
+// column describes table column.
+type column struct {
+	Kind columnKind
+	// Target is a target table of index or list column.
+	Target md.TableType
+	// Coded is a decoder of composite index column.
+	Coded codedIndex
+	// Nullable denotes that index column may be null.
+	Nullable bool
+}
+
+var (
+	value  = column{Kind: valueColumn}
+	str    = column{Kind: stringColumn}
+	blob   = column{Kind: blobColumn}
+	guid   = column{Kind: guidColumn}
+	index  = func(target md.TableType) column { return column{Kind: indexColumn, Target: target} }
+	list   = func(target md.TableType) column { return column{Kind: listColumn, Target: target} }
+	codedI = func(c codedIndex) column { return column{Kind: codedColumn, Coded: c} }
+	// nullable creates composite index column, which may be null.
+	nullable = func(c codedIndex) column { return column{Kind: codedColumn, Coded: c, Nullable: true} }
+)
+
+// schema contains column descriptions of type system tables.
+//
+// See II.22 Metadata logical format: tables.
+var schema = map[md.TableType][]column{
+	md.Module:                 {value, str, guid, guid, guid},
+	md.TypeRef:                {nullable(resolutionScope), str, str},
+	md.TypeDef:                {value, str, str, nullable(typeDefOrRef), list(md.Field), list(md.MethodDef)},
+	md.FieldPtr:               {index(md.Field)},
+	md.Field:                  {value, str, blob},
+	md.MethodPtr:              {index(md.MethodDef)},
+	md.MethodDef:              {value, value, value, str, blob, list(md.Param)},
+	md.ParamPtr:               {index(md.Param)},
+	md.Param:                  {value, value, str},
+	md.InterfaceImpl:          {index(md.TypeDef), codedI(typeDefOrRef)},
+	md.MemberRef:              {codedI(memberRefParent), str, blob},
+	md.Constant:               {value, codedI(hasConstant), blob},
+	md.CustomAttribute:        {codedI(hasCustomAttribute), codedI(customAttributeType), blob},
+	md.FieldMarshal:           {codedI(hasFieldMarshal), blob},
+	md.DeclSecurity:           {value, codedI(hasDeclSecurity), blob},
+	md.ClassLayout:            {value, value, index(md.TypeDef)},
+	md.FieldLayout:            {value, index(md.Field)},
+	md.StandAloneSig:          {blob},
+	md.EventMap:               {index(md.TypeDef), list(md.Event)},
+	md.EventPtr:               {index(md.Event)},
+	md.Event:                  {value, str, nullable(typeDefOrRef)},
+	md.PropertyMap:            {index(md.TypeDef), list(md.Property)},
+	md.PropertyPtr:            {index(md.Property)},
+	md.Property:               {value, str, blob},
+	md.MethodSemantics:        {value, index(md.MethodDef), codedI(hasSemantics)},
+	md.MethodImpl:             {index(md.TypeDef), codedI(methodDefOrRef), codedI(methodDefOrRef)},
+	md.ModuleRef:              {str},
+	md.TypeSpec:               {blob},
+	md.ImplMap:                {value, codedI(memberForwarded), str, index(md.ModuleRef)},
+	md.FieldRva:               {value, index(md.Field)},
+	md.Assembly:               {value, value, value, blob, str, str},
+	md.AssemblyProcessor:      {value},
+	md.AssemblyOs:             {value, value, value},
+	md.AssemblyRef:            {value, value, blob, str, str, blob},
+	md.AssemblyRefProcessor:   {value, index(md.AssemblyRef)},
+	md.AssemblyRefOs:          {value, value, value, index(md.AssemblyRef)},
+	md.File:                   {value, str, blob},
+	md.ExportedType:           {value, value, str, str, codedI(implementation)},
+	md.ManifestResource:       {value, value, str, nullable(implementation)},
+	md.NestedClass:            {index(md.TypeDef), index(md.TypeDef)},
+	md.GenericParam:           {value, value, codedI(typeOrMethodDef), str},
+	md.MethodSpec:             {codedI(methodDefOrRef), blob},
+	md.GenericParamConstraint: {index(md.GenericParam), codedI(typeDefOrRef)},
+}
+
+// sortKeys contains key columns of tables which may be sorted.
+var sortKeys = map[md.TableType][]int{
+	md.InterfaceImpl:          {0, 1},
+	md.Constant:               {1},
+	md.CustomAttribute:        {0},
+	md.FieldMarshal:           {0},
+	md.DeclSecurity:           {1},
+	md.ClassLayout:            {2},
+	md.FieldLayout:            {1},
+	md.MethodSemantics:        {2},
+	md.MethodImpl:             {0},
+	md.ImplMap:                {1},
+	md.FieldRva:               {1},
+	md.NestedClass:            {0},
+	md.GenericParam:           {2, 0},
+	md.GenericParamConstraint: {0},
+}
+
+// ownerRules contains rules which require every row of list target table to have exactly one owner.
+var ownerRules = map[md.TableType]string{
+	md.Field:     "II.22.15/2",
+	md.MethodDef: "II.22.26/2",
+	md.Param:     "II.22.33/2",
+	md.Event:     "II.22.13/2",
+	md.Property:  "II.22.34/2",
+}
+
+// ptrTables maps table to its Ptr table.
+var ptrTables = map[md.TableType]md.TableType{
+	md.Field:     md.FieldPtr,
+	md.MethodDef: md.MethodPtr,
+	md.Param:     md.ParamPtr,
+	md.Event:     md.EventPtr,
+	md.Property:  md.PropertyPtr,
+}
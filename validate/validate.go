@@ -0,0 +1,480 @@
+// Package validate checks metadata files against II.22.1 Metadata validation rules.
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Options is a validation options.
+type Options struct {
+	// Win32 enables checks of Win32 metadata conventions.
+	Win32 bool
+}
+
+// Validate checks given metadata file and returns found problems.
+//
+// Returned error denotes that validation could not be completed,
+// e.g. because of I/O error.
+func Validate(c *types.Context, opts Options) ([]Diagnostic, error) {
+	v := &validator{c: c, opts: opts}
+
+	checks := []func() error{
+		v.checkStreams,
+		v.checkColumns,
+		v.checkLists,
+		v.checkSorted,
+		v.checkAttributes,
+		v.checkTypeDefs,
+		v.checkSignatures,
+	}
+	if opts.Win32 {
+		checks = append(checks, v.checkWin32)
+	}
+
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return v.diags, err
+		}
+	}
+	return v.diags, nil
+}
+
+type validator struct {
+	c     *types.Context
+	opts  Options
+	diags []Diagnostic
+
+	// Heaps data, nil if heap is not present.
+	strings []byte
+	blobs   []byte
+	guids   []byte
+}
+
+func (v *validator) report(d Diagnostic, format string, args ...interface{}) {
+	if d.Severity == "" {
+		d.Severity = Error
+	}
+	d.Message = fmt.Sprintf(format, args...)
+	v.diags = append(v.diags, d)
+}
+
+// Rules of checks, which are not specific to table.
+const (
+	streamHeaderRule = "II.24.2.2"
+	stringHeapRule   = "II.24.2.3"
+	blobHeapRule     = "II.24.2.4"
+	guidHeapRule     = "II.24.2.5"
+	tablesStreamRule = "II.24.2.6"
+)
+
+// reportRow reports problem of given table row.
+func (v *validator) reportRow(rule, check string, tt md.TableType, row uint32, column int, format string, args ...interface{}) {
+	v.report(Diagnostic{
+		Rule:   rule,
+		Check:  check,
+		Table:  tt,
+		Row:    row,
+		Column: column,
+	}, format, args...)
+}
+
+// tables returns type system tables present in file.
+func (v *validator) tables() []md.TableType {
+	var result []md.TableType
+	for tt := md.Module; tt <= md.GenericParamConstraint; tt++ {
+		if _, ok := schema[tt]; ok && v.c.RowCount(tt) > 0 {
+			result = append(result, tt)
+		}
+	}
+	return result
+}
+
+// checkStreams checks that streams are inside metadata bounds and collects heaps data.
+func (v *validator) checkStreams() error {
+	m := v.c.Metadata
+	for _, h := range m.StreamHeaders {
+		section, err := m.StreamByName(h.Name)
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(section)
+		if err != nil {
+			return fmt.Errorf("read %q: %w", h.Name, err)
+		}
+
+		switch h.Name {
+		case "#Strings":
+			v.strings = data
+		case "#Blob":
+			v.blobs = data
+		case "#GUID":
+			v.guids = data
+		}
+	}
+	return nil
+}
+
+// checkColumns checks heap offsets and row indexes of every column.
+func (v *validator) checkColumns() error {
+	for _, tt := range v.tables() {
+		for i, col := range schema[tt] {
+			if err := v.checkColumn(tt, i, col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) checkColumn(tt md.TableType, column int, col column) error {
+	for row := uint32(0); row < v.c.RowCount(tt); row++ {
+		value, err := v.c.Uint32(tt, row, uint32(column))
+		if err != nil {
+			return err
+		}
+
+		switch col.Kind {
+		case stringColumn:
+			if !v.validString(value) {
+				v.reportRow(stringHeapRule, "string-heap", tt, row, column, "string index %#x is out of #Strings bounds", value)
+			}
+		case blobColumn:
+			if !v.validBlob(value) {
+				v.reportRow(blobHeapRule, "blob-heap", tt, row, column, "blob index %#x is out of #Blob bounds", value)
+			}
+		case guidColumn:
+			if int(value) > len(v.guids)/16 {
+				v.reportRow(guidHeapRule, "guid-heap", tt, row, column, "GUID index %d is out of #GUID bounds", value)
+			}
+		case indexColumn:
+			if value == 0 || value > v.c.RowCount(col.Target) {
+				v.reportRow(tablesStreamRule, "index", tt, row, column, "%v index %d is out of bounds [1, %d]",
+					col.Target, value, v.c.RowCount(col.Target))
+			}
+		case codedColumn:
+			target, idx, ok := col.Coded(value)
+			switch {
+			case !ok:
+				v.reportRow(tablesStreamRule, "coded-index", tt, row, column, "coded index %#x has invalid tag", value)
+			case idx+1 == 0:
+				if !col.Nullable {
+					v.reportRow(tablesStreamRule, "coded-index", tt, row, column, "%v index is null", target)
+				}
+			case idx >= v.c.RowCount(target):
+				v.reportRow(tablesStreamRule, "coded-index", tt, row, column, "%v index %d is out of bounds [1, %d]",
+					target, idx+1, v.c.RowCount(target))
+			}
+		}
+	}
+	return nil
+}
+
+// validString checks that string index points to null-terminated string inside #Strings heap.
+func (v *validator) validString(idx uint32) bool {
+	if idx == 0 && v.strings == nil {
+		return true
+	}
+	return int64(idx) < int64(len(v.strings)) && bytes.IndexByte(v.strings[idx:], 0) >= 0
+}
+
+// validBlob checks that blob index points to blob inside #Blob heap.
+func (v *validator) validBlob(idx uint32) bool {
+	if idx == 0 && v.blobs == nil {
+		return true
+	}
+	if int64(idx) >= int64(len(v.blobs)) {
+		return false
+	}
+
+	// Length is a compressed integer, 111xxxxx prefix is invalid.
+	if v.blobs[idx]&0xe0 == 0xe0 {
+		return false
+	}
+	size, n, ok := types.Signature(v.blobs[idx:]).Reader().Peek()
+	return ok && int64(idx)+int64(n)+int64(size) <= int64(len(v.blobs))
+}
+
+// checkLists checks that list columns are monotonic and inside target table bounds.
+func (v *validator) checkLists() error {
+	for _, tt := range v.tables() {
+		for i, col := range schema[tt] {
+			if col.Kind != listColumn {
+				continue
+			}
+			if err := v.checkList(tt, i, col.Target); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) checkList(tt md.TableType, column int, target md.TableType) error {
+	size := v.c.RowCount(target)
+	if ptr := ptrTables[target]; v.c.RowCount(ptr) > 0 {
+		size = v.c.RowCount(ptr)
+	}
+
+	var prev uint32
+	for row := uint32(0); row < v.c.RowCount(tt); row++ {
+		value, err := v.c.Uint32(tt, row, uint32(column))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case value == 0 || value > size+1:
+			v.reportRow(ownerRules[target], "list", tt, row, column, "%v list start %d is out of bounds [1, %d]", target, value, size+1)
+		case value < prev:
+			v.reportRow(ownerRules[target], "list", tt, row, column, "%v list start %d is lower than previous %d", target, value, prev)
+		}
+		prev = value
+	}
+	return nil
+}
+
+// checkSorted checks order of tables marked as sorted.
+func (v *validator) checkSorted() error {
+	for _, tt := range v.tables() {
+		keys, ok := sortKeys[tt]
+		if !ok || !v.c.IsSorted(tt) {
+			continue
+		}
+
+		var prev []uint32
+		for row := uint32(0); row < v.c.RowCount(tt); row++ {
+			key := make([]uint32, len(keys))
+			for i, column := range keys {
+				value, err := v.c.Uint32(tt, row, uint32(column))
+				if err != nil {
+					return err
+				}
+				key[i] = value
+			}
+
+			if prev != nil && keyLess(key, prev) {
+				v.reportRow(tablesStreamRule, "sorted", tt, row, keys[0], "table is marked as sorted, but row key %v is lower than previous %v", key, prev)
+			}
+			prev = key
+		}
+	}
+	return nil
+}
+
+func keyLess(a, b []uint32) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// checkAttributes checks that custom attribute constructors can be resolved.
+func (v *validator) checkAttributes() error {
+	table := v.c.Table(md.CustomAttribute)
+
+	var attr types.CustomAttribute
+	for row := uint32(0); row < table.RowCount(); row++ {
+		if err := attr.FromRow(table.Row(row)); err != nil {
+			return err
+		}
+		if tt, ok := attr.Type.Table(); !ok || attr.Type.TableIndex() >= v.c.RowCount(tt) {
+			// Reported by checkColumns.
+			continue
+		}
+
+		if _, _, _, err := attr.ResolveConstructor(v.c); err != nil {
+			v.reportRow("II.22.10/2", "attribute", md.CustomAttribute, row, 1, "can't resolve constructor: %v", err)
+		}
+	}
+	return nil
+}
+
+// checkTypeDefs checks that there are no duplicate TypeDefs.
+//
+// If Win32 option is set, TypeDefs for different architectures are not
+// duplicates and SupportedArchitectureAttribute must be well-formed.
+func (v *validator) checkTypeDefs() error {
+	// Maps nested TypeDef index to enclosing TypeDef index.
+	enclosing := map[uint32]uint32{}
+	for row := uint32(0); row < v.c.RowCount(md.NestedClass); row++ {
+		nested, err := v.c.Uint32(md.NestedClass, row, 0)
+		if err != nil {
+			return err
+		}
+		class, err := v.c.Uint32(md.NestedClass, row, 1)
+		if err != nil {
+			return err
+		}
+		enclosing[nested] = class
+	}
+
+	type typeKey struct {
+		namespace, name string
+		enclosing       uint32
+	}
+	// Same TypeDef can be defined several times for different architectures.
+	archs := map[uint32]types.Architecture{}
+	arch := func(row uint32) types.Architecture {
+		if !v.opts.Win32 {
+			return types.ArchitectureAll
+		}
+		if a, ok := archs[row]; ok {
+			return a
+		}
+
+		parent := types.CreateHasCustomAttribute(md.TypeDef, row)
+		a, err := v.c.SupportedArchitecture(parent)
+		if err != nil {
+			// Unresolvable constructors are reported by checkAttributes.
+			if _, resolveErr := v.c.NamedAttributes(parent); resolveErr == nil {
+				v.reportRow(win32Rule, "architecture", md.TypeDef, row, NoColumn,
+					"invalid SupportedArchitectureAttribute: %v", err)
+			}
+			// Assume TypeDef is available everywhere.
+			a = types.ArchitectureAll
+		}
+		archs[row] = a
+		return a
+	}
+	seen := map[typeKey][]uint32{}
+
+	table := v.c.Table(md.TypeDef)
+	for row := uint32(0); row < table.RowCount(); row++ {
+		r := table.Row(row)
+		name, err := r.String(1)
+		if err != nil {
+			// Reported by checkColumns.
+			continue
+		}
+		namespace, err := r.String(2)
+		if err != nil {
+			continue
+		}
+
+		key := typeKey{namespace: namespace, name: name, enclosing: enclosing[row+1]}
+		rule := "II.22.37/19"
+		if key.enclosing != 0 {
+			rule = "II.22.37/20"
+		}
+		rowArch := arch(row)
+		duplicate := false
+		for _, first := range seen[key] {
+			if arch(first)&rowArch != 0 {
+				v.reportRow(rule, "duplicate", md.TypeDef, row, NoColumn, "duplicate TypeDef %s.%s, first defined at row %d",
+					namespace, name, first)
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			seen[key] = append(seen[key], row)
+		}
+	}
+	return nil
+}
+
+// signatureColumns contains signature blob columns and their decoders.
+//
+// Decoder returns rule of decoded signature kind.
+var signatureColumns = []struct {
+	Table  md.TableType
+	Column uint32
+	Decode func(r *types.SignatureReader) (rule string, _ error)
+}{
+	{md.Field, 2, func(r *types.SignatureReader) (string, error) {
+		_, err := r.Field(nil)
+		return "II.23.2.4", err
+	}},
+	{md.MethodDef, 4, func(r *types.SignatureReader) (string, error) {
+		_, err := r.Method(nil)
+		return "II.23.2.1", err
+	}},
+	{md.MemberRef, 2, func(r *types.SignatureReader) (string, error) {
+		kind, err := r.Kind()
+		if err != nil {
+			return "II.23.2.2", err
+		}
+		if kind == types.SignatureKindField {
+			_, err = r.Field(nil)
+			return "II.23.2.4", err
+		}
+		_, err = r.Method(nil)
+		return "II.23.2.2", err
+	}},
+	{md.Property, 2, func(r *types.SignatureReader) (string, error) {
+		_, err := r.Property(nil)
+		return "II.23.2.5", err
+	}},
+	{md.StandAloneSig, 0, func(r *types.SignatureReader) (string, error) {
+		kind, err := r.Kind()
+		if err != nil {
+			return "II.23.2.6", err
+		}
+		if kind == types.SignatureKindLocalVar {
+			_, err = r.LocalVar(nil)
+			return "II.23.2.6", err
+		}
+		_, err = r.Method(nil)
+		return "II.23.2.3", err
+	}},
+	{md.TypeSpec, 0, func(r *types.SignatureReader) (string, error) {
+		_, err := r.TypeSpec(nil)
+		return "II.23.2.14", err
+	}},
+	{md.MethodSpec, 1, func(r *types.SignatureReader) (string, error) {
+		_, err := r.MethodSpec(nil)
+		return "II.23.2.15", err
+	}},
+}
+
+// checkSignatures checks that signature blobs are well-formed.
+func (v *validator) checkSignatures() error {
+	for _, sc := range signatureColumns {
+		for row := uint32(0); row < v.c.RowCount(sc.Table); row++ {
+			idx, err := v.c.Uint32(sc.Table, row, sc.Column)
+			if err != nil {
+				return err
+			}
+			if !v.validBlob(idx) {
+				// Reported by checkColumns.
+				continue
+			}
+
+			sig, err := v.c.Signature(sc.Table, row, sc.Column)
+			if err != nil {
+				return err
+			}
+
+			r := sig.Reader()
+			rule, err := sc.Decode(r)
+			if err != nil {
+				v.report(Diagnostic{
+					Rule:   rule,
+					Check:  "signature",
+					Table:  sc.Table,
+					Row:    row,
+					Column: int(sc.Column),
+				}, "invalid signature %x: %v", []byte(sig), err)
+				continue
+			}
+			if r.Len() != 0 {
+				v.report(Diagnostic{
+					Rule:   rule,
+					Check:  "signature",
+					Table:  sc.Table,
+					Row:    row,
+					Column: int(sc.Column),
+				}, "signature %x has %d trailing bytes", []byte(sig), r.Len())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,485 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// testModule contains indexes of rows added by testWriter.
+type testModule struct {
+	object types.Index
+	enum   types.Index
+	apis   types.Index
+}
+
+func testWriter() (*types.Writer, testModule) {
+	var m testModule
+	w := types.NewWriter()
+
+	(&types.Module{Name: "Test.winmd"}).AppendTo(w)
+	mscorlib := (&types.AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w)
+	scope := types.CreateResolutionScope(md.AssemblyRef, mscorlib-1)
+	m.object = (&types.TypeRef{ResolutionScope: scope, TypeName: "Object", TypeNamespace: "System"}).AppendTo(w)
+	m.enum = (&types.TypeRef{ResolutionScope: scope, TypeName: "Enum", TypeNamespace: "System"}).AppendTo(w)
+	guidAttr := (&types.TypeRef{
+		ResolutionScope: scope,
+		TypeName:        "GuidAttribute",
+		TypeNamespace:   "Windows.Win32.Foundation.Metadata",
+	}).AppendTo(w)
+	ctor := (&types.MemberRef{
+		Class:     types.CreateMemberRefParent(md.TypeRef, guidAttr-1),
+		Name:      ".ctor",
+		Signature: types.Signature{0x20, 0x01, 0x01, 0x0e},
+	}).AppendTo(w)
+
+	(&types.TypeDef{TypeName: "<Module>"}).AppendTo(w)
+	iface := (&types.TypeDef{
+		Flags:         0xa1, // Public | Interface | Abstract
+		TypeName:      "IFoo",
+		TypeNamespace: "Test",
+		FieldList:     types.List{0, 0},
+		MethodList:    types.List{0, 0},
+	}).AppendTo(w)
+	(&types.TypeDef{
+		Flags:         0x101, // Public | Sealed
+		TypeName:      "Color",
+		TypeNamespace: "Test",
+		Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.enum-1),
+		FieldList:     types.List{0, 0},
+		MethodList:    types.List{0, 0},
+	}).AppendTo(w)
+	(&types.Field{Flags: 0x606, Name: "value__", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	(&types.Field{Flags: 0x8056, Name: "Red", Signature: types.Signature{0x06, 0x08}}).AppendTo(w)
+	m.apis = (&types.TypeDef{
+		Flags:         0x181, // Public | Abstract | Sealed
+		TypeName:      "Apis",
+		TypeNamespace: "Test",
+		Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.object-1),
+		FieldList:     types.List{2, 2},
+		MethodList:    types.List{0, 0},
+	}).AppendTo(w)
+	method := (&types.MethodDef{Flags: 0x2096, Name: "Beep", Signature: types.Signature{0x00, 0x01, 0x01, 0x08}}).AppendTo(w)
+	(&types.Param{Sequence: 1, Name: "x"}).AppendTo(w)
+
+	kernel32 := (&types.ModuleRef{Name: "KERNEL32.dll"}).AppendTo(w)
+	(&types.ImplMap{
+		MemberForwarded: types.CreateMemberForwarded(md.MethodDef, method-1),
+		ImportName:      "Beep",
+		ImportScope:     kernel32,
+	}).AppendTo(w)
+	(&types.CustomAttribute{
+		Parent: types.CreateHasCustomAttribute(md.TypeDef, iface-1),
+		Type:   types.CreateCustomAttributeType(md.MemberRef, ctor-1),
+		Value:  types.Blob{0x01, 0x00, 0x03, 'I', 'I', 'D', 0x00, 0x00},
+	}).AppendTo(w)
+
+	return w, m
+}
+
+func buildContext(a *require.Assertions, data []byte) *types.Context {
+	c, err := types.FromReaderAt(bytes.NewReader(data), int64(len(data)))
+	a.NoError(err)
+	return c
+}
+
+func validate(a *require.Assertions, w *types.Writer) []Diagnostic {
+	data, err := w.Metadata()
+	a.NoError(err)
+
+	diags, err := Validate(buildContext(a, data), Options{Win32: true})
+	a.NoError(err)
+	return diags
+}
+
+func TestValidate(t *testing.T) {
+	a := require.New(t)
+
+	w, _ := testWriter()
+	a.Empty(validate(a, w))
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(w *types.Writer, m testModule)
+		expect Diagnostic
+	}{
+		{
+			"IndexOutOfBounds",
+			func(w *types.Writer, m testModule) {
+				(&types.NestedClass{NestedClass: m.apis, EnclosingClass: 100}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.24.2.6", Check: "index", Table: md.NestedClass, Row: 0, Column: 1},
+		},
+		{
+			"CodedIndexOutOfBounds",
+			func(w *types.Writer, m testModule) {
+				(&types.InterfaceImpl{
+					Class:     m.apis,
+					Interface: types.CreateTypeDefOrRef(md.TypeRef, 10),
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.24.2.6", Check: "coded-index", Table: md.InterfaceImpl, Row: 0, Column: 1},
+		},
+		{
+			"DuplicateTypeDef",
+			func(w *types.Writer, m testModule) {
+				(&types.TypeDef{
+					Flags:         0x181,
+					TypeName:      "Apis",
+					TypeNamespace: "Test",
+					Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.object-1),
+					FieldList:     types.List{2, 2},
+					MethodList:    types.List{1, 1},
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.22.37/19", Check: "duplicate", Table: md.TypeDef, Row: 4, Column: NoColumn},
+		},
+		{
+			"NonMonotonicList",
+			func(w *types.Writer, m testModule) {
+				(&types.TypeDef{
+					TypeName:      "Bar",
+					TypeNamespace: "Test",
+					Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.object-1),
+					FieldList:     types.List{1, 1},
+					MethodList:    types.List{1, 1},
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.22.15/2", Check: "list", Table: md.TypeDef, Row: 4, Column: 4},
+		},
+		{
+			"BadSignature",
+			func(w *types.Writer, m testModule) {
+				(&types.Field{Flags: 0x16, Name: "Broken", Signature: types.Signature{0x06}}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.23.2.4", Check: "signature", Table: md.Field, Row: 2, Column: 2},
+		},
+		{
+			"TrailingSignatureBytes",
+			func(w *types.Writer, m testModule) {
+				(&types.Field{Flags: 0x16, Name: "Trailing", Signature: types.Signature{0x06, 0x08, 0x08}}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.23.2.4", Check: "signature", Table: md.Field, Row: 2, Column: 2},
+		},
+		{
+			"BadTypeSpec",
+			func(w *types.Writer, m testModule) {
+				(&types.TypeSpec{Signature: types.Signature{0x08}}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.23.2.14", Check: "signature", Table: md.TypeSpec, Row: 0, Column: 0},
+		},
+		{
+			"BadPropertySignature",
+			func(w *types.Writer, m testModule) {
+				(&types.Property{Name: "Broken", Type: types.Signature{0x06, 0x08}}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.23.2.5", Check: "signature", Table: md.Property, Row: 0, Column: 2},
+		},
+		{
+			"UnresolvedAttributeConstructor",
+			func(w *types.Writer, m testModule) {
+				user32 := (&types.ModuleRef{Name: "USER32.dll"}).AppendTo(w)
+				ctor := (&types.MemberRef{
+					Class:     types.CreateMemberRefParent(md.ModuleRef, user32-1),
+					Name:      ".ctor",
+					Signature: types.Signature{0x20, 0x00, 0x01},
+				}).AppendTo(w)
+				(&types.CustomAttribute{
+					Parent: types.CreateHasCustomAttribute(md.TypeDef, m.apis-1),
+					Type:   types.CreateCustomAttributeType(md.MemberRef, ctor-1),
+					Value:  types.Blob{0x01, 0x00, 0x00, 0x00},
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: "II.22.10/2", Check: "attribute", Table: md.CustomAttribute, Row: 1, Column: 1},
+		},
+		{
+			"ApisWithoutImplMap",
+			func(w *types.Writer, m testModule) {
+				(&types.MethodDef{Flags: 0x96, Name: "Boop", Signature: types.Signature{0x00, 0x00, 0x01}}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: win32Rule, Check: "apis-implmap", Table: md.MethodDef, Row: 1, Column: NoColumn},
+		},
+		{
+			"EnumWithoutValue",
+			func(w *types.Writer, m testModule) {
+				(&types.TypeDef{
+					Flags:         0x101,
+					TypeName:      "Empty",
+					TypeNamespace: "Test",
+					Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.enum-1),
+					FieldList:     types.List{2, 2},
+					MethodList:    types.List{1, 1},
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Error, Rule: win32Rule, Check: "enum-value", Table: md.TypeDef, Row: 4, Column: NoColumn},
+		},
+		{
+			"InterfaceWithoutGuid",
+			func(w *types.Writer, m testModule) {
+				(&types.TypeDef{
+					Flags:         0xa1,
+					TypeName:      "IBar",
+					TypeNamespace: "Test",
+					FieldList:     types.List{2, 2},
+					MethodList:    types.List{1, 1},
+				}).AppendTo(w)
+			},
+			Diagnostic{Severity: Warning, Rule: win32Rule, Check: "interface-guid", Table: md.TypeDef, Row: 4, Column: NoColumn},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := require.New(t)
+
+			w, m := testWriter()
+			test.modify(w, m)
+
+			diags := validate(a, w)
+			a.Len(diags, 1, "%v", diags)
+
+			d := diags[0]
+			d.Message = ""
+			a.Equal(test.expect, d, diags[0].String())
+		})
+	}
+}
+
+func TestValidate_Architecture(t *testing.T) {
+	a := require.New(t)
+
+	w, m := testWriter()
+	win32 := (&types.AssemblyRef{Name: "Windows.Win32", Version: 4}).AppendTo(w)
+	attr := (&types.TypeRef{
+		ResolutionScope: types.CreateResolutionScope(md.AssemblyRef, win32-1),
+		TypeName:        "SupportedArchitectureAttribute",
+		TypeNamespace:   "Windows.Win32.Foundation.Metadata",
+	}).AppendTo(w)
+	arch := (&types.TypeRef{
+		ResolutionScope: types.CreateResolutionScope(md.AssemblyRef, win32-1),
+		TypeName:        "Architecture",
+		TypeNamespace:   "Windows.Win32.Foundation.Metadata",
+	}).AppendTo(w)
+	ctor := (&types.MemberRef{
+		Class:     types.CreateMemberRefParent(md.TypeRef, attr-1),
+		Name:      ".ctor",
+		Signature: types.Signature{0x20, 0x01, 0x01, 0x11, byte(arch<<2 | 1)},
+	}).AppendTo(w)
+	context := func(arch types.Architecture) {
+		idx := (&types.TypeDef{
+			Flags:         0x181,
+			TypeName:      "CONTEXT",
+			TypeNamespace: "Test",
+			Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.object-1),
+			FieldList:     types.List{2, 2},
+			MethodList:    types.List{1, 1},
+		}).AppendTo(w)
+		(&types.CustomAttribute{
+			Parent: types.CreateHasCustomAttribute(md.TypeDef, idx-1),
+			Type:   types.CreateCustomAttributeType(md.MemberRef, ctor-1),
+			Value:  types.Blob{0x01, 0x00, byte(arch), 0x00, 0x00, 0x00, 0x00, 0x00},
+		}).AppendTo(w)
+	}
+
+	// Definitions for different architectures are not duplicates.
+	context(types.ArchitectureX86)
+	context(types.ArchitectureX64)
+	a.Empty(validate(a, w))
+
+	// Unless Win32 conventions are not checked.
+	data, err := w.Metadata()
+	a.NoError(err)
+	diags, err := Validate(buildContext(a, data), Options{})
+	a.NoError(err)
+	a.Len(diags, 1, "%v", diags)
+	a.Equal("duplicate", diags[0].Check)
+
+	context(types.ArchitectureX64 | types.ArchitectureArm64)
+	diags = validate(a, w)
+	a.Len(diags, 1, "%v", diags)
+	a.Equal("duplicate", diags[0].Check)
+	a.Equal(uint32(6), diags[0].Row)
+
+	// Malformed attribute is reported.
+	broken := (&types.TypeDef{
+		Flags:         0x181,
+		TypeName:      "BROKEN",
+		TypeNamespace: "Test",
+		Extends:       types.CreateTypeDefOrRef(md.TypeRef, m.object-1),
+		FieldList:     types.List{2, 2},
+		MethodList:    types.List{1, 1},
+	}).AppendTo(w)
+	(&types.CustomAttribute{
+		Parent: types.CreateHasCustomAttribute(md.TypeDef, broken-1),
+		Type:   types.CreateCustomAttributeType(md.MemberRef, ctor-1),
+		Value:  types.Blob{0x01, 0x00, 0x02},
+	}).AppendTo(w)
+	diags = validate(a, w)
+	a.Len(diags, 2, "%v", diags)
+	a.Equal("architecture", diags[1].Check)
+	a.Equal(win32Rule, diags[1].Rule)
+	a.Equal(broken-1, diags[1].Row)
+}
+
+func validateTables(a *require.Assertions, b md.TablesBuilder, strings []byte, heaps ...md.Stream) []Diagnostic {
+	tables, err := b.Bytes()
+	a.NoError(err)
+
+	data := md.EncodeMetadata("v4.0.30319", append([]md.Stream{
+		{Name: "#~", Data: tables},
+		{Name: "#Strings", Data: strings},
+	}, heaps...)...)
+
+	diags, err := Validate(buildContext(a, data), Options{})
+	a.NoError(err)
+	return diags
+}
+
+func TestValidate_Tables(t *testing.T) {
+	strings := []byte("\x00<Module>\x00A\x00B\x00\x00\x00\x00")
+
+	t.Run("Unsorted", func(t *testing.T) {
+		a := require.New(t)
+
+		var b md.TablesBuilder
+		b.Sorted = 1 << md.NestedClass
+		b.Rows[md.Module] = []md.RowValues{{0, 1}}
+		b.Rows[md.TypeDef] = []md.RowValues{
+			{0, 1, 0, 0, 1, 1},
+			{0, 10, 0, 0, 1, 1},
+			{0, 12, 0, 0, 1, 1},
+		}
+		b.Rows[md.NestedClass] = []md.RowValues{
+			{3, 2},
+			{2, 1},
+		}
+
+		diags := validateTables(a, b, strings)
+		a.Equal([]Diagnostic{
+			{
+				Severity: Error,
+				Rule:     "II.24.2.6",
+				Check:    "sorted",
+				Table:    md.NestedClass,
+				Row:      1,
+				Column:   0,
+				Message:  "table is marked as sorted, but row key [2] is lower than previous [3]",
+			},
+		}, diags)
+	})
+	t.Run("DuplicateNested", func(t *testing.T) {
+		a := require.New(t)
+
+		var b md.TablesBuilder
+		b.Rows[md.Module] = []md.RowValues{{0, 1}}
+		b.Rows[md.TypeDef] = []md.RowValues{
+			{0, 1, 0, 0, 1, 1},
+			{0, 10, 0, 0, 1, 1},
+			{0, 12, 0, 0, 1, 1},
+			{0, 12, 0, 0, 1, 1},
+		}
+		b.Rows[md.NestedClass] = []md.RowValues{
+			{3, 2},
+			{4, 2},
+		}
+
+		diags := validateTables(a, b, strings)
+		a.Len(diags, 1)
+		a.Equal("duplicate", diags[0].Check)
+		a.Equal("II.22.37/20", diags[0].Rule)
+		a.Equal(uint32(3), diags[0].Row)
+	})
+	t.Run("StringOutOfBounds", func(t *testing.T) {
+		a := require.New(t)
+
+		var b md.TablesBuilder
+		b.Rows[md.Module] = []md.RowValues{{0, 100}}
+
+		diags := validateTables(a, b, strings)
+		a.Len(diags, 1)
+		a.Equal("string-heap", diags[0].Check)
+		a.Equal(md.Module, diags[0].Table)
+		a.Equal(1, diags[0].Column)
+	})
+	t.Run("InvalidBlobLength", func(t *testing.T) {
+		a := require.New(t)
+
+		var b md.TablesBuilder
+		b.Rows[md.Module] = []md.RowValues{{0, 1}}
+		b.Rows[md.Field] = []md.RowValues{{0x16, 10, 1}}
+
+		// 111xxxxx is not a valid compressed length.
+		blobs := md.Stream{Name: "#Blob", Data: []byte{0x00, 0xe0, 0x00, 0x00, 0x00}}
+		diags := validateTables(a, b, strings, blobs)
+		a.Len(diags, 1)
+		a.Equal("blob-heap", diags[0].Check)
+		a.Equal(md.Field, diags[0].Table)
+		a.Equal(2, diags[0].Column)
+	})
+	t.Run("ListOutOfBounds", func(t *testing.T) {
+		a := require.New(t)
+
+		var b md.TablesBuilder
+		b.Rows[md.Module] = []md.RowValues{{0, 1}}
+		b.Rows[md.TypeDef] = []md.RowValues{
+			{0, 1, 0, 0, 1, 5},
+		}
+
+		diags := validateTables(a, b, strings)
+		a.Len(diags, 1)
+		a.Equal("list", diags[0].Check)
+		a.Equal(5, diags[0].Column)
+	})
+}
+
+func TestDiagnostic_JSON(t *testing.T) {
+	a := require.New(t)
+
+	d := Diagnostic{
+		Severity: Error,
+		Rule:     "II.22.37/19",
+		Check:    "duplicate",
+		Table:    md.TypeDef,
+		Row:      4,
+		Column:   NoColumn,
+		Message:  "duplicate TypeDef Test.Apis, first defined at row 3",
+	}
+	data, err := json.Marshal(d)
+	a.NoError(err)
+	a.JSONEq(`{
+		"severity": "error",
+		"rule": "II.22.37/19",
+		"check": "duplicate",
+		"table": "TypeDef",
+		"row": 4,
+		"column": -1,
+		"message": "duplicate TypeDef Test.Apis, first defined at row 3"
+	}`, string(data))
+	a.Equal("TypeDef(4): error: duplicate TypeDef Test.Apis, first defined at row 3 [II.22.37/19 duplicate]", d.String())
+
+	// Stream diagnostic has no table location.
+	d = Diagnostic{
+		Severity: Error,
+		Rule:     "II.24.2.2",
+		Check:    "stream-bounds",
+		Stream:   "#Blob",
+		Column:   NoColumn,
+		Message:  "stream [108:1132] is out of metadata bounds",
+	}
+	data, err = json.Marshal(d)
+	a.NoError(err)
+	a.JSONEq(`{
+		"severity": "error",
+		"rule": "II.24.2.2",
+		"check": "stream-bounds",
+		"stream": "#Blob",
+		"message": "stream [108:1132] is out of metadata bounds"
+	}`, string(data))
+	a.Equal("#Blob: error: stream [108:1132] is out of metadata bounds [II.24.2.2 stream-bounds]", d.String())
+}
@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// win32Rule is a Diagnostic.Rule of Win32 metadata conventions.
+const win32Rule = "Win32"
+
+func (v *validator) reportWin32(severity Severity, check string, tt md.TableType, row uint32, format string, args ...interface{}) {
+	v.report(Diagnostic{
+		Severity: severity,
+		Rule:     win32Rule,
+		Check:    check,
+		Table:    tt,
+		Row:      row,
+		Column:   NoColumn,
+	}, format, args...)
+}
+
+// checkWin32 checks Win32 metadata conventions:
+//
+//   - methods of "Apis" classes are P/Invoke methods with ImplMap
+//   - enums have "value__" as their first field
+//   - interfaces have GuidAttribute
+//
+// Rows which can't be decoded are skipped, they are reported by other checks.
+func (v *validator) checkWin32() error {
+	table := v.c.Table(md.TypeDef)
+
+	var def types.TypeDef
+	for row := uint32(0); row < table.RowCount(); row++ {
+		if err := def.FromRow(table.Row(row)); err != nil {
+			continue
+		}
+
+		switch {
+		case def.TypeName == "Apis":
+			v.checkApis(def)
+		case def.Flags.Interface():
+			v.checkInterface(row, def)
+		default:
+			if namespace, name, err := v.c.ResolveTypeDefOrRefName(def.Extends); err == nil &&
+				namespace == "System" && name == "Enum" {
+				v.checkEnum(row, def)
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) checkApis(def types.TypeDef) {
+	var method types.MethodDef
+	for i := def.MethodList.Start(); i < def.MethodList.End(); i++ {
+		idx, err := v.c.ListIndex(md.MethodDef, i)
+		if err != nil {
+			continue
+		}
+		if err := method.FromRow(v.c.Table(md.MethodDef).Row(idx)); err != nil {
+			continue
+		}
+
+		_, ok, err := v.c.ImplMap(types.CreateMemberForwarded(md.MethodDef, idx))
+		if err != nil || ok {
+			continue
+		}
+		v.reportWin32(Error, "apis-implmap", md.MethodDef, idx,
+			"method %s.%s.%s has no ImplMap", def.TypeNamespace, def.TypeName, method.Name)
+	}
+}
+
+func (v *validator) checkEnum(row uint32, def types.TypeDef) {
+	if def.FieldList.Empty() {
+		v.reportWin32(Error, "enum-value", md.TypeDef, row,
+			"enum %s.%s has no fields", def.TypeNamespace, def.TypeName)
+		return
+	}
+
+	idx, err := v.c.ListIndex(md.Field, def.FieldList.Start())
+	if err != nil {
+		return
+	}
+	var field types.Field
+	if err := field.FromRow(v.c.Table(md.Field).Row(idx)); err != nil {
+		return
+	}
+
+	if field.Name != "value__" || field.Flags.Static() {
+		v.reportWin32(Error, "enum-value", md.TypeDef, row,
+			"first field of enum %s.%s is %q, expected instance field \"value__\"",
+			def.TypeNamespace, def.TypeName, field.Name)
+	}
+}
+
+func (v *validator) checkInterface(row uint32, def types.TypeDef) {
+	attrs, err := v.c.CustomAttributes(types.CreateHasCustomAttribute(md.TypeDef, row))
+	if err != nil {
+		return
+	}
+
+	for _, attr := range attrs {
+		if _, name, _, err := attr.ResolveConstructor(v.c); err == nil && name == "GuidAttribute" {
+			return
+		}
+	}
+	v.reportWin32(Warning, "interface-guid", md.TypeDef, row,
+		"interface %s.%s has no GuidAttribute", def.TypeNamespace, def.TypeName)
+}
@@ -0,0 +1,156 @@
+// Package win32 decodes Win32 metadata attributes which describe C semantics of APIs.
+package win32
+
+import (
+	"fmt"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Namespace is a namespace of Win32 metadata attributes.
+const Namespace = "Windows.Win32.Foundation.Metadata"
+
+// Decoder decodes Win32 metadata attributes of metadata entities.
+type Decoder struct {
+	// Attributes is used to decode attribute values.
+	Attributes types.CustomAttributeDecoder
+}
+
+// decodeAll finds all attributes of given table row and calls cb for each of them.
+func (d Decoder) decodeAll(
+	c *types.Context,
+	tt md.TableType,
+	idx types.Index,
+	cb func(a types.NamedAttribute, value func() (types.CustomAttributeValue, error)) error,
+) error {
+	attrs, err := c.NamedAttributes(types.CreateHasCustomAttribute(tt, idx))
+	if err != nil {
+		return err
+	}
+
+	for _, a := range attrs {
+		value := func() (types.CustomAttributeValue, error) {
+			return d.Attributes.Decode(c, &a.Row)
+		}
+		if err := cb(a, value); err != nil {
+			return fmt.Errorf("%s: %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+// fixedArg returns i-th fixed argument of attribute.
+func fixedArg[T any](v types.CustomAttributeValue, i int) (T, error) {
+	var zero T
+	if i >= len(v.Fixed) {
+		return zero, fmt.Errorf("expected at least %d arguments, got %d", i+1, len(v.Fixed))
+	}
+
+	r, ok := v.Fixed[i].Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("argument %d: unexpected type %T", i, v.Fixed[i].Value)
+	}
+	return r, nil
+}
+
+// namedArg returns named argument of attribute. If argument is not set, ok is false.
+func namedArg[T any](v types.CustomAttributeValue, name string) (_ T, ok bool, _ error) {
+	var zero T
+	for _, arg := range v.Named {
+		if arg.Name != name {
+			continue
+		}
+
+		r, ok := arg.Value.(T)
+		if !ok {
+			return zero, false, fmt.Errorf("argument %q: unexpected type %T", name, arg.Value)
+		}
+		return r, true, nil
+	}
+	return zero, false, nil
+}
+
+// stringValue decodes attribute with single string argument.
+func stringValue(value func() (types.CustomAttributeValue, error)) (string, error) {
+	v, err := value()
+	if err != nil {
+		return "", err
+	}
+	return fixedArg[string](v, 0)
+}
+
+// NativeArrayInfo describes length of C array.
+type NativeArrayInfo struct {
+	// CountConst is a constant length of array, -1 if not set.
+	CountConst int32
+	// CountParamIndex is an index of parameter which contains length of array, -1 if not set.
+	CountParamIndex int16
+	// CountFieldName is a name of struct field which contains length of array.
+	CountFieldName string
+}
+
+func decodeNativeArrayInfo(v types.CustomAttributeValue) (*NativeArrayInfo, error) {
+	info := &NativeArrayInfo{
+		CountConst:      -1,
+		CountParamIndex: -1,
+	}
+
+	countConst, ok, err := namedArg[int32](v, "CountConst")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		info.CountConst = countConst
+	}
+
+	countParam, ok, err := namedArg[int16](v, "CountParamIndex")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		info.CountParamIndex = countParam
+	}
+
+	if info.CountFieldName, _, err = namedArg[string](v, "CountFieldName"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// MemorySize describes size of buffer in bytes.
+type MemorySize struct {
+	// BytesParamIndex is an index of parameter which contains size of buffer.
+	BytesParamIndex int16
+}
+
+func decodeMemorySize(v types.CustomAttributeValue) (*MemorySize, error) {
+	idx, ok, err := namedArg[int16](v, "BytesParamIndex")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("BytesParamIndex is not set")
+	}
+	return &MemorySize{BytesParamIndex: idx}, nil
+}
+
+// NativeBitfield describes C bitfield packed into struct field.
+type NativeBitfield struct {
+	Name   string
+	Offset int64
+	Length int64
+}
+
+func decodeNativeBitfield(v types.CustomAttributeValue) (b NativeBitfield, err error) {
+	if b.Name, err = fixedArg[string](v, 0); err != nil {
+		return b, err
+	}
+	if b.Offset, err = fixedArg[int64](v, 1); err != nil {
+		return b, err
+	}
+	if b.Length, err = fixedArg[int64](v, 2); err != nil {
+		return b, err
+	}
+	return b, nil
+}
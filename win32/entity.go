@@ -0,0 +1,270 @@
+package win32
+
+import (
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// Type contains Win32 metadata attributes of TypeDef.
+type Type struct {
+	// NativeTypedef denotes that struct is a C typedef, e.g. HANDLE or HWND.
+	NativeTypedef bool
+	// MetadataTypedef denotes that struct is a typedef introduced by metadata.
+	MetadataTypedef bool
+	// RAIIFree is a name of function which frees handle.
+	RAIIFree string
+	// InvalidHandleValues contains values of invalid handle, e.g. 0 and -1.
+	InvalidHandleValues []int64
+	// AlsoUsableFor contains names of typedefs which can be used instead of this one.
+	AlsoUsableFor []string
+	// StructSizeField is a name of field which must be set to size of struct.
+	StructSizeField string
+	// Flags denotes that enum is a bit flags set.
+	Flags bool
+	// ScopedEnum denotes that enum values are not visible in enclosing scope.
+	ScopedEnum bool
+	Ansi       bool
+	Unicode    bool
+	Agile      bool
+	// SupportedOSPlatform is a minimal supported platform, e.g. "windows8.0".
+	SupportedOSPlatform string
+}
+
+// Type decodes attributes of TypeDef.
+func (d Decoder) Type(c *types.Context, idx types.Index) (t Type, _ error) {
+	err := d.decodeAll(c, md.TypeDef, idx, func(a types.NamedAttribute, value func() (types.CustomAttributeValue, error)) (err error) {
+		if a.Namespace == "System" && a.Name == "FlagsAttribute" {
+			t.Flags = true
+			return nil
+		}
+		if a.Namespace != Namespace {
+			return nil
+		}
+
+		switch a.Name {
+		case "NativeTypedefAttribute":
+			t.NativeTypedef = true
+		case "MetadataTypedefAttribute":
+			t.MetadataTypedef = true
+		case "RAIIFreeAttribute":
+			t.RAIIFree, err = stringValue(value)
+		case "InvalidHandleValueAttribute":
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			h, err := fixedArg[int64](v, 0)
+			if err != nil {
+				return err
+			}
+			t.InvalidHandleValues = append(t.InvalidHandleValues, h)
+		case "AlsoUsableForAttribute":
+			s, err := stringValue(value)
+			if err != nil {
+				return err
+			}
+			t.AlsoUsableFor = append(t.AlsoUsableFor, s)
+		case "StructSizeFieldAttribute":
+			t.StructSizeField, err = stringValue(value)
+		case "ScopedEnumAttribute":
+			t.ScopedEnum = true
+		case "AnsiAttribute":
+			t.Ansi = true
+		case "UnicodeAttribute":
+			t.Unicode = true
+		case "AgileAttribute":
+			t.Agile = true
+		case "SupportedOSPlatformAttribute":
+			t.SupportedOSPlatform, err = stringValue(value)
+		}
+		return err
+	})
+	return t, err
+}
+
+// Field contains Win32 metadata attributes of Field.
+type Field struct {
+	// NativeArrayInfo describes length of array field, nil if not set.
+	NativeArrayInfo *NativeArrayInfo
+	// Const denotes that field points to constant data.
+	Const bool
+	// FlexibleArray denotes that field is a C flexible array member.
+	FlexibleArray bool
+	// Bitfields contains bitfields packed into field.
+	Bitfields          []NativeBitfield
+	NotNullTerminated  bool
+	NullNullTerminated bool
+	// AssociatedEnum is a name of enum which contains values of field.
+	AssociatedEnum string
+	// Constant is a string representation of constant value, which can't be stored
+	// in Constant table, e.g. GUID or struct.
+	Constant string
+}
+
+// Field decodes attributes of Field.
+func (d Decoder) Field(c *types.Context, idx types.Index) (f Field, _ error) {
+	err := d.decodeAll(c, md.Field, idx, func(a types.NamedAttribute, value func() (types.CustomAttributeValue, error)) (err error) {
+		if a.Namespace != Namespace {
+			return nil
+		}
+
+		switch a.Name {
+		case "NativeArrayInfoAttribute":
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			f.NativeArrayInfo, err = decodeNativeArrayInfo(v)
+			return err
+		case "ConstAttribute":
+			f.Const = true
+		case "FlexibleArrayAttribute":
+			f.FlexibleArray = true
+		case "NativeBitfieldAttribute":
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			b, err := decodeNativeBitfield(v)
+			if err != nil {
+				return err
+			}
+			f.Bitfields = append(f.Bitfields, b)
+		case "NotNullTerminatedAttribute":
+			f.NotNullTerminated = true
+		case "NullNullTerminatedAttribute":
+			f.NullNullTerminated = true
+		case "AssociatedEnumAttribute":
+			f.AssociatedEnum, err = stringValue(value)
+		case "ConstantAttribute":
+			f.Constant, err = stringValue(value)
+		}
+		return err
+	})
+	return f, err
+}
+
+// Method contains Win32 metadata attributes of MethodDef.
+type Method struct {
+	Ansi    bool
+	Unicode bool
+	// SupportedOSPlatform is a minimal supported platform, e.g. "windows8.0".
+	SupportedOSPlatform string
+	// DoesNotReturn denotes that function never returns, e.g. ExitProcess.
+	DoesNotReturn bool
+	// CanReturnMultipleSuccessValues denotes that function can return
+	// success HRESULT other than S_OK.
+	CanReturnMultipleSuccessValues bool
+	// CanReturnErrorsAsSuccess denotes that function can return error HRESULT on success.
+	CanReturnErrorsAsSuccess bool
+}
+
+// Method decodes attributes of MethodDef.
+func (d Decoder) Method(c *types.Context, idx types.Index) (m Method, _ error) {
+	err := d.decodeAll(c, md.MethodDef, idx, func(a types.NamedAttribute, value func() (types.CustomAttributeValue, error)) (err error) {
+		if a.Namespace == "System.Diagnostics.CodeAnalysis" && a.Name == "DoesNotReturnAttribute" {
+			m.DoesNotReturn = true
+			return nil
+		}
+		if a.Namespace != Namespace {
+			return nil
+		}
+
+		switch a.Name {
+		case "AnsiAttribute":
+			m.Ansi = true
+		case "UnicodeAttribute":
+			m.Unicode = true
+		case "SupportedOSPlatformAttribute":
+			m.SupportedOSPlatform, err = stringValue(value)
+		case "CanReturnMultipleSuccessValuesAttribute":
+			m.CanReturnMultipleSuccessValues = true
+		case "CanReturnErrorsAsSuccessAttribute":
+			m.CanReturnErrorsAsSuccess = true
+		}
+		return err
+	})
+	return m, err
+}
+
+// Param contains Win32 metadata attributes of Param.
+type Param struct {
+	// NativeArrayInfo describes length of array parameter, nil if not set.
+	NativeArrayInfo *NativeArrayInfo
+	// MemorySize describes size of buffer parameter, nil if not set.
+	MemorySize *MemorySize
+	// Const denotes that parameter points to constant data.
+	Const bool
+	// ComOutPtr denotes that parameter is a COM out pointer, which is set to nil on failure.
+	ComOutPtr bool
+	// RetVal denotes that parameter is a return value.
+	RetVal bool
+	// Reserved denotes that parameter is reserved and must be zero.
+	Reserved bool
+	// DoNotRelease denotes that returned object must not be released by caller.
+	DoNotRelease bool
+	// Retained denotes that callee keeps reference to parameter after return.
+	Retained           bool
+	NotNullTerminated  bool
+	NullNullTerminated bool
+	// FreeWith is a name of function which frees returned value.
+	FreeWith string
+	// AssociatedEnum is a name of enum which contains values of parameter.
+	AssociatedEnum string
+	// IgnoreIfReturn contains return values, on which parameter value must be ignored.
+	IgnoreIfReturn []string
+}
+
+// Param decodes attributes of Param.
+func (d Decoder) Param(c *types.Context, idx types.Index) (p Param, _ error) {
+	err := d.decodeAll(c, md.Param, idx, func(a types.NamedAttribute, value func() (types.CustomAttributeValue, error)) (err error) {
+		if a.Namespace != Namespace {
+			return nil
+		}
+
+		switch a.Name {
+		case "NativeArrayInfoAttribute":
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			p.NativeArrayInfo, err = decodeNativeArrayInfo(v)
+			return err
+		case "MemorySizeAttribute":
+			v, err := value()
+			if err != nil {
+				return err
+			}
+			p.MemorySize, err = decodeMemorySize(v)
+			return err
+		case "ConstAttribute":
+			p.Const = true
+		case "ComOutPtrAttribute":
+			p.ComOutPtr = true
+		case "RetValAttribute":
+			p.RetVal = true
+		case "ReservedAttribute":
+			p.Reserved = true
+		case "DoNotReleaseAttribute":
+			p.DoNotRelease = true
+		case "RetainedAttribute":
+			p.Retained = true
+		case "NotNullTerminatedAttribute":
+			p.NotNullTerminated = true
+		case "NullNullTerminatedAttribute":
+			p.NullNullTerminated = true
+		case "FreeWithAttribute":
+			p.FreeWith, err = stringValue(value)
+		case "AssociatedEnumAttribute":
+			p.AssociatedEnum, err = stringValue(value)
+		case "IgnoreIfReturnAttribute":
+			s, err := stringValue(value)
+			if err != nil {
+				return err
+			}
+			p.IgnoreIfReturn = append(p.IgnoreIfReturn, s)
+		}
+		return err
+	})
+	return p, err
+}
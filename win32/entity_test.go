@@ -0,0 +1,252 @@
+package win32
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tdakkota/win32metadata/md"
+	"github.com/tdakkota/win32metadata/types"
+)
+
+// blob builds custom attribute value.
+type blob []byte
+
+func newBlob() blob {
+	return blob{0x01, 0x00}
+}
+
+func (b blob) string(s string) blob {
+	return append(append(b, byte(len(s))), s...)
+}
+
+func (b blob) int64(v int64) blob {
+	return binary.LittleEndian.AppendUint64(b, uint64(v))
+}
+
+// named starts NamedArgs with given count.
+func (b blob) named(n uint16) blob {
+	return binary.LittleEndian.AppendUint16(b, n)
+}
+
+// field appends named field argument, value must be already encoded.
+func (b blob) field(typ types.ElementTypeKind, name string, value ...byte) blob {
+	b = append(b, 0x53, byte(typ))
+	return append(b.string(name), value...)
+}
+
+type testWriter struct {
+	*types.Writer
+	mscorlib types.Index
+	ctors    map[string]types.Index
+}
+
+func (w *testWriter) attr(parent types.HasCustomAttribute, namespace, name string, ctor types.Signature, value blob) {
+	key := namespace + "." + name
+	idx, ok := w.ctors[key]
+	if !ok {
+		ref := (&types.TypeRef{
+			ResolutionScope: types.CreateResolutionScope(md.AssemblyRef, w.mscorlib-1),
+			TypeName:        name,
+			TypeNamespace:   namespace,
+		}).AppendTo(w.Writer)
+		idx = (&types.MemberRef{
+			Class:     types.CreateMemberRefParent(md.TypeRef, ref-1),
+			Name:      ".ctor",
+			Signature: ctor,
+		}).AppendTo(w.Writer)
+		w.ctors[key] = idx
+	}
+
+	if value == nil {
+		value = newBlob().named(0)
+	}
+	(&types.CustomAttribute{
+		Parent: parent,
+		Type:   types.CreateCustomAttributeType(md.MemberRef, idx-1),
+		Value:  types.Blob(value),
+	}).AppendTo(w.Writer)
+}
+
+var (
+	noArgs    = types.Signature{0x20, 0x00, 0x01}
+	stringArg = types.Signature{0x20, 0x01, 0x01, 0x0e}
+	int64Arg  = types.Signature{0x20, 0x01, 0x01, 0x0a}
+)
+
+func testContext(a *require.Assertions) *types.Context {
+	w := &testWriter{Writer: types.NewWriter(), ctors: map[string]types.Index{}}
+	(&types.Module{Name: "Windows.Win32.winmd"}).AppendTo(w.Writer)
+	w.mscorlib = (&types.AssemblyRef{Name: "mscorlib", Version: 4}).AppendTo(w.Writer)
+
+	typeDef := func(name string) types.HasCustomAttribute {
+		idx := (&types.TypeDef{
+			Flags:         0x109,
+			TypeName:      name,
+			TypeNamespace: "Windows.Win32.Foundation",
+			FieldList:     types.List{w.RowCount(md.Field), w.RowCount(md.Field)},
+			MethodList:    types.List{w.RowCount(md.MethodDef), w.RowCount(md.MethodDef)},
+		}).AppendTo(w.Writer)
+		return types.CreateHasCustomAttribute(md.TypeDef, idx-1)
+	}
+	field := func(name string) types.HasCustomAttribute {
+		idx := (&types.Field{Flags: 0x6, Name: name, Signature: types.Signature{0x06, 0x08}}).AppendTo(w.Writer)
+		return types.CreateHasCustomAttribute(md.Field, idx-1)
+	}
+
+	// HANDLE
+	handle := typeDef("HANDLE")
+	field("Value")
+	w.attr(handle, Namespace, "NativeTypedefAttribute", noArgs, nil)
+	w.attr(handle, Namespace, "RAIIFreeAttribute", stringArg, newBlob().string("CloseHandle").named(0))
+	w.attr(handle, Namespace, "InvalidHandleValueAttribute", int64Arg, newBlob().int64(-1).named(0))
+	w.attr(handle, Namespace, "InvalidHandleValueAttribute", int64Arg, newBlob().int64(0).named(0))
+	w.attr(handle, Namespace, "AlsoUsableForAttribute", stringArg, newBlob().string("HINSTANCE").named(0))
+
+	// FILE_ACCESS_RIGHTS
+	rights := typeDef("FILE_ACCESS_RIGHTS")
+	w.attr(rights, "System", "FlagsAttribute", noArgs, nil)
+	w.attr(rights, Namespace, "ScopedEnumAttribute", noArgs, nil)
+
+	// BITMAPINFO
+	info := typeDef("BITMAPINFO")
+	w.attr(info, Namespace, "StructSizeFieldAttribute", stringArg, newBlob().string("bmiHeader").named(0))
+	colors := field("bmiColors")
+	w.attr(colors, Namespace, "FlexibleArrayAttribute", noArgs, nil)
+	name := field("szName")
+	w.attr(name, Namespace, "ConstAttribute", noArgs, nil)
+	w.attr(name, Namespace, "NativeArrayInfoAttribute", noArgs, newBlob().named(1).
+		field(types.ELEMENT_TYPE_I4, "CountConst", 32, 0, 0, 0))
+	flags := field("_bitfield")
+	w.attr(flags, Namespace, "NativeBitfieldAttribute", types.Signature{0x20, 0x03, 0x01, 0x0e, 0x0a, 0x0a},
+		newBlob().string("fBinary").int64(0).int64(1).named(0))
+	w.attr(flags, Namespace, "NativeBitfieldAttribute", types.Signature{0x20, 0x03, 0x01, 0x0e, 0x0a, 0x0a},
+		newBlob().string("fParity").int64(1).int64(2).named(0))
+
+	// Apis
+	typeDef("Apis")
+	method := types.CreateHasCustomAttribute(md.MethodDef, (&types.MethodDef{
+		Flags:     0x2096,
+		Name:      "ReadFile",
+		Signature: types.Signature{0x00, 0x03, 0x08, 0x18, 0x0f, 0x01, 0x09},
+	}).AppendTo(w.Writer)-1)
+	w.attr(method, Namespace, "UnicodeAttribute", noArgs, nil)
+	w.attr(method, Namespace, "SupportedOSPlatformAttribute", stringArg, newBlob().string("windows5.1.2600").named(0))
+	w.attr(method, "System.Diagnostics.CodeAnalysis", "DoesNotReturnAttribute", noArgs, nil)
+
+	param := func(seq uint16, name string) types.HasCustomAttribute {
+		idx := (&types.Param{Sequence: seq, Name: name}).AppendTo(w.Writer)
+		return types.CreateHasCustomAttribute(md.Param, idx-1)
+	}
+	ret := param(0, "")
+	w.attr(ret, Namespace, "FreeWithAttribute", stringArg, newBlob().string("LocalFree").named(0))
+	file := param(1, "hFile")
+	w.attr(file, Namespace, "ConstAttribute", noArgs, nil)
+	buffer := param(2, "lpBuffer")
+	w.attr(buffer, Namespace, "NativeArrayInfoAttribute", noArgs, newBlob().named(1).
+		field(types.ELEMENT_TYPE_I2, "CountParamIndex", 2, 0))
+	w.attr(buffer, Namespace, "MemorySizeAttribute", noArgs, newBlob().named(1).
+		field(types.ELEMENT_TYPE_I2, "BytesParamIndex", 2, 0))
+	size := param(3, "nNumberOfBytesToRead")
+	w.attr(size, Namespace, "ComOutPtrAttribute", noArgs, nil)
+	w.attr(size, Namespace, "RetValAttribute", noArgs, nil)
+	w.attr(size, Namespace, "ReservedAttribute", noArgs, nil)
+	w.attr(size, Namespace, "IgnoreIfReturnAttribute", stringArg, newBlob().string("S_FALSE").named(0))
+
+	data, err := w.Metadata()
+	a.NoError(err)
+	c, err := types.FromBytes(data)
+	a.NoError(err)
+	return c
+}
+
+func TestDecoder_Type(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a)
+	var d Decoder
+
+	handle, err := d.Type(c, 0)
+	a.NoError(err)
+	a.Equal(Type{
+		NativeTypedef:       true,
+		RAIIFree:            "CloseHandle",
+		InvalidHandleValues: []int64{-1, 0},
+		AlsoUsableFor:       []string{"HINSTANCE"},
+	}, handle)
+
+	rights, err := d.Type(c, 1)
+	a.NoError(err)
+	a.Equal(Type{Flags: true, ScopedEnum: true}, rights)
+
+	info, err := d.Type(c, 2)
+	a.NoError(err)
+	a.Equal(Type{StructSizeField: "bmiHeader"}, info)
+}
+
+func TestDecoder_Field(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a)
+	var d Decoder
+
+	value, err := d.Field(c, 0)
+	a.NoError(err)
+	a.Equal(Field{}, value)
+
+	colors, err := d.Field(c, 1)
+	a.NoError(err)
+	a.Equal(Field{FlexibleArray: true}, colors)
+
+	name, err := d.Field(c, 2)
+	a.NoError(err)
+	a.Equal(Field{
+		NativeArrayInfo: &NativeArrayInfo{CountConst: 32, CountParamIndex: -1},
+		Const:           true,
+	}, name)
+
+	flags, err := d.Field(c, 3)
+	a.NoError(err)
+	a.Equal(Field{Bitfields: []NativeBitfield{
+		{Name: "fBinary", Offset: 0, Length: 1},
+		{Name: "fParity", Offset: 1, Length: 2},
+	}}, flags)
+}
+
+func TestDecoder_Method(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a)
+	var d Decoder
+
+	m, err := d.Method(c, 0)
+	a.NoError(err)
+	a.Equal(Method{
+		Unicode:             true,
+		SupportedOSPlatform: "windows5.1.2600",
+		DoesNotReturn:       true,
+	}, m)
+}
+
+func TestDecoder_Param(t *testing.T) {
+	a := require.New(t)
+	c := testContext(a)
+	var d Decoder
+
+	for i, expected := range []Param{
+		{FreeWith: "LocalFree"},
+		{Const: true},
+		{
+			NativeArrayInfo: &NativeArrayInfo{CountConst: -1, CountParamIndex: 2},
+			MemorySize:      &MemorySize{BytesParamIndex: 2},
+		},
+		{
+			ComOutPtr:      true,
+			RetVal:         true,
+			Reserved:       true,
+			IgnoreIfReturn: []string{"S_FALSE"},
+		},
+	} {
+		p, err := d.Param(c, types.Index(i))
+		a.NoError(err)
+		a.Equal(expected, p, i)
+	}
+}